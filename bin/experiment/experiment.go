@@ -2,6 +2,10 @@ package main
 
 import (
 	"flag"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 	// Uncomment to load all auth plugins
 	// _ "k8s.io/client-go/plugin/pkg/client/auth"
 
@@ -44,6 +48,7 @@ import (
 	podNetworkLatency "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-latency/experiment"
 	podNetworkLoss "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-loss/experiment"
 	podNetworkPartition "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-partition/experiment"
+	podNetworkRateLimit "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-rate-limit/experiment"
 	kafkaBrokerPodFailure "github.com/litmuschaos/litmus-go/experiments/kafka/kafka-broker-pod-failure/experiment"
 	ebsLossByID "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss-by-id/experiment"
 	ebsLossByTag "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss-by-tag/experiment"
@@ -52,25 +57,39 @@ import (
 	vmpoweroff "github.com/litmuschaos/litmus-go/experiments/vmware/vm-poweroff/experiment"
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/experiment"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/sirupsen/logrus"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
 )
 
 func init() {
-	// Log as JSON instead of the default ASCII formatter.
-	logrus.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:          true,
-		DisableSorting:         true,
-		DisableLevelTruncation: true,
-	})
+	// configure the log formatter, level and the default fields from the env
+	log.Init()
 }
 
 func main() {
 
+	// run the experiment from the local file, if the run subcommand is provided
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		run := runCommand()
+		run.SetArgs(os.Args[2:])
+		if err := run.Execute(); err != nil {
+			log.Errorf("Unable to run the experiment, err: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	clients := clients.ClientSets{}
 
 	// parse the experiment name
 	experimentName := flag.String("name", "pod-delete", "name of the chaos experiment")
+	dryRun := flag.Bool("dry-run", false, "derive the plan of the chaos experiment, without injecting the chaos (supported experiments: "+strings.Join(experiment.DryRunSupported(), ", ")+")")
+	configFile := flag.String("config", "", "path of the yaml or json file, which contains the env of the chaos experiment")
+	overrides := envFlags{}
+	flag.Var(&overrides, "set", "env of the chaos experiment as KEY=VALUE, it takes precedence over the env and config file (can be repeated)")
 
 	//Getting kubeConfig and Generate ClientSets
 	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
@@ -78,8 +97,31 @@ func main() {
 		return
 	}
 
+	// experiment name is added to the log entries, if it is not provided through the env
+	if log.GetField(log.ExperimentField) == "" {
+		log.SetField(log.ExperimentField, *experimentName)
+	}
 	log.Infof("Experiment Name: %v", *experimentName)
 
+	// the flags are propagated through the env, which is read by the experiments
+	if *dryRun {
+		os.Setenv("DRY_RUN", "true")
+	}
+	if *configFile != "" {
+		os.Setenv(config.FileEnv, *configFile)
+	}
+	for key, value := range overrides {
+		os.Setenv(key, value)
+	}
+	if isDryRun, _ := strconv.ParseBool(os.Getenv("DRY_RUN")); isDryRun && !experiment.SupportsDryRun(*experimentName) {
+		log.Errorf("Dry-run is not supported by the %v experiment, supported experiments: %v", *experimentName, experiment.DryRunSupported())
+		return
+	}
+
+	// serve the experiment metrics, if the metrics address is provided
+	metrics.Serve(os.Getenv("METRICS_ADDRESS"))
+	defer waitForMetricsScrape()
+
 	// invoke the corresponding experiment based on the the (-name) flag
 	switch *experimentName {
 	case "container-kill":
@@ -122,6 +164,8 @@ func main() {
 		podNetworkLoss.PodNetworkLoss(clients)
 	case "pod-network-partition":
 		podNetworkPartition.PodNetworkPartition(clients)
+	case "pod-network-rate-limit":
+		podNetworkRateLimit.PodNetworkRateLimit(clients)
 	case "pod-memory-hog":
 		podMemoryHog.PodMemoryHog(clients)
 	case "pod-cpu-hog":
@@ -166,3 +210,38 @@ func main() {
 		return
 	}
 }
+
+// envFlags contains the env provided through the repeated -set KEY=VALUE flags
+type envFlags map[string]string
+
+// String returns the env in the KEY=VALUE format
+func (env envFlags) String() string {
+	values := []string{}
+	for key, value := range env {
+		values = append(values, key+"="+value)
+	}
+	return strings.Join(values, ",")
+}
+
+// Set parse and add the KEY=VALUE env
+func (env envFlags) Set(value string) error {
+	index := strings.Index(value, "=")
+	if index <= 0 {
+		return errors.Errorf("%q should be in the KEY=VALUE format", value)
+	}
+	env[value[:index]] = value[index+1:]
+	return nil
+}
+
+// waitForMetricsScrape keeps the metrics endpoint alive for the given duration (in sec) after the experiment completion
+// so that the final values of the metrics can be scraped
+func waitForMetricsScrape() {
+	if os.Getenv("METRICS_ADDRESS") == "" {
+		return
+	}
+	wait, _ := strconv.Atoi(os.Getenv("METRICS_SCRAPE_WAIT"))
+	if wait > 0 {
+		log.Infof("[Metrics]: Waiting for %vs for the final scrape of the metrics", wait)
+		time.Sleep(time.Duration(wait) * time.Second)
+	}
+}
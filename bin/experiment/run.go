@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/experiment"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// runCommand runs the experiment defined inside the local file, without the chaosengine
+func runCommand() *cobra.Command {
+
+	var (
+		filePath   string
+		kubeconfig string
+		outputPath string
+	)
+
+	var run = &cobra.Command{
+		Use:                   "run [flags]",
+		Short:                 "Run the chaos experiment defined inside the file",
+		Long:                  "Run the chaos experiment defined inside the file against the current kubeconfig, without the chaosengine\n\nSupported experiments: " + strings.Join(experiment.Registered(), ", "),
+		Args:                  cobra.MaximumNArgs(0),
+		Example:               "./experiments run -f=experiment.yaml",
+		DisableFlagsInUseLine: true,
+		SilenceUsage:          true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			spec, err := experiment.LoadSpec(filePath)
+			if err != nil {
+				return err
+			}
+
+			// experiment name is added to the log entries, if it is not provided through the env
+			if log.GetField(log.ExperimentField) == "" {
+				log.SetField(log.ExperimentField, spec.Name)
+			}
+
+			clients := clients.ClientSets{}
+			if err := clients.GenerateClientSetFromKubeConfigPath(kubeconfig); err != nil {
+				return errors.Errorf("unable to get the kubeconfig, err: %v", err)
+			}
+
+			var out io.Writer = os.Stdout
+			if outputPath != "" {
+				file, err := os.Create(outputPath)
+				if err != nil {
+					return errors.Errorf("unable to create the %v file, err: %v", outputPath, err)
+				}
+				defer file.Close()
+				out = file
+			}
+			return experiment.RunStandalone(spec, clients, out)
+		},
+	}
+
+	run.Flags().StringVarP(&filePath, "file", "f", "", "path of the experiment.yaml manifest")
+	run.Flags().StringVar(&kubeconfig, "kubeconfig", "", "path of the kubeconfig file, it defaults to the current kubeconfig")
+	run.Flags().StringVarP(&outputPath, "output", "o", "", "path of the json report, it defaults to the stdout")
+	run.MarkFlagRequired("file")
+	return run
+}
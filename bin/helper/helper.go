@@ -19,16 +19,11 @@ import (
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/sirupsen/logrus"
 )
 
 func init() {
-	// Log as JSON instead of the default ASCII formatter
-	logrus.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:          true,
-		DisableSorting:         true,
-		DisableLevelTruncation: true,
-	})
+	// configure the log formatter, level and the default fields from the env
+	log.Init()
 }
 
 func main() {
@@ -43,9 +43,9 @@ func PrepareAzureStop(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	}
 
 	//  get the instance name or list of instance names
-	instanceNameList := strings.Split(experimentsDetails.AzureInstanceName, ",")
-	if len(instanceNameList) == 0 {
-		return errors.Errorf("no instance name found to stop")
+	instanceNameList, err := getTargetInstances(experimentsDetails)
+	if err != nil {
+		return err
 	}
 
 	// watching for the abort signal and revert the chaos
@@ -72,6 +72,20 @@ func PrepareAzureStop(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	return nil
 }
 
+// PlanAzureStop derives the target instances of the azure-instance-stop chaos, without stopping them
+func PlanAzureStop(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	return getTargetInstances(experimentsDetails)
+}
+
+// getTargetInstances returns the list of target instance names
+func getTargetInstances(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	instanceNameList := strings.Split(experimentsDetails.AzureInstanceName, ",")
+	if len(instanceNameList) == 0 {
+		return nil, errors.Errorf("no instance name found to stop")
+	}
+	return instanceNameList, nil
+}
+
 // injectChaosInSerialMode will inject the azure instance termination in serial mode that is one after the other
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, instanceNameList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	select {
@@ -116,7 +130,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 
 				// Run the probes during chaos
 				// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
-				if len(resultDetails.ProbeDetails) != 0 && i == 0 {
+				if resultDetails.HasProbes() && i == 0 {
 					if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 						return err
 					}
@@ -195,7 +209,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 			}
 
 			// Run probes during chaos
-			if len(resultDetails.ProbeDetails) != 0 {
+			if resultDetails.HasProbes() {
 				if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return err
 				}
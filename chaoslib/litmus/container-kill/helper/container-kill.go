@@ -1,9 +1,8 @@
 package helper
 
 import (
-	"bytes"
-	"os/exec"
 	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
@@ -13,6 +12,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
 	"github.com/openebs/maya/pkg/util/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -33,21 +33,28 @@ func Helper(clients clients.ClientSets) {
 	getENV(&experimentsDetails)
 
 	// Intialise the chaos attributes
-	types.InitialiseChaosVariables(&chaosDetails)
+	if err := types.InitialiseChaosVariables(&chaosDetails); err != nil {
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
 
 	// Intialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
 
-	err := killContainer(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	runtime, err := containerRuntime.New(experimentsDetails.ContainerRuntime, experimentsDetails.SocketPath)
 	if err != nil {
 		log.Fatalf("helper pod failed, err: %v", err)
 	}
+	defer runtime.Close()
+
+	if err := killContainer(&experimentsDetails, runtime, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
 }
 
 // killContainer kill the random application container
 // it will kill the container till the chaos duration
 // the execution will stop after timestamp passes the given chaos duration
-func killContainer(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func killContainer(experimentsDetails *experimentTypes.ExperimentDetails, runtime containerRuntime.ContainerRuntime, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 	ChaosStartTimeStamp := time.Now()
@@ -81,17 +88,8 @@ func killContainer(experimentsDetails *experimentTypes.ExperimentDetails, client
 			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 		}
 
-		switch experimentsDetails.ContainerRuntime {
-		case "docker":
-			if err := stopDockerContainer(containerID, experimentsDetails.SocketPath, experimentsDetails.Signal); err != nil {
-				return err
-			}
-		case "containerd", "crio":
-			if err := stopContainerdContainer(containerID, experimentsDetails.SocketPath, experimentsDetails.Signal); err != nil {
-				return err
-			}
-		default:
-			return errors.Errorf("%v container runtime not supported", experimentsDetails.ContainerRuntime)
+		if err := stopContainer(experimentsDetails, runtime, containerID); err != nil {
+			return err
 		}
 
 		//Waiting for the chaos interval after chaos injection
@@ -113,43 +111,29 @@ func killContainer(experimentsDetails *experimentTypes.ExperimentDetails, client
 		}
 		duration = int(time.Since(ChaosStartTimeStamp).Seconds())
 	}
-	if err := result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "targeted", "pod", experimentsDetails.TargetPods); err != nil {
-		return err
+	// the chaosresult is not available in the standalone mode
+	if !chaosDetails.Standalone {
+		if err := result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "targeted", "pod", experimentsDetails.TargetPods, clients); err != nil {
+			return err
+		}
 	}
 	log.Infof("[Completion]: %v chaos has been completed", experimentsDetails.ExperimentName)
 	return nil
 }
 
-//stopContainerdContainer kill the application container
-func stopContainerdContainer(containerID, socketPath, signal string) error {
-	var errOut bytes.Buffer
-	var cmd *exec.Cmd
-	endpoint := "unix://" + socketPath
-	switch signal {
+//stopContainer kill the application container with the given signal
+func stopContainer(experimentsDetails *experimentTypes.ExperimentDetails, runtime containerRuntime.ContainerRuntime, containerID string) error {
+	var signal syscall.Signal
+	switch experimentsDetails.Signal {
 	case "SIGKILL":
-		cmd = exec.Command("sudo", "crictl", "-i", endpoint, "-r", endpoint, "stop", "--timeout=0", string(containerID))
+		signal = syscall.SIGKILL
 	case "SIGTERM":
-		cmd = exec.Command("sudo", "crictl", "-i", endpoint, "-r", endpoint, "stop", string(containerID))
+		signal = syscall.SIGTERM
 	default:
-		return errors.Errorf("{%v} signal not supported, use either SIGTERM or SIGKILL", signal)
+		return errors.Errorf("{%v} signal not supported, use either SIGTERM or SIGKILL", experimentsDetails.Signal)
 	}
-	cmd.Stderr = &errOut
-	if err := cmd.Run(); err != nil {
-		return errors.Errorf("Unable to run command, err: %v; error output: %v", err, errOut.String())
-	}
-	return nil
-}
 
-//stopDockerContainer kill the application container
-func stopDockerContainer(containerID, socketPath, signal string) error {
-	var errOut bytes.Buffer
-	host := "unix://" + socketPath
-	cmd := exec.Command("sudo", "docker", "--host", host, "kill", string(containerID), "--signal", signal)
-	cmd.Stderr = &errOut
-	if err := cmd.Run(); err != nil {
-		return errors.Errorf("Unable to run command, err: %v; error output: %v", err, errOut.String())
-	}
-	return nil
+	return runtime.Kill(containerID, signal)
 }
 
 //getRestartCount return the restart count of target container
@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"syscall"
+	"testing"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/container-kill/types"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
+	"github.com/pkg/errors"
+)
+
+func TestStopContainer(t *testing.T) {
+	tests := []struct {
+		name    string
+		signal  string
+		want    syscall.Signal
+		wantErr bool
+	}{
+		{name: "sigkill", signal: "SIGKILL", want: syscall.SIGKILL},
+		{name: "sigterm", signal: "SIGTERM", want: syscall.SIGTERM},
+		{name: "unsupported signal", signal: "SIGHUP", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime := containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: 10, Running: true})
+			experimentsDetails := &experimentTypes.ExperimentDetails{Signal: tt.signal}
+
+			err := stopContainer(experimentsDetails, runtime, "abc")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %v signal", tt.signal)
+				}
+				if len(runtime.Signals["abc"]) != 0 {
+					t.Fatalf("expected no signal to be sent, got %v", runtime.Signals["abc"])
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := runtime.Signals["abc"]; len(got) != 1 || got[0] != tt.want {
+				t.Fatalf("expected %v signal, got %v", tt.want, got)
+			}
+			if runtime.Containers["abc"].Running {
+				t.Fatalf("expected the container to be stopped")
+			}
+		})
+	}
+}
+
+func TestStopContainerRuntimeError(t *testing.T) {
+	runtime := containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: 10, Running: true})
+	runtime.Err = errors.Errorf("runtime unavailable")
+
+	err := stopContainer(&experimentTypes.ExperimentDetails{Signal: "SIGKILL"}, runtime, "abc")
+	if err == nil || err.Error() != "runtime unavailable" {
+		t.Fatalf("expected the runtime error, got %v", err)
+	}
+}
+
+func TestStopContainerNotFound(t *testing.T) {
+	runtime := containerRuntime.NewFake()
+
+	if err := stopContainer(&experimentTypes.ExperimentDetails{Signal: "SIGKILL"}, runtime, "abc"); err == nil {
+		t.Fatalf("expected an error for the missing container")
+	}
+}
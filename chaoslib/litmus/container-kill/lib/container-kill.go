@@ -1,6 +1,7 @@
 package lib
 
 import (
+	"os"
 	"strconv"
 	"strings"
 
@@ -20,14 +21,72 @@ import (
 //PrepareContainerKill contains the prepration steps before chaos injection
 func PrepareContainerKill(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
-	// Get the target pod details for the chaos execution
-	// if the target pod is not defined it will derive the random target pod list using pod affected percentage
-	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" {
-		return errors.Errorf("please provide one of the appLabel or TARGET_PODS")
+	targetPodList, err := getTargetPods(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return err
+	}
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	if err := setHelperDetails(experimentsDetails, clients, chaosDetails, targetPodList); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(experimentsDetails.Sequence) {
+	case "serial":
+		if err = injectChaosInSerialMode(experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
+			return err
+		}
+	case "parallel":
+		if err = injectChaosInParallelMode(experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("%v sequence is not supported", experimentsDetails.Sequence)
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// PlanContainerKill derives the target pods and the helper pods of the container-kill chaos, without creating the helper pods
+func PlanContainerKill(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, []apiv1.Pod, error) {
+
+	targetPodList, err := getTargetPods(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return apiv1.PodList{}, nil, err
+	}
+
+	if err := setHelperDetails(experimentsDetails, clients, chaosDetails, targetPodList); err != nil {
+		return apiv1.PodList{}, nil, err
+	}
+
+	labelSuffix := common.GetRunID()
+	helperPods := []apiv1.Pod{}
+	for _, pod := range targetPodList.Items {
+		helperPods = append(helperPods, *getHelperPod(experimentsDetails, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, common.GetRunID(), labelSuffix))
+	}
+	return targetPodList, helperPods, nil
+}
+
+// getTargetPods derive the target pods for the chaos execution
+// if the target pod is not defined it will derive the random target pod list using pod affected percentage
+func getTargetPods(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, error) {
+
+	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" && !chaosDetails.AppDetail.IsMultiTarget() {
+		return apiv1.PodList{}, errors.Errorf("please provide one of the appLabel or TARGET_PODS")
 	}
 	targetPodList, err := common.GetPodList(experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
 	if err != nil {
-		return err
+		return apiv1.PodList{}, err
 	}
 
 	podNames := []string{}
@@ -35,13 +94,13 @@ func PrepareContainerKill(experimentsDetails *experimentTypes.ExperimentDetails,
 		podNames = append(podNames, pod.Name)
 	}
 	log.Infof("Target pods list for chaos, %v", podNames)
+	return targetPodList, nil
+}
 
-	//Waiting for the ramp time before chaos injection
-	if experimentsDetails.RampTime != 0 {
-		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
-	}
+// setHelperDetails derive the serviceAccountName, target container and helper data required by the helper pods
+func setHelperDetails(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, targetPodList apiv1.PodList) error {
 
+	var err error
 	// Getting the serviceAccountName, need permission inside helper pod to create the events
 	if experimentsDetails.ChaosServiceAccount == "" {
 		experimentsDetails.ChaosServiceAccount, err = common.GetServiceAccount(experimentsDetails.ChaosNamespace, experimentsDetails.ChaosPodName, clients)
@@ -52,7 +111,7 @@ func PrepareContainerKill(experimentsDetails *experimentTypes.ExperimentDetails,
 
 	//Get the target container name of the application pod
 	if experimentsDetails.TargetContainer == "" {
-		experimentsDetails.TargetContainer, err = common.GetTargetContainer(experimentsDetails.AppNS, targetPodList.Items[0].Name, clients)
+		experimentsDetails.TargetContainer, err = common.GetTargetContainer(targetPodList.Items[0].Namespace, targetPodList.Items[0].Name, clients)
 		if err != nil {
 			return errors.Errorf("unable to get the target container name, err: %v", err)
 		}
@@ -63,25 +122,6 @@ func PrepareContainerKill(experimentsDetails *experimentTypes.ExperimentDetails,
 			return err
 		}
 	}
-
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return err
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(experimentsDetails, targetPodList, clients, chaosDetails, resultDetails, eventsDetails); err != nil {
-			return err
-		}
-	default:
-		return errors.Errorf("%v sequence is not supported", experimentsDetails.Sequence)
-	}
-
-	//Waiting for the ramp time after chaos injection
-	if experimentsDetails.RampTime != 0 {
-		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
-	}
 	return nil
 }
 
@@ -91,12 +131,15 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
 
+	// notify the chaos injection, the recovery of the targets is measured from here
+	chaosDetails.MarkInjected()
+
 	// creating the helper pod to perform container kill chaos
 	for _, pod := range targetPodList.Items {
 
@@ -106,7 +149,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 			"ContainerName": experimentsDetails.TargetContainer,
 		})
 		runID := common.GetRunID()
-		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Spec.NodeName, runID, labelSuffix); err != nil {
+		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, runID, labelSuffix); err != nil {
 			return errors.Errorf("unable to create the helper pod, err: %v", err)
 		}
 
@@ -143,12 +186,15 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
 
+	// notify the chaos injection, the recovery of the targets is measured from here
+	chaosDetails.MarkInjected()
+
 	// creating the helper pod to perform container kill chaos
 	for _, pod := range targetPodList.Items {
 
@@ -158,7 +204,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 			"ContainerName": experimentsDetails.TargetContainer,
 		})
 		runID := common.GetRunID()
-		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Spec.NodeName, runID, labelSuffix); err != nil {
+		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, runID, labelSuffix); err != nil {
 			return errors.Errorf("unable to create the helper pod, err: %v", err)
 		}
 	}
@@ -191,7 +237,14 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 }
 
 // createHelperPod derive the attributes for helper pod and create the helper pod
-func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, podName, nodeName, runID, labelSuffix string) error {
+func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, podName, podNamespace, nodeName, runID, labelSuffix string) error {
+	helperPod := getHelperPod(experimentsDetails, chaosDetails, podName, podNamespace, nodeName, runID, labelSuffix)
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, podName, podNamespace, nodeName, runID, labelSuffix string) *apiv1.Pod {
 
 	privilegedEnable := false
 	if experimentsDetails.ContainerRuntime == "crio" {
@@ -235,7 +288,7 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 						"./helpers -name container-kill",
 					},
 					Resources: chaosDetails.Resources,
-					Env:       getPodEnv(experimentsDetails, podName),
+					Env:       getPodEnv(experimentsDetails, podName, podNamespace),
 					VolumeMounts: []apiv1.VolumeMount{
 						{
 							Name:      "cri-socket",
@@ -249,16 +302,14 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
+	return helperPod
 }
 
 // getPodEnv derive all the env required for the helper pod
-func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName string) []apiv1.EnvVar {
+func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName, podNamespace string) []apiv1.EnvVar {
 
 	var envDetails common.ENVDetails
-	envDetails.SetEnv("APP_NAMESPACE", experimentsDetails.AppNS).
+	envDetails.SetEnv("APP_NAMESPACE", podNamespace).
 		SetEnv("APP_POD", podName).
 		SetEnv("APP_CONTAINER", experimentsDetails.TargetContainer).
 		SetEnv("TOTAL_CHAOS_DURATION", strconv.Itoa(experimentsDetails.ChaosDuration)).
@@ -273,6 +324,8 @@ func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName st
 		SetEnv("STATUS_CHECK_TIMEOUT", strconv.Itoa(experimentsDetails.Timeout)).
 		SetEnv("EXPERIMENT_NAME", experimentsDetails.ExperimentName).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
+		SetEnv("STANDALONE", os.Getenv("STANDALONE")).
+		SetLogEnv().
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
 	return envDetails.ENV
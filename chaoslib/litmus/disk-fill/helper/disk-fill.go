@@ -49,7 +49,9 @@ func Helper(clients clients.ClientSets) {
 	getENV(&experimentsDetails)
 
 	// Intialise the chaos attributes
-	types.InitialiseChaosVariables(&chaosDetails)
+	if err := types.InitialiseChaosVariables(&chaosDetails); err != nil {
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
 
 	// Intialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -127,7 +129,7 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 			return err
 		}
 
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", experimentsDetails.TargetPods); err != nil {
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", experimentsDetails.TargetPods, clients); err != nil {
 			return err
 		}
 
@@ -143,7 +145,7 @@ func diskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 		if err != nil {
 			return errors.Errorf("unable to perform remedy operation, err: %v", err)
 		}
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods); err != nil {
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods, clients); err != nil {
 			return err
 		}
 	} else {
@@ -283,7 +285,7 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients
 		retry--
 		time.Sleep(1 * time.Second)
 	}
-	if err := result.AnnotateChaosResult(resultName, experimentsDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods); err != nil {
+	if err := result.AnnotateChaosResult(resultName, experimentsDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods, clients); err != nil {
 		log.Errorf("unable to annotate the chaosresult, err :%v", err)
 	}
 	log.Info("Chaos Revert Completed")
@@ -23,14 +23,72 @@ func PrepareDiskFill(experimentsDetails *experimentTypes.ExperimentDetails, clie
 	// It will contains all the pod & container details required for exec command
 	execCommandDetails := exec.PodDetails{}
 
-	// Get the target pod details for the chaos execution
-	// if the target pod is not defined it will derive the random target pod list using pod affected percentage
-	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" {
-		return errors.Errorf("please provide one of the appLabel or TARGET_PODS")
+	targetPodList, err := getTargetPods(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return err
+	}
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	if err := setHelperDetails(experimentsDetails, clients, chaosDetails, targetPodList); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(experimentsDetails.Sequence) {
+	case "serial":
+		if err = injectChaosInSerialMode(experimentsDetails, targetPodList, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails); err != nil {
+			return err
+		}
+	case "parallel":
+		if err = injectChaosInParallelMode(experimentsDetails, targetPodList, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("%v sequence is not supported", experimentsDetails.Sequence)
+	}
+
+	//Waiting for the ramp time after chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+	return nil
+}
+
+// PlanDiskFill derives the target pods and the helper pods of the disk-fill chaos, without creating the helper pods
+func PlanDiskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, []apiv1.Pod, error) {
+
+	targetPodList, err := getTargetPods(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return apiv1.PodList{}, nil, err
+	}
+
+	if err := setHelperDetails(experimentsDetails, clients, chaosDetails, targetPodList); err != nil {
+		return apiv1.PodList{}, nil, err
+	}
+
+	labelSuffix := common.GetRunID()
+	helperPods := []apiv1.Pod{}
+	for _, pod := range targetPodList.Items {
+		helperPods = append(helperPods, *getHelperPod(experimentsDetails, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, common.GetRunID(), labelSuffix))
+	}
+	return targetPodList, helperPods, nil
+}
+
+// getTargetPods derive the target pods for the chaos execution
+// if the target pod is not defined it will derive the random target pod list using pod affected percentage
+func getTargetPods(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, error) {
+
+	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" && !chaosDetails.AppDetail.IsMultiTarget() {
+		return apiv1.PodList{}, errors.Errorf("please provide one of the appLabel or TARGET_PODS")
 	}
 	targetPodList, err := common.GetPodList(experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
 	if err != nil {
-		return err
+		return apiv1.PodList{}, err
 	}
 
 	podNames := []string{}
@@ -38,16 +96,16 @@ func PrepareDiskFill(experimentsDetails *experimentTypes.ExperimentDetails, clie
 		podNames = append(podNames, pod.Name)
 	}
 	log.Infof("Target pods list for chaos, %v", podNames)
+	return targetPodList, nil
+}
 
-	//Waiting for the ramp time before chaos injection
-	if experimentsDetails.RampTime != 0 {
-		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
-	}
+// setHelperDetails derive the target container, serviceAccountName and helper data required by the helper pods
+func setHelperDetails(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, targetPodList apiv1.PodList) error {
 
+	var err error
 	//Get the target container name of the application pod
 	if experimentsDetails.TargetContainer == "" {
-		experimentsDetails.TargetContainer, err = common.GetTargetContainer(experimentsDetails.AppNS, targetPodList.Items[0].Name, clients)
+		experimentsDetails.TargetContainer, err = common.GetTargetContainer(targetPodList.Items[0].Namespace, targetPodList.Items[0].Name, clients)
 		if err != nil {
 			return errors.Errorf("unable to get the target container name, err: %v", err)
 		}
@@ -66,25 +124,6 @@ func PrepareDiskFill(experimentsDetails *experimentTypes.ExperimentDetails, clie
 			return err
 		}
 	}
-
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(experimentsDetails, targetPodList, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails); err != nil {
-			return err
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(experimentsDetails, targetPodList, clients, chaosDetails, execCommandDetails, resultDetails, eventsDetails); err != nil {
-			return err
-		}
-	default:
-		return errors.Errorf("%v sequence is not supported", experimentsDetails.Sequence)
-	}
-
-	//Waiting for the ramp time after chaos injection
-	if experimentsDetails.RampTime != 0 {
-		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
-	}
 	return nil
 }
 
@@ -94,7 +133,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -103,7 +142,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	// creating the helper pod to perform disk-fill chaos
 	for _, pod := range targetPodList.Items {
 		runID := common.GetRunID()
-		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Spec.NodeName, runID, labelSuffix); err != nil {
+		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, runID, labelSuffix); err != nil {
 			return errors.Errorf("unable to create the helper pod, err: %v", err)
 		}
 
@@ -142,7 +181,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -151,7 +190,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	// creating the helper pod to perform disk-fill chaos
 	for _, pod := range targetPodList.Items {
 		runID := common.GetRunID()
-		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Spec.NodeName, runID, labelSuffix); err != nil {
+		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, runID, labelSuffix); err != nil {
 			return errors.Errorf("unable to create the helper pod, err: %v", err)
 		}
 	}
@@ -184,7 +223,14 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 }
 
 // createHelperPod derive the attributes for helper pod and create the helper pod
-func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, appName, appNodeName, runID, labelSuffix string) error {
+func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, appName, appNamespace, appNodeName, runID, labelSuffix string) error {
+	helperPod := getHelperPod(experimentsDetails, chaosDetails, appName, appNamespace, appNodeName, runID, labelSuffix)
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appName, appNamespace, appNodeName, runID, labelSuffix string) *apiv1.Pod {
 
 	mountPropagationMode := apiv1.MountPropagationHostToContainer
 	terminationGracePeriodSeconds := int64(experimentsDetails.TerminationGracePeriodSeconds)
@@ -225,7 +271,7 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 						"./helpers -name disk-fill",
 					},
 					Resources: chaosDetails.Resources,
-					Env:       getPodEnv(experimentsDetails, appName),
+					Env:       getPodEnv(experimentsDetails, appName, appNamespace),
 					VolumeMounts: []apiv1.VolumeMount{
 						{
 							Name:             "udev",
@@ -237,16 +283,14 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
+	return helperPod
 }
 
 // getPodEnv derive all the env required for the helper pod
-func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName string) []apiv1.EnvVar {
+func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName, podNamespace string) []apiv1.EnvVar {
 
 	var envDetails common.ENVDetails
-	envDetails.SetEnv("APP_NAMESPACE", experimentsDetails.AppNS).
+	envDetails.SetEnv("APP_NAMESPACE", podNamespace).
 		SetEnv("APP_POD", podName).
 		SetEnv("APP_CONTAINER", experimentsDetails.TargetContainer).
 		SetEnv("TOTAL_CHAOS_DURATION", strconv.Itoa(experimentsDetails.ChaosDuration)).
@@ -258,6 +302,7 @@ func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName st
 		SetEnv("EPHEMERAL_STORAGE_MEBIBYTES", strconv.Itoa(experimentsDetails.EphemeralStorageMebibytes)).
 		SetEnv("DATA_BLOCK_SIZE", strconv.Itoa(experimentsDetails.DataBlockSize)).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
+		SetLogEnv().
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
 	return envDetails.ENV
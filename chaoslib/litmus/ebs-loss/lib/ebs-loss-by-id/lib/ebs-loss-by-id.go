@@ -46,9 +46,9 @@ func PrepareEBSLossByID(experimentsDetails *experimentTypes.ExperimentDetails, c
 	default:
 
 		//get the volume id or list of instance ids
-		volumeIDList := strings.Split(experimentsDetails.EBSVolumeID, ",")
-		if len(volumeIDList) == 0 {
-			return errors.Errorf("no volume id found to detach")
+		volumeIDList, err := getTargetVolumes(experimentsDetails)
+		if err != nil {
+			return err
 		}
 		// watching for the abort signal and revert the chaos
 		go ebsloss.AbortWatcher(experimentsDetails, volumeIDList, abort, chaosDetails)
@@ -74,3 +74,17 @@ func PrepareEBSLossByID(experimentsDetails *experimentTypes.ExperimentDetails, c
 	}
 	return nil
 }
+
+// PlanEBSLossByID derives the target volumes of the ebs-loss-by-id chaos, without detaching them
+func PlanEBSLossByID(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	return getTargetVolumes(experimentsDetails)
+}
+
+// getTargetVolumes returns the list of target volume ids
+func getTargetVolumes(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	volumeIDList := strings.Split(experimentsDetails.EBSVolumeID, ",")
+	if len(volumeIDList) == 0 {
+		return nil, errors.Errorf("no volume id found to detach")
+	}
+	return volumeIDList, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	ebsloss "github.com/litmuschaos/litmus-go/chaoslib/litmus/ebs-loss/lib"
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	ebs "github.com/litmuschaos/litmus-go/pkg/cloud/aws/ebs"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ebs-loss/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -71,3 +72,12 @@ func PrepareEBSLossByTag(experimentsDetails *experimentTypes.ExperimentDetails,
 	}
 	return nil
 }
+
+// PlanEBSLossByTag derives the target volumes of the ebs-loss-by-tag chaos, without detaching them
+// the target volumes are selected randomly from the attached volumes, based on the volume affected percentage
+func PlanEBSLossByTag(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	if err := ebs.SetTargetVolumeIDs(experimentsDetails); err != nil {
+		return nil, err
+	}
+	return common.FilterBasedOnPercentage(experimentsDetails.VolumeAffectedPerc, experimentsDetails.TargetVolumeIDList), nil
+}
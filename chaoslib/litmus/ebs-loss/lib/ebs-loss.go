@@ -53,7 +53,7 @@ func InjectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 
 			// run the probes during chaos
 			// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
-			if len(resultDetails.ProbeDetails) != 0 && i == 0 {
+			if resultDetails.HasProbes() && i == 0 {
 				if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return err
 				}
@@ -142,7 +142,7 @@ func InjectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 		}
 
 		// run the probes during chaos
-		if len(resultDetails.ProbeDetails) != 0 {
+		if resultDetails.HasProbes() {
 			if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 				return err
 			}
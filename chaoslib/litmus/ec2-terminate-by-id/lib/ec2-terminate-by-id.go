@@ -43,9 +43,9 @@ func PrepareEC2TerminateByID(experimentsDetails *experimentTypes.ExperimentDetai
 	}
 
 	//get the instance id or list of instance ids
-	instanceIDList := strings.Split(experimentsDetails.Ec2InstanceID, ",")
-	if len(instanceIDList) == 0 {
-		return errors.Errorf("no instance id found to terminate")
+	instanceIDList, err := getTargetInstances(experimentsDetails)
+	if err != nil {
+		return err
 	}
 
 	// watching for the abort signal and revert the chaos
@@ -72,6 +72,20 @@ func PrepareEC2TerminateByID(experimentsDetails *experimentTypes.ExperimentDetai
 	return nil
 }
 
+// PlanEC2TerminateByID derives the target instances of the ec2-terminate-by-id chaos, without stopping them
+func PlanEC2TerminateByID(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	return getTargetInstances(experimentsDetails)
+}
+
+// getTargetInstances returns the list of target instance ids
+func getTargetInstances(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	instanceIDList := strings.Split(experimentsDetails.Ec2InstanceID, ",")
+	if len(instanceIDList) == 0 {
+		return nil, errors.Errorf("no instance id found to terminate")
+	}
+	return instanceIDList, nil
+}
+
 //injectChaosInSerialMode will inject the ec2 instance termination in serial mode that is one after other
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
@@ -113,7 +127,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 
 				// run the probes during chaos
 				// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
-				if len(resultDetails.ProbeDetails) != 0 && i == 0 {
+				if resultDetails.HasProbes() && i == 0 {
 					if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 						return err
 					}
@@ -186,7 +200,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 			}
 
 			// run the probes during chaos
-			if len(resultDetails.ProbeDetails) != 0 {
+			if resultDetails.HasProbes() {
 				if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return err
 				}
@@ -108,7 +108,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 
 				// run the probes during chaos
 				// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
-				if len(resultDetails.ProbeDetails) != 0 && i == 0 {
+				if resultDetails.HasProbes() && i == 0 {
 					if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 						return err
 					}
@@ -179,7 +179,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 			}
 
 			// run the probes during chaos
-			if len(resultDetails.ProbeDetails) != 0 {
+			if resultDetails.HasProbes() {
 				if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return err
 				}
@@ -216,6 +216,15 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	return nil
 }
 
+// PlanEC2TerminateByTag derives the target instances of the ec2-terminate-by-tag chaos, without stopping them
+// the target instances are selected randomly from the running instances, based on the instance affected percentage
+func PlanEC2TerminateByTag(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, error) {
+	if err := SetTargetInstance(experimentsDetails); err != nil {
+		return nil, err
+	}
+	return common.FilterBasedOnPercentage(experimentsDetails.InstanceAffectedPerc, experimentsDetails.TargetInstanceIDList), nil
+}
+
 //SetTargetInstance will select the target instance which are in running state and filtered from the given instance tag
 func SetTargetInstance(experimentsDetails *experimentTypes.ExperimentDetails) error {
 
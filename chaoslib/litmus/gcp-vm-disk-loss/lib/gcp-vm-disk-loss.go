@@ -26,8 +26,6 @@ var (
 //PrepareDiskVolumeLoss contains the prepration and injection steps for the experiment
 func PrepareDiskVolumeLoss(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
-	var instanceNamesList []string
-
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to inject channel.
@@ -44,32 +42,10 @@ func PrepareDiskVolumeLoss(experimentsDetails *experimentTypes.ExperimentDetails
 		common.WaitForDuration(experimentsDetails.RampTime)
 	}
 
-	//get the disk volume names list
-	diskNamesList := strings.Split(experimentsDetails.DiskVolumeNames, ",")
-	if len(diskNamesList) == 0 {
-		return errors.Errorf("no volumes found to detach")
-	}
-
-	//get the disk zones list
-	diskZonesList := strings.Split(experimentsDetails.DiskZones, ",")
-	if len(diskZonesList) == 0 {
-		return errors.Errorf("no zones found for corressponding instances")
-	}
-
-	if len(diskNamesList) != len(diskZonesList) {
-		return errors.Errorf("unequal number of disk names and zones received")
-	}
-
-	//prepare the instace names for the given disks
-	for i := range diskNamesList {
-
-		//Get volume attachment details
-		instanceName, err := gcp.GetVolumeAttachmentDetails(experimentsDetails.GCPProjectID, diskZonesList[i], diskNamesList[i])
-		if err != nil || instanceName == "" {
-			return errors.Errorf("failed to get the attachment info, err: %v", err)
-		}
-
-		instanceNamesList = append(instanceNamesList, instanceName)
+	//get the disk volume names list, along with the instances they are attached to
+	diskNamesList, instanceNamesList, err := getTargetDisks(experimentsDetails)
+	if err != nil {
+		return err
 	}
 
 	select {
@@ -103,6 +79,46 @@ func PrepareDiskVolumeLoss(experimentsDetails *experimentTypes.ExperimentDetails
 	return nil
 }
 
+// PlanDiskVolumeLoss derives the target disks and the instances they are attached to for the gcp-vm-disk-loss chaos, without detaching them
+func PlanDiskVolumeLoss(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, []string, error) {
+	return getTargetDisks(experimentsDetails)
+}
+
+// getTargetDisks returns the list of target disk names and the list of instances they are attached to
+func getTargetDisks(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, []string, error) {
+
+	var instanceNamesList []string
+
+	//get the disk volume names list
+	diskNamesList := strings.Split(experimentsDetails.DiskVolumeNames, ",")
+	if len(diskNamesList) == 0 {
+		return nil, nil, errors.Errorf("no volumes found to detach")
+	}
+
+	//get the disk zones list
+	diskZonesList := strings.Split(experimentsDetails.DiskZones, ",")
+	if len(diskZonesList) == 0 {
+		return nil, nil, errors.Errorf("no zones found for corressponding instances")
+	}
+
+	if len(diskNamesList) != len(diskZonesList) {
+		return nil, nil, errors.Errorf("unequal number of disk names and zones received")
+	}
+
+	//prepare the instace names for the given disks
+	for i := range diskNamesList {
+
+		//Get volume attachment details
+		instanceName, err := gcp.GetVolumeAttachmentDetails(experimentsDetails.GCPProjectID, diskZonesList[i], diskNamesList[i])
+		if err != nil || instanceName == "" {
+			return nil, nil, errors.Errorf("failed to get the attachment info, err: %v", err)
+		}
+
+		instanceNamesList = append(instanceNamesList, instanceName)
+	}
+	return diskNamesList, instanceNamesList, nil
+}
+
 //injectChaosInSerialMode will inject the disk loss chaos in serial mode which means one after the other
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, targetDiskVolumeNamesList []string, instanceNamesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
@@ -138,7 +154,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 
 			// run the probes during chaos
 			// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
-			if len(resultDetails.ProbeDetails) != 0 && i == 0 {
+			if resultDetails.HasProbes() && i == 0 {
 				if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return err
 				}
@@ -216,7 +232,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 		}
 
 		// run the probes during chaos
-		if len(resultDetails.ProbeDetails) != 0 {
+		if resultDetails.HasProbes() {
 			if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 				return err
 			}
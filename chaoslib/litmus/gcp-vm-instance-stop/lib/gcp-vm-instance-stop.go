@@ -42,20 +42,10 @@ func PrepareVMStop(experimentsDetails *experimentTypes.ExperimentDetails, client
 		common.WaitForDuration(experimentsDetails.RampTime)
 	}
 
-	// get the instance name or list of instance names
-	instanceNamesList := strings.Split(experimentsDetails.VMInstanceName, ",")
-	if len(instanceNamesList) == 0 {
-		return errors.Errorf("no instance name found to stop")
-	}
-
-	// get the zone name or list of corresponding zones for the instances
-	instanceZonesList := strings.Split(experimentsDetails.InstanceZone, ",")
-	if len(instanceZonesList) == 0 {
-		return errors.Errorf("no corresponding zones found for the instances")
-	}
-
-	if len(instanceNamesList) != len(instanceZonesList) {
-		return errors.Errorf("number of instances is not equal to the number of zones")
+	// get the instance name or list of instance names, along with their zones
+	instanceNamesList, instanceZonesList, err := getTargetInstances(experimentsDetails)
+	if err != nil {
+		return err
 	}
 
 	go abortWatcher(experimentsDetails, instanceNamesList, instanceZonesList, chaosDetails)
@@ -81,6 +71,32 @@ func PrepareVMStop(experimentsDetails *experimentTypes.ExperimentDetails, client
 	return nil
 }
 
+// PlanVMStop derives the target instances and their zones for the gcp-vm-instance-stop chaos, without stopping them
+func PlanVMStop(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, []string, error) {
+	return getTargetInstances(experimentsDetails)
+}
+
+// getTargetInstances returns the list of target instance names and the list of corresponding zones
+func getTargetInstances(experimentsDetails *experimentTypes.ExperimentDetails) ([]string, []string, error) {
+
+	// get the instance name or list of instance names
+	instanceNamesList := strings.Split(experimentsDetails.VMInstanceName, ",")
+	if len(instanceNamesList) == 0 {
+		return nil, nil, errors.Errorf("no instance name found to stop")
+	}
+
+	// get the zone name or list of corresponding zones for the instances
+	instanceZonesList := strings.Split(experimentsDetails.InstanceZone, ",")
+	if len(instanceZonesList) == 0 {
+		return nil, nil, errors.Errorf("no corresponding zones found for the instances")
+	}
+
+	if len(instanceNamesList) != len(instanceZonesList) {
+		return nil, nil, errors.Errorf("number of instances is not equal to the number of zones")
+	}
+	return instanceNamesList, instanceZonesList, nil
+}
+
 //injectChaosInSerialMode stops VM instances in serial mode i.e. one after the other
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, instanceNamesList []string, instanceZonesList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
@@ -122,7 +138,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 
 				// run the probes during chaos
 				// the OnChaos probes execution will start in the first iteration and keep running for the entire chaos duration
-				if len(resultDetails.ProbeDetails) != 0 && i == 0 {
+				if resultDetails.HasProbes() && i == 0 {
 					if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 						return err
 					}
@@ -197,7 +213,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 			}
 
 			// run the probes during chaos
-			if len(resultDetails.ProbeDetails) != 0 {
+			if resultDetails.HasProbes() {
 				if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return err
 				}
@@ -70,7 +70,7 @@ func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, c
 	common.SetTargets(experimentsDetails.TargetNode, "targeted", "node", chaosDetails)
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return err
@@ -107,8 +107,35 @@ func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, c
 	return nil
 }
 
+// PlanKubeletKill derives the target node and the helper pod of the kubelet-service-kill chaos, without creating the helper pod
+func PlanKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (*apiv1.Pod, error) {
+
+	var err error
+	if experimentsDetails.TargetNode == "" {
+		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if experimentsDetails.EngineName != "" {
+		if err := common.SetHelperData(chaosDetails, clients); err != nil {
+			return nil, err
+		}
+	}
+
+	experimentsDetails.RunID = common.GetRunID()
+	return getHelperPod(experimentsDetails, chaosDetails, experimentsDetails.TargetNode), nil
+}
+
 // createHelperPod derive the attributes for helper pod and create the helper pod
 func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, appNodeName string) error {
+	helperPod := getHelperPod(experimentsDetails, chaosDetails, appNodeName)
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appNodeName string) *apiv1.Pod {
 
 	privileged := true
 	terminationGracePeriodSeconds := int64(experimentsDetails.TerminationGracePeriodSeconds)
@@ -188,9 +215,7 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
+	return helperPod
 }
 
 func ptrint64(p int64) *int64 {
@@ -1,9 +1,7 @@
 package helper
 
 import (
-	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"strconv"
 	"strings"
@@ -17,15 +15,11 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
 	"github.com/pkg/errors"
 	clientTypes "k8s.io/apimachinery/pkg/types"
 )
 
-const (
-	qdiscNotFound    = "Cannot delete qdisc with handle of zero"
-	qdiscNoFileFound = "RTNETLINK answers: No such file or directory"
-)
-
 var (
 	err           error
 	inject, abort chan os.Signal
@@ -54,7 +48,9 @@ func Helper(clients clients.ClientSets) {
 	getENV(&experimentsDetails)
 
 	// Intialise the chaos attributes
-	types.InitialiseChaosVariables(&chaosDetails)
+	if err := types.InitialiseChaosVariables(&chaosDetails); err != nil {
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
 
 	// Intialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -62,22 +58,22 @@ func Helper(clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	err := preparePodNetworkChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	runtime, err := containerRuntime.New(experimentsDetails.ContainerRuntime, experimentsDetails.SocketPath)
 	if err != nil {
 		log.Fatalf("helper pod failed, err: %v", err)
 	}
+	defer runtime.Close()
+
+	if err := preparePodNetworkChaos(&experimentsDetails, runtime, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
 
 }
 
 //preparePodNetworkChaos contains the prepration steps before chaos injection
-func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetails, runtime containerRuntime.ContainerRuntime, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
-	containerID, err := getContainerID(experimentsDetails, clients)
-	if err != nil {
-		return err
-	}
-	// extract out the pid of the target container
-	targetPID, err := common.GetPID(experimentsDetails.ContainerRuntime, containerID, experimentsDetails.SocketPath)
+	_, targetPID, err := common.GetContainerPID(experimentsDetails.AppNS, experimentsDetails.TargetPods, experimentsDetails.TargetContainer, runtime, clients)
 	if err != nil {
 		return err
 	}
@@ -89,15 +85,21 @@ func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetail
 		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	}
 
+	// derive the qdisc tree, which will be installed and reverted by the chaos
+	tree, err := getQdiscTree(experimentsDetails)
+	if err != nil {
+		return err
+	}
+
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(targetPID, resultDetails.Name, chaosDetails.ChaosNamespace, experimentsDetails.TargetPods)
+	go abortWatcher(targetPID, tree, clients, resultDetails.Name, chaosDetails.ChaosNamespace, experimentsDetails.TargetPods)
 
 	// injecting network chaos inside target container
-	if err = injectChaos(experimentsDetails, targetPID); err != nil {
+	if err = injectChaos(targetPID, tree); err != nil {
 		return err
 	}
 
-	if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", experimentsDetails.TargetPods); err != nil {
+	if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", experimentsDetails.TargetPods, clients); err != nil {
 		return err
 	}
 
@@ -108,143 +110,102 @@ func preparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetail
 	log.Info("[Chaos]: Stopping the experiment")
 
 	// cleaning the netem process after chaos injection
-	if err = killnetem(targetPID); err != nil {
+	if err = killnetem(targetPID, tree); err != nil {
 		return err
 	}
 
-	return result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods)
+	return result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods, clients)
 }
 
-//getContainerID extract out the container id of the target container
-func getContainerID(experimentDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
+// getQdiscTree derive the qdisc tree from the netem and shaping arguments and the traffic scope
+func getQdiscTree(experimentDetails *experimentTypes.ExperimentDetails) (qdiscTree, error) {
+	netemAttrs, distribution, err := parseNetemArgs(os.Getenv("NETEM_COMMAND"))
+	if err != nil {
+		return qdiscTree{}, err
+	}
+	shaper, err := parseShaperArgs(os.Getenv("SHAPING_COMMAND"))
+	if err != nil {
+		return qdiscTree{}, err
+	}
+	destinationIPs, err := parseDestinationIPs(experimentDetails.DestinationIPs)
+	if err != nil {
+		return qdiscTree{}, err
+	}
+	destinationPorts, err := parsePorts(experimentDetails.DestinationPorts)
+	if err != nil {
+		return qdiscTree{}, err
+	}
+	sourcePorts, err := parsePorts(experimentDetails.SourcePorts)
+	if err != nil {
+		return qdiscTree{}, err
+	}
+	protocols, err := parseProtocols(experimentDetails.Protocols)
+	if err != nil {
+		return qdiscTree{}, err
+	}
 
-	var containerID string
-	switch experimentDetails.ContainerRuntime {
-	case "docker":
-		host := "unix://" + experimentDetails.SocketPath
-		// deriving the container id of the pause container
-		cmd := "sudo docker --host " + host + " ps | grep k8s_POD_" + experimentDetails.TargetPods + "_" + experimentDetails.AppNS + " | awk '{print $1}'"
-		out, err := exec.Command("/bin/sh", "-c", cmd).CombinedOutput()
-		if err != nil {
-			log.Error(fmt.Sprintf("[docker]: Failed to run docker ps command: %s", string(out)))
-			return "", err
-		}
-		containerID = strings.TrimSpace(string(out))
-	case "containerd", "crio":
-		containerID, err = common.GetContainerID(experimentDetails.AppNS, experimentDetails.TargetPods, experimentDetails.TargetContainer, clients)
-		if err != nil {
-			return containerID, err
-		}
+	direction := strings.ToLower(experimentDetails.TrafficDirection)
+	switch direction {
+	case "":
+		direction = "egress"
+	case "egress", "ingress", "both":
 	default:
-		return "", errors.Errorf("%v container runtime not suported", experimentDetails.ContainerRuntime)
+		return qdiscTree{}, errors.Errorf("traffic direction '%s' not supported, it supports egress, ingress and both", experimentDetails.TrafficDirection)
 	}
-	log.Infof("Container ID: %v", containerID)
 
-	return containerID, nil
+	return qdiscTree{
+		Interface:        experimentDetails.NetworkInterface,
+		Netem:            netemAttrs,
+		Distribution:     distribution,
+		Shaper:           shaper,
+		DestinationIPs:   destinationIPs,
+		DestinationPorts: destinationPorts,
+		SourcePorts:      sourcePorts,
+		Protocols:        protocols,
+		Direction:        direction,
+	}, nil
 }
 
 // injectChaos inject the network chaos in target container
-// it is using nsenter command to enter into network namespace of target container
-// and execute the netem command inside it.
-func injectChaos(experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
-
-	netemCommands := os.Getenv("NETEM_COMMAND")
-	destinationIPs := os.Getenv("DESTINATION_IPS")
+// it enters into network namespace of target container
+// and programs the qdisc tree through netlink
+func injectChaos(pid int, tree qdiscTree) error {
 
 	select {
 	case <-inject:
 		// stopping the chaos execution, if abort signal received
 		os.Exit(1)
 	default:
-		if destinationIPs == "" {
-			tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc replace dev %s root netem %v", pid, experimentDetails.NetworkInterface, netemCommands)
-			cmd := exec.Command("/bin/bash", "-c", tc)
-			out, err := cmd.CombinedOutput()
-			log.Info(cmd.String())
-			if err != nil {
-				log.Error(string(out))
-				return err
-			}
-		} else {
-
-			ips := strings.Split(destinationIPs, ",")
-			var uniqueIps []string
-
-			// removing duplicates ips from the list, if any
-			for i := range ips {
-				isPresent := false
-				for j := range uniqueIps {
-					if ips[i] == uniqueIps[j] {
-						isPresent = true
-					}
-				}
-				if !isPresent {
-					uniqueIps = append(uniqueIps, ips[i])
-				}
-
-			}
-
-			// Create a priority-based queue
-			// This instantly creates classes 1:1, 1:2, 1:3
-			priority := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v root handle 1: prio", pid, experimentDetails.NetworkInterface)
-			cmd := exec.Command("/bin/bash", "-c", priority)
-			out, err := cmd.CombinedOutput()
-			log.Info(cmd.String())
-			if err != nil {
-				log.Error(string(out))
-				return err
-			}
-
-			// Add queueing discipline for 1:3 class.
-			// No traffic is going through 1:3 yet
-			traffic := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v parent 1:3 netem %v", pid, experimentDetails.NetworkInterface, netemCommands)
-			cmd = exec.Command("/bin/bash", "-c", traffic)
-			out, err = cmd.CombinedOutput()
-			log.Info(cmd.String())
-			if err != nil {
-				log.Error(string(out))
-				return err
-			}
-
-			for _, ip := range uniqueIps {
-
-				// redirect traffic to specific IP through band 3
-				tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip dst %v flowid 1:3", pid, experimentDetails.NetworkInterface, ip)
-				if strings.Contains(ip, ":") {
-					tc = fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip6 dst %v flowid 1:3", pid, experimentDetails.NetworkInterface, ip)
-				}
-				cmd = exec.Command("/bin/bash", "-c", tc)
-				out, err = cmd.CombinedOutput()
-				log.Info(cmd.String())
-				if err != nil {
-					log.Error(string(out))
-					return err
-				}
-			}
+		tc, err := newTCHandle(pid, tree.Interface)
+		if err != nil {
+			return err
 		}
+		defer tc.close()
 
+		if err := tc.install(tree); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// killnetem kill the netem process for all the target containers
-func killnetem(PID int) error {
-
-	tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc delete dev eth0 root", PID)
-	cmd := exec.Command("/bin/bash", "-c", tc)
-	out, err := cmd.CombinedOutput()
-	log.Info(cmd.String())
+// killnetem removes the qdisc tree installed by the chaos from the target container
+func killnetem(PID int, tree qdiscTree) error {
 
+	tc, err := newTCHandle(PID, tree.Interface)
 	if err != nil {
-		log.Error(string(out))
-		// ignoring err if qdisc process doesn't exist inside the target container
-		if strings.Contains(string(out), qdiscNotFound) || strings.Contains(string(out), qdiscNoFileFound) {
+		return err
+	}
+	defer tc.close()
+
+	if err := tc.revert(tree); err != nil {
+		// ignoring err if qdisc doesn't exist inside the target container
+		if errors.Is(err, ErrQdiscNotFound) {
 			log.Warn("The network chaos process has already been removed")
 			return nil
 		}
 		return err
 	}
-
 	return nil
 }
 
@@ -265,10 +226,14 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.NetworkInterface = types.Getenv("NETWORK_INTERFACE", "eth0")
 	experimentDetails.SocketPath = types.Getenv("SOCKET_PATH", "")
 	experimentDetails.DestinationIPs = types.Getenv("DESTINATION_IPS", "")
+	experimentDetails.DestinationPorts = types.Getenv("DESTINATION_PORTS", "")
+	experimentDetails.SourcePorts = types.Getenv("SOURCE_PORTS", "")
+	experimentDetails.Protocols = types.Getenv("PROTOCOLS", "")
+	experimentDetails.TrafficDirection = types.Getenv("TRAFFIC_DIRECTION", "egress")
 }
 
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targetPID int, resultName, chaosNS, targetPodName string) {
+func abortWatcher(targetPID int, tree qdiscTree, clients clients.ClientSets, resultName, chaosNS, targetPodName string) {
 
 	<-abort
 	log.Info("[Chaos]: Killing process started because of terminated signal received")
@@ -276,13 +241,13 @@ func abortWatcher(targetPID int, resultName, chaosNS, targetPodName string) {
 	// retry thrice for the chaos revert
 	retry := 3
 	for retry > 0 {
-		if err = killnetem(targetPID); err != nil {
+		if err = killnetem(targetPID, tree); err != nil {
 			log.Errorf("unable to kill netem process, err :%v", err)
 		}
 		retry--
 		time.Sleep(1 * time.Second)
 	}
-	if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", targetPodName); err != nil {
+	if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", targetPodName, clients); err != nil {
 		log.Errorf("unable to annotate the chaosresult, err :%v", err)
 	}
 	log.Info("Chaos Revert Completed")
@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"os"
+	"testing"
+
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
+)
+
+func TestNewTCHandle(t *testing.T) {
+	// the fake container runs as the test process, so its network namespace is the current one
+	runtime := containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: os.Getpid(), Running: true})
+	pid, err := runtime.PID("abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	netnsPath, err := runtime.NetnsPath("abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(netnsPath); err != nil {
+		t.Fatalf("expected the network namespace of the container at %v, err: %v", netnsPath, err)
+	}
+
+	tc, err := newTCHandle(pid, "lo")
+	if err != nil {
+		if os.Geteuid() != 0 {
+			t.Skipf("entering the network namespace requires privileges, err: %v", err)
+		}
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer tc.close()
+
+	if tc.link.Attrs().Name != "lo" {
+		t.Fatalf("expected the lo link, got %v", tc.link.Attrs().Name)
+	}
+	if _, err := newTCHandle(pid, "litmus-missing0"); err == nil {
+		t.Fatalf("expected an error for the missing interface")
+	}
+}
@@ -0,0 +1,305 @@
+package helper
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// distributionScale is the scale of the distribution table values (NETEM_DIST_SCALE)
+	distributionScale = 8192
+	// distributionSize is the number of entries in the distribution table
+	distributionSize = 4096
+)
+
+// distributions contains the generators of the supported netem delay distributions
+// each generator returns the value (in units of jitter) at the given cumulative probability
+var distributions = map[string]func(p float64) float64{
+	"normal": normalQuantile,
+	"pareto": paretoQuantile,
+	// paretonormal combines 1/4 pareto and 3/4 normal, like the table shipped with iproute2
+	"paretonormal": func(p float64) float64 {
+		return 0.25*paretoQuantile(p) + 0.75*normalQuantile(p)
+	},
+}
+
+// shaper contains the details of the qdisc used to limit the bandwidth
+type shaper struct {
+	// Kind of the shaper qdisc, it can be tbf or htb
+	Kind string
+	// Rate contains the bandwidth (in bits per second)
+	Rate uint64
+	// Ceil contains the maximum bandwidth (in bits per second) of the htb class
+	Ceil uint64
+	// Burst contains the size (in bytes) of the bucket
+	Burst uint32
+	// Limit contains the number of bytes that can be queued waiting for tokens
+	// it is applicable only for tbf
+	Limit uint32
+}
+
+// parseShaperArgs parse the shaper arguments (as accepted by tc)
+// e.g. "tbf rate 1mbit burst 32kbit limit 10000" or "htb rate 1mbit ceil 2mbit burst 15k"
+// it returns empty shaper, if arguments are not provided
+func parseShaperArgs(args string) (shaper, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return shaper{}, nil
+	}
+
+	s := shaper{Kind: fields[0]}
+	if s.Kind != "tbf" && s.Kind != "htb" {
+		return shaper{}, errors.Errorf("shaper '%s' not supported, it supports tbf and htb", s.Kind)
+	}
+
+	fields = fields[1:]
+	if len(fields)%2 != 0 {
+		return shaper{}, errors.Errorf("value is required for %v %v option", s.Kind, fields[len(fields)-1])
+	}
+	for i := 0; i < len(fields); i += 2 {
+		option, value := fields[i], fields[i+1]
+
+		var err error
+		switch option {
+		case "rate":
+			s.Rate, err = parseRate(value)
+		case "ceil":
+			s.Ceil, err = parseRate(value)
+		case "burst":
+			s.Burst, err = parseSize(value)
+		case "limit":
+			s.Limit, err = parseSize(value)
+		default:
+			return shaper{}, errors.Errorf("%v option '%s' not supported", s.Kind, option)
+		}
+		if err != nil {
+			return shaper{}, err
+		}
+	}
+
+	if s.Rate == 0 {
+		return shaper{}, errors.Errorf("rate is required for %v shaper", s.Kind)
+	}
+	if s.Kind == "tbf" {
+		if s.Burst == 0 {
+			return shaper{}, errors.Errorf("burst is required for tbf shaper")
+		}
+		// queue a single burst, if limit is not provided
+		if s.Limit == 0 {
+			s.Limit = s.Burst
+		}
+	}
+	return s, nil
+}
+
+// installShaper attach the shaper qdisc with the given parent and handle
+// it returns the handle of the leaf class, where the next qdisc can be attached
+func (t *tcHandle) installShaper(linkIndex int, parent, handle uint32, s shaper) (uint32, error) {
+	major, _ := netlink.MajorMinor(handle)
+	leaf := netlink.MakeHandle(major, 1)
+	attrs := netlink.QdiscAttrs{
+		LinkIndex: linkIndex,
+		Handle:    handle,
+		Parent:    parent,
+	}
+
+	switch s.Kind {
+	case "tbf":
+		// tbf expects the rate in bytes per second and the buffer in ticks
+		rate := s.Rate / 8
+		tbf := &netlink.Tbf{
+			QdiscAttrs: attrs,
+			Rate:       rate,
+			Limit:      s.Limit,
+			Buffer:     uint32(netlink.Xmittime(rate, s.Burst)),
+		}
+		return leaf, t.handle.QdiscReplace(tbf)
+	default:
+		// all the traffic goes through the default class of htb
+		htb := netlink.NewHtb(attrs)
+		htb.Defcls = 1
+		if err := t.handle.QdiscReplace(htb); err != nil {
+			return 0, err
+		}
+		class := netlink.NewHtbClass(netlink.ClassAttrs{
+			LinkIndex: linkIndex,
+			Parent:    handle,
+			Handle:    leaf,
+		}, netlink.HtbClassAttrs{
+			Rate:    s.Rate,
+			Ceil:    s.Ceil,
+			Buffer:  s.Burst,
+			Cbuffer: s.Burst,
+		})
+		return leaf, t.handle.ClassReplace(class)
+	}
+}
+
+// replaceNetem attach the netem qdisc with the given parent and handle
+// netlink library doesn't support the delay distribution, so the request
+// is built here, if the distribution is provided
+func (t *tcHandle) replaceNetem(linkIndex int, parent, handle uint32, attrs netlink.NetemQdiscAttrs, distribution string) error {
+	netem := netlink.NewNetem(netlink.QdiscAttrs{
+		LinkIndex: linkIndex,
+		Handle:    handle,
+		Parent:    parent,
+	}, attrs)
+	if distribution == "" {
+		return t.handle.QdiscReplace(netem)
+	}
+
+	req := nl.NewNetlinkRequest(unix.RTM_NEWQDISC, unix.NLM_F_CREATE|unix.NLM_F_REPLACE|unix.NLM_F_ACK)
+	req.AddData(&nl.TcMsg{
+		Family:  nl.FAMILY_ALL,
+		Ifindex: int32(linkIndex),
+		Handle:  handle,
+		Parent:  parent,
+	})
+	req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated(netem.Type())))
+
+	opt := nl.TcNetemQopt{
+		Latency:   netem.Latency,
+		Limit:     netem.Limit,
+		Loss:      netem.Loss,
+		Gap:       netem.Gap,
+		Duplicate: netem.Duplicate,
+		Jitter:    netem.Jitter,
+	}
+	options := nl.NewRtAttr(nl.TCA_OPTIONS, opt.Serialize())
+	corr := nl.TcNetemCorr{
+		DelayCorr: netem.DelayCorr,
+		LossCorr:  netem.LossCorr,
+		DupCorr:   netem.DuplicateCorr,
+	}
+	if corr.DelayCorr > 0 || corr.LossCorr > 0 || corr.DupCorr > 0 {
+		options.AddRtAttr(nl.TCA_NETEM_CORR, corr.Serialize())
+	}
+	if netem.CorruptProb > 0 {
+		corrupt := nl.TcNetemCorrupt{Probability: netem.CorruptProb, Correlation: netem.CorruptCorr}
+		options.AddRtAttr(nl.TCA_NETEM_CORRUPT, corrupt.Serialize())
+	}
+	if netem.ReorderProb > 0 {
+		reorder := nl.TcNetemReorder{Probability: netem.ReorderProb, Correlation: netem.ReorderCorr}
+		options.AddRtAttr(nl.TCA_NETEM_REORDER, reorder.Serialize())
+	}
+	options.AddRtAttr(nl.TCA_NETEM_DELAY_DIST, getDistributionTable(distribution))
+	req.AddData(options)
+
+	return t.execute(req)
+}
+
+// execute send the request through the netlink socket opened inside the network namespace of the target
+// and wait for its acknowledgement
+func (t *tcHandle) execute(req *nl.NetlinkRequest) error {
+	sock, err := nl.GetNetlinkSocketAt(t.ns, netns.None(), unix.NETLINK_ROUTE)
+	if err != nil {
+		return err
+	}
+	defer sock.Close()
+
+	if err := sock.Send(req); err != nil {
+		return err
+	}
+	pid, err := sock.GetPid()
+	if err != nil {
+		return err
+	}
+
+	for {
+		msgs, _, err := sock.Receive()
+		if err != nil {
+			return err
+		}
+		for _, m := range msgs {
+			if m.Header.Seq != req.Seq || m.Header.Pid != pid {
+				continue
+			}
+			if m.Header.Type == unix.NLMSG_ERROR {
+				errno := int32(nl.NativeEndian().Uint32(m.Data[0:4]))
+				if errno == 0 {
+					return nil
+				}
+				return syscall.Errno(-errno)
+			}
+		}
+	}
+}
+
+// getDistributionTable returns the serialized distribution table
+// the values are scaled by distributionScale and clamped into the int16 range
+func getDistributionTable(distribution string) []byte {
+	quantile := distributions[distribution]
+	table := make([]byte, 2*distributionSize)
+	for i := 0; i < distributionSize; i++ {
+		value := math.Round(quantile((float64(i)+0.5)/distributionSize) * distributionScale)
+		value = math.Max(math.MinInt16, math.Min(math.MaxInt16, value))
+		nl.NativeEndian().PutUint16(table[2*i:], uint16(int16(value)))
+	}
+	return table
+}
+
+// normalQuantile returns the quantile of the standard normal distribution
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// paretoQuantile returns the quantile of the pareto distribution (with shape 3),
+// shifted and scaled to zero mean and unit standard deviation
+func paretoQuantile(p float64) float64 {
+	const shape = 3.0
+	mean := shape / (shape - 1)
+	stddev := math.Sqrt(shape/(shape-2)) / (shape - 1)
+	return (math.Pow(1-p, -1/shape) - mean) / stddev
+}
+
+// parseRate parse the rate (in tc format) into bits per second
+// it supports bit, kbit, mbit, gbit and bps, kbps, mbps, gbps units, value without unit is treated as bits per second
+func parseRate(value string) (uint64, error) {
+	units := []unit{
+		{"kbit", 1e3}, {"mbit", 1e6}, {"gbit", 1e9}, {"bit", 1},
+		{"kbps", 8e3}, {"mbps", 8e6}, {"gbps", 8e9}, {"bps", 8},
+	}
+	return parseUnit(value, units, math.MaxUint64)
+}
+
+// parseSize parse the size (in tc format) into bytes
+// it supports b, k/kb, m/mb, g/gb and kbit, mbit, gbit units, value without unit is treated as bytes
+func parseSize(value string) (uint32, error) {
+	units := []unit{
+		{"kbit", 1024 / 8}, {"mbit", 1024 * 1024 / 8}, {"gbit", 1024 * 1024 * 1024 / 8},
+		{"kb", 1024}, {"mb", 1024 * 1024}, {"gb", 1024 * 1024 * 1024},
+		{"k", 1024}, {"m", 1024 * 1024}, {"g", 1024 * 1024 * 1024}, {"b", 1},
+	}
+	size, err := parseUnit(value, units, math.MaxUint32)
+	return uint32(size), err
+}
+
+// unit contains the suffix of the unit and its multiplier
+type unit struct {
+	suffix     string
+	multiplier float64
+}
+
+// parseUnit parse the value with the first matching unit suffix
+func parseUnit(value string, units []unit, max float64) (uint64, error) {
+	number, multiplier := strings.ToLower(value), 1.0
+	for _, unit := range units {
+		if strings.HasSuffix(number, unit.suffix) {
+			number, multiplier = strings.TrimSuffix(number, unit.suffix), unit.multiplier
+			break
+		}
+	}
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil || parsed < 0 || parsed*multiplier > max {
+		return 0, errors.Errorf("invalid value: %v", value)
+	}
+	return uint64(parsed * multiplier), nil
+}
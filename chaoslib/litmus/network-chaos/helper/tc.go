@@ -0,0 +1,736 @@
+package helper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+var (
+	// rootHandle is the handle of the root qdisc installed by the chaos
+	rootHandle = netlink.MakeHandle(1, 0)
+	// targetClass is the third band of the prio qdisc, the filtered traffic is redirected to it
+	targetClass = netlink.MakeHandle(1, 3)
+	// shaperHandle is the handle of the shaper qdisc attached to the target class
+	shaperHandle = netlink.MakeHandle(20, 0)
+	// netemHandle is the handle of the netem qdisc attached to the target class
+	netemHandle = netlink.MakeHandle(30, 0)
+	// ingressHandle is the handle of the ingress qdisc
+	ingressHandle = netlink.MakeHandle(0xffff, 0)
+)
+
+// ifbInterface is the name of the ifb device, which receives the ingress traffic
+const ifbInterface = "litmus-ifb0"
+
+// ErrQdiscNotFound is returned when the qdisc installed by the chaos is not present on the interface
+var ErrQdiscNotFound = errors.New("qdisc installed by the chaos not found")
+
+// NetlinkError is returned when a netlink operation fails inside the network namespace of the target
+type NetlinkError struct {
+	Op        string
+	Interface string
+	PID       int
+	Err       error
+}
+
+// Error returns the error message
+func (e *NetlinkError) Error() string {
+	return fmt.Sprintf("unable to %v on %v interface of %v pid, err: %v", e.Op, e.Interface, e.PID, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *NetlinkError) Unwrap() error {
+	return e.Err
+}
+
+// qdiscTree contains the details of the qdisc tree installed by the chaos
+// it is derived before the injection so that the revert removes exactly the same tree
+type qdiscTree struct {
+	Interface string
+	Netem     netlink.NetemQdiscAttrs
+	// Distribution of the netem delay jitter, it can be normal, pareto or paretonormal
+	Distribution string
+	// Shaper limits the bandwidth of the traffic, the netem qdisc is attached to its leaf class
+	Shaper           shaper
+	DestinationIPs   []*net.IPNet
+	DestinationPorts []uint16
+	SourcePorts      []uint16
+	Protocols        []string
+	// Direction of the traffic under chaos, it can be egress, ingress or both
+	Direction string
+}
+
+// isScoped check whether the chaos is scoped to the subset of the traffic
+func (tree qdiscTree) isScoped() bool {
+	return len(tree.DestinationIPs) != 0 || len(tree.DestinationPorts) != 0 || len(tree.SourcePorts) != 0 || len(tree.Protocols) != 0
+}
+
+// hasNetem check whether any of the netem option is provided
+func (tree qdiscTree) hasNetem() bool {
+	return tree.Netem != netlink.NetemQdiscAttrs{}
+}
+
+// rootKind returns the kind of the root qdisc of the tree
+func (tree qdiscTree) rootKind() string {
+	switch {
+	case tree.isScoped():
+		return "prio"
+	case tree.Shaper.Kind != "":
+		return tree.Shaper.Kind
+	default:
+		return "netem"
+	}
+}
+
+// hasEgress check whether the chaos is applied on the egress traffic
+func (tree qdiscTree) hasEgress() bool {
+	return tree.Direction != "ingress"
+}
+
+// hasIngress check whether the chaos is applied on the ingress traffic
+func (tree qdiscTree) hasIngress() bool {
+	return tree.Direction == "ingress" || tree.Direction == "both"
+}
+
+// tcHandle contains the netlink handle opened inside the network namespace of the target
+type tcHandle struct {
+	ns     netns.NsHandle
+	handle *netlink.Handle
+	link   netlink.Link
+	pid    int
+	iface  string
+}
+
+// newTCHandle enter into the network namespace of the given pid and
+// return the handle for the given interface
+func newTCHandle(pid int, iface string) (*tcHandle, error) {
+	ns, err := netns.GetFromPid(pid)
+	if err != nil {
+		return nil, &NetlinkError{Op: "get network namespace", Interface: iface, PID: pid, Err: err}
+	}
+
+	handle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		ns.Close()
+		return nil, &NetlinkError{Op: "open netlink handle", Interface: iface, PID: pid, Err: err}
+	}
+
+	link, err := handle.LinkByName(iface)
+	if err != nil {
+		handle.Delete()
+		ns.Close()
+		return nil, &NetlinkError{Op: "get link", Interface: iface, PID: pid, Err: err}
+	}
+
+	return &tcHandle{ns: ns, handle: handle, link: link, pid: pid, iface: iface}, nil
+}
+
+// close release the netlink handle and the network namespace
+func (t *tcHandle) close() {
+	t.handle.Delete()
+	t.ns.Close()
+}
+
+// wrap convert the netlink error into typed error
+func (t *tcHandle) wrap(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &NetlinkError{Op: op, Interface: t.iface, PID: t.pid, Err: err}
+}
+
+// install programs the qdisc tree for the egress and/or ingress traffic
+// the ingress traffic is redirected to the ifb device and the tree is installed on it
+// the qdiscs installed before a failure are removed, so that the target is not left with the partial tree
+func (t *tcHandle) install(tree qdiscTree) error {
+	if err := t.installTree(tree); err != nil {
+		log.Info("[Chaos]: Removing the partially installed qdisc tree")
+		if revertErr := t.revert(tree); revertErr != nil && !errors.Is(revertErr, ErrQdiscNotFound) {
+			log.Errorf("Unable to remove the partially installed qdisc tree, err: %v", revertErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// installTree programs the qdisc tree for the egress and/or ingress traffic
+func (t *tcHandle) installTree(tree qdiscTree) error {
+	if tree.hasEgress() {
+		if err := t.installOn(t.link, tree, false); err != nil {
+			return err
+		}
+	}
+	if tree.hasIngress() {
+		ifb, err := t.redirectIngressToIFB()
+		if err != nil {
+			return err
+		}
+		if err := t.installOn(ifb, tree, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installOn programs the qdisc tree on the given link
+// if the chaos is not scoped, the qdiscs are attached at the root
+// otherwise a prio qdisc is attached as root and the filtered traffic
+// is redirected to its third band, which contains the rest of the qdiscs
+// the netem qdisc is attached to the leaf class of the shaper, if shaper is provided
+func (t *tcHandle) installOn(link netlink.Link, tree qdiscTree, ingress bool) error {
+	linkIndex := link.Attrs().Index
+	linkName := link.Attrs().Name
+
+	parent, handle := uint32(netlink.HANDLE_ROOT), rootHandle
+	if tree.isScoped() {
+		// Create a priority-based queue
+		// This instantly creates classes 1:1, 1:2, 1:3
+		prio := netlink.NewPrio(netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Handle:    rootHandle,
+			Parent:    netlink.HANDLE_ROOT,
+		})
+		log.Infof("[Chaos]: Adding %v root qdisc on %v interface", prio.Type(), linkName)
+		if err := t.handle.QdiscReplace(prio); err != nil {
+			return t.wrap("replace root prio qdisc on "+linkName, err)
+		}
+		// Add queueing discipline for 1:3 class.
+		// No traffic is going through 1:3 yet
+		parent, handle = targetClass, shaperHandle
+	}
+
+	if tree.Shaper.Kind != "" {
+		log.Infof("[Chaos]: Adding %v qdisc on %v interface", tree.Shaper.Kind, linkName)
+		leaf, err := t.installShaper(linkIndex, parent, handle, tree.Shaper)
+		if err != nil {
+			return t.wrap("replace "+tree.Shaper.Kind+" qdisc on "+linkName, err)
+		}
+		parent, handle = leaf, netemHandle
+	} else if tree.isScoped() {
+		handle = netemHandle
+	}
+
+	if tree.hasNetem() {
+		log.Infof("[Chaos]: Adding netem qdisc on %v interface", linkName)
+		if err := t.replaceNetem(linkIndex, parent, handle, tree.Netem, tree.Distribution); err != nil {
+			return t.wrap("replace netem qdisc on "+linkName, err)
+		}
+	}
+
+	if !tree.isScoped() {
+		return nil
+	}
+
+	// redirect the filtered traffic through band 3
+	filters, err := getFilters(linkIndex, tree, ingress)
+	if err != nil {
+		return err
+	}
+	for _, filter := range filters {
+		if err := t.handle.FilterAdd(filter); err != nil {
+			return t.wrap("add filter on "+linkName, err)
+		}
+	}
+	log.Infof("[Chaos]: Added %v filters on %v interface", len(filters), linkName)
+	return nil
+}
+
+// redirectIngressToIFB creates the ifb device and redirects all the ingress traffic of the interface to it
+// the traffic leaving the ifb device is treated as the egress traffic, so the netem can be applied on it
+// the ifb device and the ingress qdisc left by the previous run are replaced, along with their stale filters
+func (t *tcHandle) redirectIngressToIFB() (netlink.Link, error) {
+	if leftover, err := t.handle.LinkByName(ifbInterface); err == nil {
+		log.Infof("[Chaos]: Replacing the leftover %v interface", ifbInterface)
+		if err := t.handle.LinkDel(leftover); err != nil {
+			return nil, t.wrap("delete leftover "+ifbInterface+" link", err)
+		}
+	}
+	qdisc, err := t.getQdisc(t.link, netlink.HANDLE_INGRESS, ingressHandle, "ingress")
+	if err != nil {
+		return nil, err
+	}
+	if qdisc != nil {
+		if err := t.handle.QdiscDel(qdisc); err != nil {
+			return nil, t.wrap("delete leftover ingress qdisc", err)
+		}
+	}
+
+	ifb := &netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: ifbInterface}}
+	if err := t.handle.LinkAdd(ifb); err != nil {
+		return nil, t.wrap("add "+ifbInterface+" link", err)
+	}
+	link, err := t.handle.LinkByName(ifbInterface)
+	if err != nil {
+		return nil, t.wrap("get "+ifbInterface+" link", err)
+	}
+	if err := t.handle.LinkSetUp(link); err != nil {
+		return nil, t.wrap("set up "+ifbInterface+" link", err)
+	}
+
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: t.link.Attrs().Index,
+			Handle:    ingressHandle,
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	log.Infof("[Chaos]: Redirecting ingress traffic of %v interface to %v interface", t.iface, ifbInterface)
+	if err := t.handle.QdiscReplace(ingress); err != nil {
+		return nil, t.wrap("replace ingress qdisc", err)
+	}
+
+	for _, protocol := range []uint16{unix.ETH_P_IP, unix.ETH_P_IPV6} {
+		redirect := &netlink.U32{
+			FilterAttrs: netlink.FilterAttrs{
+				LinkIndex: t.link.Attrs().Index,
+				Parent:    ingressHandle,
+				Priority:  1,
+				Protocol:  protocol,
+			},
+			Actions: []netlink.Action{netlink.NewMirredAction(link.Attrs().Index)},
+		}
+		if err := t.handle.FilterAdd(redirect); err != nil {
+			return nil, t.wrap("add ingress redirect filter", err)
+		}
+	}
+	return link, nil
+}
+
+// revert removes the qdisc tree installed by the chaos
+// the kernel attaches the default qdisc once the root qdisc is deleted
+// it returns ErrQdiscNotFound, if the tree is already removed
+func (t *tcHandle) revert(tree qdiscTree) error {
+	var isFound bool
+
+	if tree.hasEgress() {
+		qdisc, err := t.getQdisc(t.link, netlink.HANDLE_ROOT, rootHandle, tree.rootKind())
+		if err != nil {
+			return err
+		}
+		if qdisc != nil {
+			log.Infof("[Chaos]: Deleting %v root qdisc on %v interface", qdisc.Type(), t.iface)
+			// deleting the root qdisc also removes its child qdiscs and filters
+			if err := t.handle.QdiscDel(qdisc); err != nil {
+				return t.wrap("delete root "+qdisc.Type()+" qdisc", err)
+			}
+			isFound = true
+		}
+	}
+
+	if tree.hasIngress() {
+		qdisc, err := t.getQdisc(t.link, netlink.HANDLE_INGRESS, ingressHandle, "ingress")
+		if err != nil {
+			return err
+		}
+		if qdisc != nil {
+			log.Infof("[Chaos]: Deleting ingress qdisc on %v interface", t.iface)
+			if err := t.handle.QdiscDel(qdisc); err != nil {
+				return t.wrap("delete ingress qdisc", err)
+			}
+			isFound = true
+		}
+		// deleting the ifb device also removes the qdisc tree installed on it
+		if ifb, err := t.handle.LinkByName(ifbInterface); err == nil {
+			log.Infof("[Chaos]: Deleting %v interface", ifbInterface)
+			if err := t.handle.LinkDel(ifb); err != nil {
+				return t.wrap("delete "+ifbInterface+" link", err)
+			}
+			isFound = true
+		}
+	}
+
+	if !isFound {
+		return ErrQdiscNotFound
+	}
+	return nil
+}
+
+// getQdisc returns the qdisc of the given kind with matching parent and handle
+// it returns nil, if no such qdisc is present on the link
+func (t *tcHandle) getQdisc(link netlink.Link, parent, handle uint32, kind string) (netlink.Qdisc, error) {
+	qdiscs, err := t.handle.QdiscList(link)
+	if err != nil {
+		return nil, t.wrap("list qdiscs", err)
+	}
+	for _, qdisc := range qdiscs {
+		attrs := qdisc.Attrs()
+		if attrs.Parent == parent && attrs.Handle == handle && qdisc.Type() == kind {
+			return qdisc, nil
+		}
+	}
+	return nil, nil
+}
+
+// getFilters returns the u32 filters, which redirect the scoped traffic to the target class
+// the filters are derived for every combination of destination, protocol and ports
+// keys inside a filter are matched together, while a packet matching any of the filters is redirected
+// for the ingress traffic, destination ips and ports are matched against the source address and port of the packets
+func getFilters(linkIndex int, tree qdiscTree, ingress bool) ([]*netlink.U32, error) {
+	ipNets := tree.DestinationIPs
+	if len(ipNets) == 0 {
+		// matching all the ipv4 and ipv6 destinations
+		ipNets = []*net.IPNet{
+			{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)},
+			{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)},
+		}
+	}
+	protocols := tree.Protocols
+	if len(protocols) == 0 {
+		protocols = []string{""}
+	}
+	destinationPorts := tree.DestinationPorts
+	if len(destinationPorts) == 0 {
+		destinationPorts = []uint16{0}
+	}
+	sourcePorts := tree.SourcePorts
+	if len(sourcePorts) == 0 {
+		sourcePorts = []uint16{0}
+	}
+
+	var filters []*netlink.U32
+	for _, ipNet := range ipNets {
+		ipv4 := ipNet.IP.To4() != nil
+		for _, protocol := range protocols {
+			protocolNumber, err := getProtocolNumber(protocol, ipv4)
+			if err != nil {
+				return nil, err
+			}
+			for _, dport := range destinationPorts {
+				for _, sport := range sourcePorts {
+					if protocol == "icmp" && (dport != 0 || sport != 0) {
+						return nil, errors.Errorf("ports can't be used along with icmp protocol")
+					}
+					filters = append(filters, getFilter(linkIndex, ipNet, protocolNumber, dport, sport, ingress))
+				}
+			}
+		}
+	}
+	return filters, nil
+}
+
+// getFilter returns the u32 filter for the given destination, protocol and ports
+// zero value of protocol and ports matches all the values
+func getFilter(linkIndex int, ipNet *net.IPNet, protocol uint8, dport, sport uint16, ingress bool) *netlink.U32 {
+	sel := &netlink.TcU32Sel{Flags: netlink.TC_U32_TERMINAL}
+
+	// offsets of the fields inside the ip headers, ports are matched assuming
+	// the transport header follows the fixed size ip header
+	ethProtocol := uint16(unix.ETH_P_IP)
+	addressOffset, protocolOffset, protocolMask, protocolShift, portOffset := int32(16), int32(8), uint32(0x00ff0000), uint(16), int32(20)
+	if ingress {
+		addressOffset = 12
+	}
+	ip, mask := ipNet.IP.To4(), ipNet.Mask
+	if ip == nil {
+		ethProtocol = unix.ETH_P_IPV6
+		addressOffset, protocolOffset, protocolMask, protocolShift, portOffset = 24, 4, 0x0000ff00, 8, 40
+		if ingress {
+			addressOffset = 8
+		}
+		ip = ipNet.IP.To16()
+	}
+	if len(mask) != len(ip) {
+		mask = mask[len(mask)-len(ip):]
+	}
+
+	for i := 0; i < len(ip); i += 4 {
+		keyMask := binary.BigEndian.Uint32(mask[i : i+4])
+		if keyMask == 0 {
+			continue
+		}
+		sel.Keys = append(sel.Keys, netlink.TcU32Key{
+			Mask: keyMask,
+			Val:  binary.BigEndian.Uint32(ip[i:i+4]) & keyMask,
+			Off:  addressOffset + int32(i),
+		})
+	}
+
+	if protocol != 0 {
+		sel.Keys = append(sel.Keys, netlink.TcU32Key{
+			Mask: protocolMask,
+			Val:  uint32(protocol) << protocolShift,
+			Off:  protocolOffset,
+		})
+	}
+
+	// the remote end is the source of the ingress packets, so the ports are swapped along with the address
+	if ingress {
+		dport, sport = sport, dport
+	}
+
+	// source port and destination port are the first and second half of the transport header's first word
+	if dport != 0 || sport != 0 {
+		key := netlink.TcU32Key{Off: portOffset}
+		if sport != 0 {
+			key.Mask |= 0xffff0000
+			key.Val |= uint32(sport) << 16
+		}
+		if dport != 0 {
+			key.Mask |= 0x0000ffff
+			key.Val |= uint32(dport)
+		}
+		sel.Keys = append(sel.Keys, key)
+	}
+
+	// the filter without selector keys matches all the traffic
+	if len(sel.Keys) == 0 {
+		sel = nil
+	}
+
+	return &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: linkIndex,
+			Parent:    rootHandle,
+			Priority:  3,
+			Protocol:  ethProtocol,
+		},
+		ClassId: targetClass,
+		Sel:     sel,
+	}
+}
+
+// getProtocolNumber returns the ip protocol number for the given protocol
+// it returns zero, if protocol is not provided
+func getProtocolNumber(protocol string, ipv4 bool) (uint8, error) {
+	switch protocol {
+	case "":
+		return 0, nil
+	case "tcp":
+		return unix.IPPROTO_TCP, nil
+	case "udp":
+		return unix.IPPROTO_UDP, nil
+	case "icmp":
+		if ipv4 {
+			return unix.IPPROTO_ICMP, nil
+		}
+		return unix.IPPROTO_ICMPV6, nil
+	default:
+		return 0, errors.Errorf("protocol '%s' not supported, it supports tcp, udp and icmp", protocol)
+	}
+}
+
+// parsePorts parse the comma separated ports
+func parsePorts(ports string) ([]uint16, error) {
+	var portList []uint16
+	for _, port := range strings.Split(ports, ",") {
+		port = strings.TrimSpace(port)
+		if port == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(port, 10, 16)
+		if err != nil || value == 0 {
+			return nil, errors.Errorf("invalid port: %v", port)
+		}
+		portList = append(portList, uint16(value))
+	}
+	return portList, nil
+}
+
+// parseProtocols parse the comma separated protocols
+func parseProtocols(protocols string) ([]string, error) {
+	var protocolList []string
+	for _, protocol := range strings.Split(protocols, ",") {
+		protocol = strings.ToLower(strings.TrimSpace(protocol))
+		if protocol == "" {
+			continue
+		}
+		if _, err := getProtocolNumber(protocol, true); err != nil {
+			return nil, err
+		}
+		protocolList = append(protocolList, protocol)
+	}
+	return protocolList, nil
+}
+
+// parseDestinationIPs parse the comma separated ips and cidrs
+// it removes the duplicate entries, if any
+func parseDestinationIPs(destinationIPs string) ([]*net.IPNet, error) {
+	var ipNets []*net.IPNet
+	if destinationIPs == "" {
+		return ipNets, nil
+	}
+
+	isPresent := map[string]bool{}
+	for _, entry := range strings.Split(destinationIPs, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, errors.Errorf("invalid destination ip: %v", entry)
+			}
+			if ip.To4() != nil {
+				entry = entry + "/32"
+			} else {
+				entry = entry + "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, errors.Errorf("invalid destination ip: %v, err: %v", entry, err)
+		}
+		if isPresent[ipNet.String()] {
+			continue
+		}
+		isPresent[ipNet.String()] = true
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// parseNetemArgs parse the netem arguments (as accepted by tc) into the netem attributes
+// it supports delay (with jitter distribution), loss, duplicate, corrupt, reorder, gap and limit options
+func parseNetemArgs(args string) (netlink.NetemQdiscAttrs, string, error) {
+	attrs := netlink.NetemQdiscAttrs{}
+	distribution := ""
+	fields := strings.Fields(args)
+
+	// optional returns the next field, if it is not an option keyword
+	optional := func(index int) (string, bool) {
+		if index >= len(fields) || isNetemOption(fields[index]) {
+			return "", false
+		}
+		return fields[index], true
+	}
+
+	for i := 0; i < len(fields); i++ {
+		option := fields[i]
+		value, ok := optional(i + 1)
+		if !ok {
+			return attrs, "", errors.Errorf("value is required for %v netem option", option)
+		}
+		i++
+
+		var err error
+		switch option {
+		case "delay":
+			if attrs.Latency, err = parseNetemTime(value); err != nil {
+				return attrs, "", err
+			}
+			if jitter, ok := optional(i + 1); ok {
+				i++
+				if attrs.Jitter, err = parseNetemTime(jitter); err != nil {
+					return attrs, "", err
+				}
+				if corr, ok := optional(i + 1); ok {
+					i++
+					if attrs.DelayCorr, err = parseNetemPercentage(corr); err != nil {
+						return attrs, "", err
+					}
+				}
+			}
+		case "loss":
+			// "loss random <pct>" is same as "loss <pct>"
+			if value == "random" {
+				if value, ok = optional(i + 1); !ok {
+					return attrs, "", errors.Errorf("value is required for loss netem option")
+				}
+				i++
+			}
+			if attrs.Loss, attrs.LossCorr, i, err = parsePercentageWithCorrelation(value, i, optional); err != nil {
+				return attrs, "", err
+			}
+		case "duplicate":
+			if attrs.Duplicate, attrs.DuplicateCorr, i, err = parsePercentageWithCorrelation(value, i, optional); err != nil {
+				return attrs, "", err
+			}
+		case "corrupt":
+			if attrs.CorruptProb, attrs.CorruptCorr, i, err = parsePercentageWithCorrelation(value, i, optional); err != nil {
+				return attrs, "", err
+			}
+		case "reorder":
+			if attrs.ReorderProb, attrs.ReorderCorr, i, err = parsePercentageWithCorrelation(value, i, optional); err != nil {
+				return attrs, "", err
+			}
+		case "distribution":
+			if _, ok := distributions[value]; !ok {
+				return attrs, "", errors.Errorf("distribution '%s' not supported, it supports normal, pareto and paretonormal", value)
+			}
+			distribution = value
+		case "gap", "limit":
+			number, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return attrs, "", errors.Errorf("invalid value %v for %v netem option, err: %v", value, option, err)
+			}
+			if option == "gap" {
+				attrs.Gap = uint32(number)
+			} else {
+				attrs.Limit = uint32(number)
+			}
+		default:
+			return attrs, "", errors.Errorf("netem option '%s' not supported", option)
+		}
+	}
+	if distribution != "" && attrs.Jitter == 0 {
+		return attrs, "", errors.Errorf("jitter is required for the delay distribution")
+	}
+	return attrs, distribution, nil
+}
+
+// parsePercentageWithCorrelation parse the percentage and the optional correlation followed by it
+func parsePercentageWithCorrelation(value string, index int, optional func(int) (string, bool)) (float32, float32, int, error) {
+	percentage, err := parseNetemPercentage(value)
+	if err != nil {
+		return 0, 0, index, err
+	}
+	corr, ok := optional(index + 1)
+	if !ok {
+		return percentage, 0, index, nil
+	}
+	correlation, err := parseNetemPercentage(corr)
+	if err != nil {
+		return 0, 0, index, err
+	}
+	return percentage, correlation, index + 1, nil
+}
+
+// isNetemOption check whether the given field is the netem option keyword
+func isNetemOption(field string) bool {
+	switch field {
+	case "delay", "loss", "duplicate", "corrupt", "reorder", "gap", "limit", "distribution":
+		return true
+	}
+	return false
+}
+
+// parseNetemTime parse the time (in tc format) into microseconds
+// it supports us, ms and s units, value without unit is treated as microseconds
+func parseNetemTime(value string) (uint32, error) {
+	unit := time.Microsecond
+	number := value
+	switch {
+	case strings.HasSuffix(value, "us"), strings.HasSuffix(value, "usec"):
+		number = strings.TrimSuffix(strings.TrimSuffix(value, "usec"), "us")
+	case strings.HasSuffix(value, "ms"), strings.HasSuffix(value, "msec"):
+		unit = time.Millisecond
+		number = strings.TrimSuffix(strings.TrimSuffix(value, "msec"), "ms")
+	case strings.HasSuffix(value, "s"), strings.HasSuffix(value, "sec"):
+		unit = time.Second
+		number = strings.TrimSuffix(strings.TrimSuffix(value, "sec"), "s")
+	}
+	t, err := strconv.ParseFloat(number, 64)
+	if err != nil || t < 0 {
+		return 0, errors.Errorf("invalid time value: %v", value)
+	}
+	return uint32(time.Duration(t*float64(unit)) / time.Microsecond), nil
+}
+
+// parseNetemPercentage parse the percentage value, the % suffix is optional
+func parseNetemPercentage(value string) (float32, error) {
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 32)
+	if err != nil || percentage < 0 || percentage > 100 {
+		return 0, errors.Errorf("invalid percentage value: %v", value)
+	}
+	return float32(percentage), nil
+}
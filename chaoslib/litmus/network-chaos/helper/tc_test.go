@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"net"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+)
+
+// findKey returns the selector key of the filter at the given offset
+func findKey(t *testing.T, filter *netlink.U32, offset int32) netlink.TcU32Key {
+	t.Helper()
+	for _, key := range filter.Sel.Keys {
+		if key.Off == offset {
+			return key
+		}
+	}
+	t.Fatalf("no selector key found at %v offset", offset)
+	return netlink.TcU32Key{}
+}
+
+func TestGetFilterPorts(t *testing.T) {
+	_, ipNet, _ := net.ParseCIDR("10.0.0.1/32")
+
+	tests := []struct {
+		name        string
+		ingress     bool
+		addressOff  int32
+		wantPortVal uint32
+	}{
+		// egress packets are sent to the destination port from the source port
+		{name: "egress", addressOff: 16, wantPortVal: 8080<<16 | 443},
+		// ingress packets are received from the destination port on the source port
+		{name: "ingress", ingress: true, addressOff: 12, wantPortVal: 443<<16 | 8080},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := getFilter(1, ipNet, 6, 443, 8080, tt.ingress)
+
+			address := findKey(t, filter, tt.addressOff)
+			if address.Val != 0x0a000001 {
+				t.Fatalf("expected the address key to match 10.0.0.1, got %#x", address.Val)
+			}
+			ports := findKey(t, filter, 20)
+			if ports.Mask != 0xffffffff || ports.Val != tt.wantPortVal {
+				t.Fatalf("expected the ports key %#x, got %#x with %#x mask", tt.wantPortVal, ports.Val, ports.Mask)
+			}
+		})
+	}
+}
+
+func TestGetFilterSinglePortIngress(t *testing.T) {
+	_, ipNet, _ := net.ParseCIDR("10.0.0.0/8")
+
+	// destination port of the remote service is the source port of the ingress packets
+	filter := getFilter(1, ipNet, 6, 443, 0, true)
+	ports := findKey(t, filter, 20)
+	if ports.Mask != 0xffff0000 || ports.Val != 443<<16 {
+		t.Fatalf("expected the source port key to match 443, got %#x with %#x mask", ports.Val, ports.Mask)
+	}
+}
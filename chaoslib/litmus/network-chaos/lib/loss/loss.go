@@ -7,11 +7,21 @@ import (
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 //PodNetworkLossChaos contains the steps to prepare and inject chaos
 func PodNetworkLossChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
-	args := "loss " + strconv.Itoa(experimentsDetails.NetworkPacketLossPercentage)
-	return network_chaos.PrepareAndInjectChaos(experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, args)
+	return network_chaos.PrepareAndInjectChaos(experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, getNetemArgs(experimentsDetails))
+}
+
+// PlanPodNetworkLossChaos derives the target pods and the helper pods of the chaos, without creating them
+func PlanPodNetworkLossChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, []apiv1.Pod, error) {
+	return network_chaos.PlanNetworkChaos(experimentsDetails, clients, chaosDetails, getNetemArgs(experimentsDetails))
+}
+
+// getNetemArgs derive the netem arguments for the loss
+func getNetemArgs(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	return "loss " + strconv.Itoa(experimentsDetails.NetworkPacketLossPercentage)
 }
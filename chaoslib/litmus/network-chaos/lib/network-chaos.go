@@ -21,14 +21,67 @@ import (
 //PrepareAndInjectChaos contains the prepration & injection steps
 func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, args string) error {
 
-	// Get the target pod details for the chaos execution
-	// if the target pod is not defined it will derive the random target pod list using pod affected percentage
-	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" {
-		return errors.Errorf("please provide one of the appLabel or TARGET_PODS")
+	targetPodList, err := getTargetPods(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return err
+	}
+
+	//Waiting for the ramp time before chaos injection
+	if experimentsDetails.RampTime != 0 {
+		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
+		common.WaitForDuration(experimentsDetails.RampTime)
+	}
+
+	if err := setHelperDetails(experimentsDetails, clients, chaosDetails, targetPodList); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(experimentsDetails.Sequence) {
+	case "serial":
+		if err = injectChaosInSerialMode(experimentsDetails, targetPodList, clients, chaosDetails, args, resultDetails, eventsDetails); err != nil {
+			return err
+		}
+	case "parallel":
+		if err = injectChaosInParallelMode(experimentsDetails, targetPodList, clients, chaosDetails, args, resultDetails, eventsDetails); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("%v sequence is not supported", experimentsDetails.Sequence)
+	}
+
+	return nil
+}
+
+// PlanNetworkChaos derives the target pods and the helper pods of the network chaos, without creating the helper pods
+func PlanNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, args string) (apiv1.PodList, []apiv1.Pod, error) {
+
+	targetPodList, err := getTargetPods(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return apiv1.PodList{}, nil, err
+	}
+
+	if err := setHelperDetails(experimentsDetails, clients, chaosDetails, targetPodList); err != nil {
+		return apiv1.PodList{}, nil, err
+	}
+
+	labelSuffix := common.GetRunID()
+	helperPods := []apiv1.Pod{}
+	for _, pod := range targetPodList.Items {
+		helperPods = append(helperPods, *getHelperPod(experimentsDetails, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, common.GetRunID(), args, labelSuffix))
+	}
+	return targetPodList, helperPods, nil
+}
+
+// getTargetPods derive the target pods for the chaos execution
+// if the target pod is not defined it will derive the random target pod list using pod affected percentage
+func getTargetPods(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, error) {
+
+	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" && !chaosDetails.AppDetail.IsMultiTarget() {
+		return apiv1.PodList{}, errors.Errorf("please provide one of the appLabel or TARGET_PODS")
 	}
 	targetPodList, err := common.GetPodList(experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
 	if err != nil {
-		return err
+		return apiv1.PodList{}, err
 	}
 
 	podNames := []string{}
@@ -36,13 +89,13 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 		podNames = append(podNames, pod.Name)
 	}
 	log.Infof("Target pods list for chaos, %v", podNames)
+	return targetPodList, nil
+}
 
-	//Waiting for the ramp time before chaos injection
-	if experimentsDetails.RampTime != 0 {
-		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
-	}
+// setHelperDetails derive the serviceAccountName, target container, destination ips and helper data required by the helper pods
+func setHelperDetails(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, targetPodList apiv1.PodList) error {
 
+	var err error
 	// Getting the serviceAccountName, need permission inside helper pod to create the events
 	if experimentsDetails.ChaosServiceAccount == "" {
 		experimentsDetails.ChaosServiceAccount, err = common.GetServiceAccount(experimentsDetails.ChaosNamespace, experimentsDetails.ChaosPodName, clients)
@@ -53,7 +106,7 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 
 	//Get the target container name of the application pod
 	if experimentsDetails.TargetContainer == "" {
-		experimentsDetails.TargetContainer, err = common.GetTargetContainer(experimentsDetails.AppNS, targetPodList.Items[0].Name, clients)
+		experimentsDetails.TargetContainer, err = common.GetTargetContainer(targetPodList.Items[0].Namespace, targetPodList.Items[0].Name, clients)
 		if err != nil {
 			return errors.Errorf("unable to get the target container name, err: %v", err)
 		}
@@ -69,20 +122,6 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 			return err
 		}
 	}
-
-	switch strings.ToLower(experimentsDetails.Sequence) {
-	case "serial":
-		if err = injectChaosInSerialMode(experimentsDetails, targetPodList, clients, chaosDetails, args, resultDetails, eventsDetails); err != nil {
-			return err
-		}
-	case "parallel":
-		if err = injectChaosInParallelMode(experimentsDetails, targetPodList, clients, chaosDetails, args, resultDetails, eventsDetails); err != nil {
-			return err
-		}
-	default:
-		return errors.Errorf("%v sequence is not supported", experimentsDetails.Sequence)
-	}
-
 	return nil
 }
 
@@ -92,7 +131,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -107,7 +146,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 			"ContainerName": experimentsDetails.TargetContainer,
 		})
 		runID := common.GetRunID()
-		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Spec.NodeName, runID, args, labelSuffix); err != nil {
+		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, runID, args, labelSuffix); err != nil {
 			return errors.Errorf("unable to create the helper pod, err: %v", err)
 		}
 
@@ -145,7 +184,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -160,7 +199,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 			"ContainerName": experimentsDetails.TargetContainer,
 		})
 		runID := common.GetRunID()
-		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Spec.NodeName, runID, args, labelSuffix); err != nil {
+		if err := createHelperPod(experimentsDetails, clients, chaosDetails, pod.Name, pod.Namespace, pod.Spec.NodeName, runID, args, labelSuffix); err != nil {
 			return errors.Errorf("unable to create the helper pod, err: %v", err)
 		}
 	}
@@ -193,7 +232,14 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 }
 
 // createHelperPod derive the attributes for helper pod and create the helper pod
-func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, podName, nodeName, runID, args, labelSuffix string) error {
+func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, podName, podNamespace, nodeName, runID, args, labelSuffix string) error {
+	helperPod := getHelperPod(experimentsDetails, chaosDetails, podName, podNamespace, nodeName, runID, args, labelSuffix)
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, podName, podNamespace, nodeName, runID, args, labelSuffix string) *apiv1.Pod {
 
 	privilegedEnable := true
 	terminationGracePeriodSeconds := int64(experimentsDetails.TerminationGracePeriodSeconds)
@@ -236,7 +282,7 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 						"./helpers -name network-chaos",
 					},
 					Resources: chaosDetails.Resources,
-					Env:       getPodEnv(experimentsDetails, podName, args),
+					Env:       getPodEnv(experimentsDetails, podName, podNamespace, args),
 					VolumeMounts: []apiv1.VolumeMount{
 						{
 							Name:      "cri-socket",
@@ -256,17 +302,14 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
-
+	return helperPod
 }
 
 // getPodEnv derive all the env required for the helper pod
-func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName, args string) []apiv1.EnvVar {
+func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName, podNamespace, args string) []apiv1.EnvVar {
 
 	var envDetails common.ENVDetails
-	envDetails.SetEnv("APP_NAMESPACE", experimentsDetails.AppNS).
+	envDetails.SetEnv("APP_NAMESPACE", podNamespace).
 		SetEnv("APP_POD", podName).
 		SetEnv("APP_CONTAINER", experimentsDetails.TargetContainer).
 		SetEnv("TOTAL_CHAOS_DURATION", strconv.Itoa(experimentsDetails.ChaosDuration)).
@@ -275,16 +318,38 @@ func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, podName, a
 		SetEnv("CHAOS_UID", string(experimentsDetails.ChaosUID)).
 		SetEnv("CONTAINER_RUNTIME", experimentsDetails.ContainerRuntime).
 		SetEnv("NETEM_COMMAND", args).
+		SetEnv("SHAPING_COMMAND", getShapingCommand(experimentsDetails)).
 		SetEnv("NETWORK_INTERFACE", experimentsDetails.NetworkInterface).
 		SetEnv("EXPERIMENT_NAME", experimentsDetails.ExperimentName).
 		SetEnv("SOCKET_PATH", experimentsDetails.SocketPath).
 		SetEnv("DESTINATION_IPS", experimentsDetails.DestinationIPs).
+		SetEnv("DESTINATION_PORTS", experimentsDetails.DestinationPorts).
+		SetEnv("SOURCE_PORTS", experimentsDetails.SourcePorts).
+		SetEnv("PROTOCOLS", experimentsDetails.Protocols).
+		SetEnv("TRAFFIC_DIRECTION", experimentsDetails.TrafficDirection).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
+		SetLogEnv().
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
 	return envDetails.ENV
 }
 
+// getShapingCommand derive the shaping arguments (as accepted by tc) for the helper
+// it returns empty string, if bandwidth is not provided
+func getShapingCommand(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	if experimentsDetails.NetworkBandwidth == "" {
+		return ""
+	}
+	args := experimentsDetails.Shaper + " rate " + experimentsDetails.NetworkBandwidth
+	if experimentsDetails.Burst != "" {
+		args += " burst " + experimentsDetails.Burst
+	}
+	if experimentsDetails.Limit != "" && experimentsDetails.Shaper == "tbf" {
+		args += " limit " + experimentsDetails.Limit
+	}
+	return args
+}
+
 // GetTargetIps return the comma separated target ips
 // It fetch the ips from the target ips (if defined by users)
 // it append the ips from the host, if target host is provided
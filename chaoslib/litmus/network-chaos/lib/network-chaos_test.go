@@ -0,0 +1,55 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPlanNetworkChaos(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "nginx-1", Namespace: "default"},
+		Spec:       apiv1.PodSpec{NodeName: "node-1", Containers: []apiv1.Container{{Name: "nginx"}}},
+	}
+	kubeClient := fake.NewSimpleClientset(pod)
+	experimentsDetails := &experimentTypes.ExperimentDetails{
+		ExperimentName:      "pod-network-latency",
+		ChaosNamespace:      "litmus",
+		ChaosServiceAccount: "litmus-admin",
+		TargetPods:          "nginx-1",
+		DestinationIPs:      "10.0.0.1",
+	}
+	chaosDetails := &types.ChaosDetails{AppDetail: types.AppDetails{Namespace: "default"}}
+
+	targetPodList, helperPods, err := PlanNetworkChaos(experimentsDetails, clients.ClientSets{KubeClient: kubeClient}, chaosDetails, "delay 2000ms")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targetPodList.Items) != 1 || targetPodList.Items[0].Name != "nginx-1" {
+		t.Fatalf("expected nginx-1 target pod, got %v", targetPodList.Items)
+	}
+	if len(helperPods) != 1 || helperPods[0].Spec.NodeName != "node-1" || helperPods[0].Namespace != "litmus" {
+		t.Fatalf("expected the helper pod on node-1 in litmus namespace, got %+v", helperPods)
+	}
+	env := map[string]string{}
+	for _, e := range helperPods[0].Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["NETEM_COMMAND"] != "delay 2000ms" || env["APP_CONTAINER"] != "nginx" || env["DESTINATION_IPS"] != "10.0.0.1" {
+		t.Fatalf("expected the netem command, target container and destination ips in the helper env, got %v", env)
+	}
+
+	// the helper pods are only planned, not created
+	podList, err := kubeClient.CoreV1().Pods("litmus").List(v1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(podList.Items) != 0 {
+		t.Fatalf("expected no helper pod to be created, got %v", podList.Items)
+	}
+}
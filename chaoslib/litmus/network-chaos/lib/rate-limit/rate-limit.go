@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"strconv"
+	"strings"
+
+	network_chaos "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib"
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+//PodNetworkRateLimitChaos contains the steps to prepare and inject chaos
+func PodNetworkRateLimitChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+
+	if err := validate(experimentsDetails); err != nil {
+		return err
+	}
+	return network_chaos.PrepareAndInjectChaos(experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, getNetemArgs(experimentsDetails))
+}
+
+// PlanPodNetworkRateLimitChaos derives the target pods and the helper pods of the chaos, without creating them
+func PlanPodNetworkRateLimitChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (apiv1.PodList, []apiv1.Pod, error) {
+
+	if err := validate(experimentsDetails); err != nil {
+		return apiv1.PodList{}, nil, err
+	}
+	return network_chaos.PlanNetworkChaos(experimentsDetails, clients, chaosDetails, getNetemArgs(experimentsDetails))
+}
+
+// getNetemArgs derive the netem arguments for the delay and loss
+// the bandwidth is limited by the shaper, which is derived inside the network-chaos lib
+func getNetemArgs(experimentsDetails *experimentTypes.ExperimentDetails) string {
+	var args []string
+	if experimentsDetails.NetworkLatency != 0 {
+		args = append(args, "delay", strconv.Itoa(experimentsDetails.NetworkLatency)+"ms")
+		if experimentsDetails.Jitter != 0 {
+			args = append(args, strconv.Itoa(experimentsDetails.Jitter)+"ms")
+			if experimentsDetails.JitterDistribution != "" {
+				args = append(args, "distribution", experimentsDetails.JitterDistribution)
+			}
+		}
+	}
+	if experimentsDetails.NetworkPacketLossPercentage != 0 {
+		args = append(args, "loss", strconv.Itoa(experimentsDetails.NetworkPacketLossPercentage))
+		if experimentsDetails.LossCorrelation != 0 {
+			args = append(args, strconv.Itoa(experimentsDetails.LossCorrelation))
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// validate validates the rate limit inputs before creating the helper pods
+func validate(experimentsDetails *experimentTypes.ExperimentDetails) error {
+	switch {
+	case experimentsDetails.NetworkBandwidth == "":
+		return errors.Errorf("please provide the NETWORK_BANDWIDTH")
+	case experimentsDetails.Shaper != "tbf" && experimentsDetails.Shaper != "htb":
+		return errors.Errorf("shaper '%s' not supported, it supports tbf and htb", experimentsDetails.Shaper)
+	case experimentsDetails.Shaper == "tbf" && experimentsDetails.Burst == "":
+		return errors.Errorf("please provide the BURST for tbf shaper")
+	}
+
+	switch experimentsDetails.JitterDistribution {
+	case "", "normal", "pareto", "paretonormal":
+	default:
+		return errors.Errorf("jitter distribution '%s' not supported, it supports normal, pareto and paretonormal", experimentsDetails.JitterDistribution)
+	}
+	if experimentsDetails.JitterDistribution != "" && (experimentsDetails.NetworkLatency == 0 || experimentsDetails.Jitter == 0) {
+		return errors.Errorf("please provide the NETWORK_LATENCY and JITTER to use the jitter distribution")
+	}
+	return nil
+}
@@ -64,6 +64,35 @@ func PrepareNodeCPUHog(experimentsDetails *experimentTypes.ExperimentDetails, cl
 	return nil
 }
 
+// PlanNodeCPUHog derives the target nodes and the helper pods of the node-cpu-hog chaos, without creating the helper pods
+func PlanNodeCPUHog(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, []apiv1.Pod, error) {
+
+	targetNodeList, err := common.GetNodeList(experimentsDetails.TargetNodes, experimentsDetails.NodeLabel, experimentsDetails.NodesAffectedPerc, clients)
+	if err != nil {
+		return nil, nil, err
+	}
+	if experimentsDetails.EngineName != "" {
+		if err := common.SetHelperData(chaosDetails, clients); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	nodeCPUCores := experimentsDetails.NodeCPUcores
+	labelSuffix := common.GetRunID()
+	helperPods := []apiv1.Pod{}
+	for _, appNode := range targetNodeList {
+		// When number of cpu cores for hogging is not defined , it will take it from node capacity
+		if nodeCPUCores == 0 {
+			if err := setCPUCapacity(experimentsDetails, appNode, clients); err != nil {
+				return nil, nil, err
+			}
+		}
+		experimentsDetails.RunID = common.GetRunID()
+		helperPods = append(helperPods, *getHelperPod(experimentsDetails, chaosDetails, appNode, labelSuffix))
+	}
+	return targetNodeList, helperPods, nil
+}
+
 // injectChaosInSerialMode stress the cpu of all the target nodes serially (one by one)
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, targetNodeList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
@@ -71,7 +100,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -139,7 +168,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -218,6 +247,13 @@ func setCPUCapacity(experimentsDetails *experimentTypes.ExperimentDetails, appNo
 
 // createHelperPod derive the attributes for helper pod and create the helper pod
 func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appNode string, clients clients.ClientSets, labelSuffix string) error {
+	helperPod := getHelperPod(experimentsDetails, chaosDetails, appNode, labelSuffix)
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appNode string, labelSuffix string) *apiv1.Pod {
 
 	terminationGracePeriodSeconds := int64(experimentsDetails.TerminationGracePeriodSeconds)
 
@@ -252,7 +288,5 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chao
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
+	return helperPod
 }
@@ -0,0 +1,52 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-cpu-hog/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPlanNodeCPUHog(t *testing.T) {
+	node := &apiv1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: "node-1"},
+		Status:     apiv1.NodeStatus{Capacity: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("4")}},
+	}
+	kubeClient := fake.NewSimpleClientset(node)
+	experimentsDetails := &experimentTypes.ExperimentDetails{
+		ExperimentName: "node-cpu-hog",
+		ChaosNamespace: "litmus",
+		TargetNodes:    "node-1",
+		ChaosDuration:  60,
+	}
+	chaosDetails := &types.ChaosDetails{}
+
+	targetNodeList, helperPods, err := PlanNodeCPUHog(experimentsDetails, clients.ClientSets{KubeClient: kubeClient}, chaosDetails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targetNodeList) != 1 || targetNodeList[0] != "node-1" {
+		t.Fatalf("expected node-1 target node, got %v", targetNodeList)
+	}
+	if len(helperPods) != 1 || helperPods[0].Spec.NodeName != "node-1" || helperPods[0].Namespace != "litmus" {
+		t.Fatalf("expected the helper pod on node-1 in litmus namespace, got %+v", helperPods)
+	}
+	// the cpu cores are derived from the node capacity, if not provided
+	if args := helperPods[0].Spec.Containers[0].Args; len(args) < 2 || args[1] != "4" {
+		t.Fatalf("expected the helper pod to hog 4 cpu cores, got %v", args)
+	}
+
+	// the helper pods are only planned, not created
+	podList, err := kubeClient.CoreV1().Pods("litmus").List(v1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(podList.Items) != 0 {
+		t.Fatalf("expected no helper pod to be created, got %v", podList.Items)
+	}
+}
@@ -0,0 +1,215 @@
+package lib
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-drain/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+// BlockedEvictionsAnnotation is the chaosresult annotation, which contains the evictions blocked by the pod disruption budgets
+const BlockedEvictionsAnnotation = "litmuschaos.io/blocked-evictions"
+
+// mirrorPodAnnotation is the annotation of the static pods, which are mirrored by the kubelet
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// BlockedEviction contains the details of the pod, whose eviction is rejected till the drain timeout
+type BlockedEviction struct {
+	Pod       string   `json:"pod"`
+	Namespace string   `json:"namespace"`
+	PDBs      []string `json:"pdbs,omitempty"`
+	Reason    string   `json:"reason"`
+	Attempts  int      `json:"attempts"`
+}
+
+// setUnschedulable cordon or uncordon the node, using the strategic merge patch
+func setUnschedulable(nodeName string, unschedulable bool, clients clients.ClientSets) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": unschedulable,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = clients.KubeClient.CoreV1().Nodes().Patch(nodeName, clientTypes.StrategicMergePatchType, patch)
+	return err
+}
+
+// getPodsToEvict returns the pods scheduled on the node, which are matched with the pod selector
+// the completed pods are skipped, the daemonset and mirror pods are skipped if the respective options are enabled
+func getPodsToEvict(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) ([]apiv1.Pod, error) {
+
+	podList, err := clients.KubeClient.CoreV1().Pods("").List(v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", experimentsDetails.TargetNode).String(),
+		LabelSelector: experimentsDetails.DrainPodSelector,
+	})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the pods of %v node, err: %v", experimentsDetails.TargetNode, err)
+	}
+
+	pods := []apiv1.Pod{}
+	for _, pod := range podList.Items {
+		switch {
+		case pod.Status.Phase == apiv1.PodSucceeded || pod.Status.Phase == apiv1.PodFailed:
+			continue
+		case experimentsDetails.SkipMirrorPods && isMirrorPod(pod):
+			log.Infof("[Drain]: Skipping the %v mirror pod", pod.Name)
+			continue
+		case experimentsDetails.IgnoreDaemonSets && isDaemonSetPod(pod):
+			log.Infof("[Drain]: Skipping the %v daemonset pod", pod.Name)
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// isMirrorPod checks whether the pod is the mirror of the static pod
+func isMirrorPod(pod apiv1.Pod) bool {
+	_, ok := pod.Annotations[mirrorPodAnnotation]
+	return ok
+}
+
+// isDaemonSetPod checks whether the pod is controlled by the daemonset
+func isDaemonSetPod(pod apiv1.Pod) bool {
+	controller := v1.GetControllerOf(&pod)
+	return controller != nil && controller.Kind == "DaemonSet"
+}
+
+// evictPods evicts all the pods in parallel, using the eviction subresource
+// the evictions rejected by the pod disruption budgets (429) are retried till the timeout
+// it returns the evictions, which are still blocked after the timeout
+func evictPods(pods []apiv1.Pod, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) ([]BlockedEviction, error) {
+
+	deadline := time.Now().Add(time.Duration(experimentsDetails.ChaosDuration) * time.Second)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		blocked []BlockedEviction
+		errs    []string
+	)
+	for _, pod := range pods {
+		wg.Add(1)
+		go func(pod apiv1.Pod) {
+			defer wg.Done()
+			blockedEviction, err := evictPod(pod, deadline, experimentsDetails, clients)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err.Error())
+				return
+			}
+			if blockedEviction != nil {
+				blocked = append(blocked, *blockedEviction)
+			}
+		}(pod)
+	}
+	wg.Wait()
+
+	if len(errs) != 0 {
+		return blocked, errors.Errorf("unable to evict the pods of %v node, err: %v", experimentsDetails.TargetNode, strings.Join(errs, "; "))
+	}
+	return blocked, nil
+}
+
+// evictPod evicts the pod and waits till it is deleted
+// it returns the blocked eviction, if the eviction is rejected by the pod disruption budget till the deadline
+func evictPod(pod apiv1.Pod, deadline time.Time, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (*BlockedEviction, error) {
+
+	eviction := &policyv1beta1.Eviction{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+
+	attempts := 0
+	for {
+		attempts++
+		err := clients.KubeClient.PolicyV1beta1().Evictions(pod.Namespace).Evict(eviction)
+		switch {
+		case err == nil:
+			log.Infof("[Drain]: The %v pod of %v namespace has been evicted", pod.Name, pod.Namespace)
+			return nil, waitForPodDeletion(pod, deadline, experimentsDetails, clients)
+		case k8serrors.IsNotFound(err):
+			return nil, nil
+		case !k8serrors.IsTooManyRequests(err):
+			return nil, errors.Errorf("unable to evict the %v pod of %v namespace, err: %v", pod.Name, pod.Namespace, err)
+		}
+
+		// the eviction is rejected by the pod disruption budget, retry it till the deadline
+		if time.Now().Add(time.Duration(experimentsDetails.EvictionRetryInterval) * time.Second).After(deadline) {
+			log.Warnf("[Drain]: The eviction of the %v pod of %v namespace is blocked, err: %v", pod.Name, pod.Namespace, err)
+			return &BlockedEviction{
+				Pod:       pod.Name,
+				Namespace: pod.Namespace,
+				PDBs:      getMatchingPDBs(pod, clients),
+				Reason:    err.Error(),
+				Attempts:  attempts,
+			}, nil
+		}
+		log.Infof("[Drain]: The eviction of the %v pod is rejected, retrying in %vs, err: %v", pod.Name, experimentsDetails.EvictionRetryInterval, err)
+		time.Sleep(time.Duration(experimentsDetails.EvictionRetryInterval) * time.Second)
+	}
+}
+
+// waitForPodDeletion waits till the evicted pod is deleted or replaced by the pod with the same name
+func waitForPodDeletion(pod apiv1.Pod, deadline time.Time, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
+	for {
+		currentPod, err := clients.KubeClient.CoreV1().Pods(pod.Namespace).Get(pod.Name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) || (err == nil && currentPod.UID != pod.UID) {
+			return nil
+		}
+		if err != nil {
+			return errors.Errorf("unable to get the %v pod of %v namespace, err: %v", pod.Name, pod.Namespace, err)
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("the evicted %v pod of %v namespace is not deleted within the timeout", pod.Name, pod.Namespace)
+		}
+		time.Sleep(time.Duration(experimentsDetails.Delay) * time.Second)
+	}
+}
+
+// getMatchingPDBs returns the pod disruption budgets, which selects the pod
+func getMatchingPDBs(pod apiv1.Pod, clients clients.ClientSets) []string {
+	pdbList, err := clients.KubeClient.PolicyV1beta1().PodDisruptionBudgets(pod.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		log.Warnf("[Drain]: Unable to list the pod disruption budgets of %v namespace, err: %v", pod.Namespace, err)
+		return nil
+	}
+
+	pdbs := []string{}
+	for _, pdb := range pdbList.Items {
+		selector, err := v1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		pdbs = append(pdbs, pdb.Name)
+	}
+	return pdbs
+}
+
+// recordBlockedEvictions adds the blocked evictions to the chaosresult annotation
+func recordBlockedEvictions(blocked []BlockedEviction, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	data, err := json.Marshal(blocked)
+	if err != nil {
+		return err
+	}
+	return result.SetResultAnnotations(resultDetails.Name, chaosDetails.ChaosNamespace, map[string]string{BlockedEvictionsAnnotation: string(data)}, clients)
+}
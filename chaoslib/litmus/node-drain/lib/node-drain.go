@@ -1,11 +1,8 @@
 package lib
 
 import (
-	"bytes"
 	"os"
-	"os/exec"
 	"os/signal"
-	"strconv"
 	"syscall"
 	"time"
 
@@ -19,6 +16,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -61,7 +59,7 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	}
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -70,8 +68,11 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	// watching for the abort signal and revert the chaos
 	go abortWatcher(experimentsDetails, clients, resultDetails, chaosDetails, eventsDetails)
 
+	// notify the chaos injection, the recovery of the targets is measured from here
+	chaosDetails.MarkInjected()
+
 	// Drain the application node
-	if err := drainNode(experimentsDetails, clients, chaosDetails); err != nil {
+	if err := drainNode(experimentsDetails, clients, resultDetails, chaosDetails); err != nil {
 		return err
 	}
 
@@ -108,8 +109,25 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	return nil
 }
 
-// drainNode drain the application node
-func drainNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+// PlanNodeDrain derives the target node and the pods to be evicted by the node-drain chaos, without draining the node
+func PlanNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, []apiv1.Pod, error) {
+
+	var err error
+	if experimentsDetails.TargetNode == "" {
+		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	pods, err := getPodsToEvict(experimentsDetails, clients)
+	if err != nil {
+		return "", nil, err
+	}
+	return experimentsDetails.TargetNode, pods, nil
+}
+
+// drainNode cordon the application node and evicts its pods, honoring the pod disruption budgets
+func drainNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
 
 	select {
 	case <-inject:
@@ -118,13 +136,27 @@ func drainNode(experimentsDetails *experimentTypes.ExperimentDetails, clients cl
 	default:
 		log.Infof("[Inject]: Draining the %v node", experimentsDetails.TargetNode)
 
-		command := exec.Command("kubectl", "drain", experimentsDetails.TargetNode, "--ignore-daemonsets", "--delete-local-data", "--force", "--timeout", strconv.Itoa(experimentsDetails.ChaosDuration)+"s")
-		var out, stderr bytes.Buffer
-		command.Stdout = &out
-		command.Stderr = &stderr
-		if err := command.Run(); err != nil {
-			log.Infof("Error String: %v", stderr.String())
-			return errors.Errorf("Unable to drain the %v node, err: %v", experimentsDetails.TargetNode, err)
+		// cordon the node, so that the evicted pods are not rescheduled on it
+		if err := setUnschedulable(experimentsDetails.TargetNode, true, clients); err != nil {
+			return errors.Errorf("unable to cordon the %v node, err: %v", experimentsDetails.TargetNode, err)
+		}
+
+		pods, err := getPodsToEvict(experimentsDetails, clients)
+		if err != nil {
+			return err
+		}
+		log.Infof("[Drain]: Evicting %v pods of the %v node", len(pods), experimentsDetails.TargetNode)
+
+		blocked, err := evictPods(pods, experimentsDetails, clients)
+		if len(blocked) != 0 {
+			// the blocked evictions are the outcome of the pod disruption budgets, which are recorded instead of failing the drain
+			log.Warnf("[Drain]: %v evictions are blocked by the pod disruption budgets", len(blocked))
+			if err := recordBlockedEvictions(blocked, resultDetails, chaosDetails, clients); err != nil {
+				log.Errorf("Unable to record the blocked evictions inside the chaosresult, err: %v", err)
+			}
+		}
+		if err != nil {
+			return err
 		}
 
 		common.SetTargets(experimentsDetails.TargetNode, "injected", "node", chaosDetails)
@@ -146,17 +178,12 @@ func drainNode(experimentsDetails *experimentTypes.ExperimentDetails, clients cl
 	return nil
 }
 
-// uncordonNode uncordon the application node
+// uncordonNode uncordon the application node, using the api
 func uncordonNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	log.Infof("[Recover]: Uncordon the %v node", experimentsDetails.TargetNode)
 
-	command := exec.Command("kubectl", "uncordon", experimentsDetails.TargetNode)
-	var out, stderr bytes.Buffer
-	command.Stdout = &out
-	command.Stderr = &stderr
-	if err := command.Run(); err != nil {
-		log.Infof("Error String: %v", stderr.String())
+	if err := setUnschedulable(experimentsDetails.TargetNode, false, clients); err != nil {
 		return errors.Errorf("unable to uncordon the %v node, err: %v", experimentsDetails.TargetNode, err)
 	}
 
@@ -64,13 +64,35 @@ func PrepareNodeIOStress(experimentsDetails *experimentTypes.ExperimentDetails,
 	return nil
 }
 
+// PlanNodeIOStress derives the target nodes and the helper pods of the node-io-stress chaos, without creating the helper pods
+func PlanNodeIOStress(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, []apiv1.Pod, error) {
+
+	targetNodeList, err := common.GetNodeList(experimentsDetails.TargetNodes, experimentsDetails.NodeLabel, experimentsDetails.NodesAffectedPerc, clients)
+	if err != nil {
+		return nil, nil, err
+	}
+	if experimentsDetails.EngineName != "" {
+		if err := common.SetHelperData(chaosDetails, clients); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	labelSuffix := common.GetRunID()
+	helperPods := []apiv1.Pod{}
+	for _, appNode := range targetNodeList {
+		experimentsDetails.RunID = common.GetRunID()
+		helperPods = append(helperPods, *getHelperPod(experimentsDetails, chaosDetails, appNode, labelSuffix))
+	}
+	return targetNodeList, helperPods, nil
+}
+
 // injectChaosInSerialMode stress the io of all the target nodes serially (one by one)
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, targetNodeList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -130,7 +152,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -192,6 +214,13 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 
 // createHelperPod derive the attributes for helper pod and create the helper pod
 func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appNode string, clients clients.ClientSets, labelSuffix string) error {
+	helperPod := getHelperPod(experimentsDetails, chaosDetails, appNode, labelSuffix)
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appNode string, labelSuffix string) *apiv1.Pod {
 
 	terminationGracePeriodSeconds := int64(experimentsDetails.TerminationGracePeriodSeconds)
 
@@ -221,9 +250,7 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chao
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
+	return helperPod
 }
 
 // getContainerArguments derives the args for the pumba stress helper pod
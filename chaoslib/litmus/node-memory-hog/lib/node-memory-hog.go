@@ -64,13 +64,47 @@ func PrepareNodeMemoryHog(experimentsDetails *experimentTypes.ExperimentDetails,
 	return nil
 }
 
+// PlanNodeMemoryHog derives the target nodes and the helper pods of the node-memory-hog chaos, without creating the helper pods
+func PlanNodeMemoryHog(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, []apiv1.Pod, error) {
+
+	targetNodeList, err := common.GetNodeList(experimentsDetails.TargetNodes, experimentsDetails.NodeLabel, experimentsDetails.NodesAffectedPerc, clients)
+	if err != nil {
+		return nil, nil, err
+	}
+	if experimentsDetails.EngineName != "" {
+		if err := common.SetHelperData(chaosDetails, clients); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	labelSuffix := common.GetRunID()
+	helperPods := []apiv1.Pod{}
+	for _, appNode := range targetNodeList {
+		experimentsDetails.RunID = common.GetRunID()
+
+		//Getting node memory details
+		memoryCapacity, memoryAllocatable, err := getNodeMemoryDetails(appNode, clients)
+		if err != nil {
+			return nil, nil, errors.Errorf("unable to get the node memory details, err: %v", err)
+		}
+
+		//Getting the exact memory value to exhaust
+		MemoryConsumption, err := calculateMemoryConsumption(experimentsDetails, clients, memoryCapacity, memoryAllocatable)
+		if err != nil {
+			return nil, nil, errors.Errorf("memory calculation failed, err: %v", err)
+		}
+		helperPods = append(helperPods, *getHelperPod(experimentsDetails, chaosDetails, appNode, labelSuffix, MemoryConsumption))
+	}
+	return targetNodeList, helperPods, nil
+}
+
 // injectChaosInSerialMode stress the memory of all the target nodes serially (one by one)
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, targetNodeList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -146,7 +180,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	labelSuffix := common.GetRunID()
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -299,6 +333,13 @@ func calculateMemoryConsumption(experimentsDetails *experimentTypes.ExperimentDe
 
 // createHelperPod derive the attributes for helper pod and create the helper pod
 func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appNode string, clients clients.ClientSets, labelSuffix, MemoryConsumption string) error {
+	helperPod := getHelperPod(experimentsDetails, chaosDetails, appNode, labelSuffix, MemoryConsumption)
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, appNode string, labelSuffix, MemoryConsumption string) *apiv1.Pod {
 
 	terminationGracePeriodSeconds := int64(experimentsDetails.TerminationGracePeriodSeconds)
 
@@ -335,7 +376,5 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chao
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
+	return helperPod
 }
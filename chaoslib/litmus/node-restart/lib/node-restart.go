@@ -77,6 +77,9 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 		}
 	}
 
+	// notify the chaos injection, the recovery of the targets is measured from here
+	chaosDetails.MarkInjected()
+
 	// Creating the helper pod to perform node restart
 	if err = createHelperPod(experimentsDetails, chaosDetails, clients); err != nil {
 		return errors.Errorf("unable to create the helper pod, err: %v", err)
@@ -92,7 +95,7 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 	common.SetTargets(experimentsDetails.TargetNode, "targeted", "node", chaosDetails)
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return err
@@ -121,8 +124,40 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 	return nil
 }
 
+// PlanNodeRestart derives the target node and the helper pod of the node-restart chaos, without creating the helper pod
+func PlanNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (*apiv1.Pod, error) {
+
+	var err error
+	if experimentsDetails.TargetNode == "" {
+		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if experimentsDetails.TargetNodeIP == "" {
+		experimentsDetails.TargetNodeIP, err = getInternalIP(experimentsDetails.TargetNode, clients)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if experimentsDetails.EngineName != "" {
+		if err := common.SetHelperData(chaosDetails, clients); err != nil {
+			return nil, err
+		}
+	}
+
+	experimentsDetails.RunID = common.GetRunID()
+	return getHelperPod(experimentsDetails, chaosDetails), nil
+}
+
 // createHelperPod derive the attributes for helper pod and create the helper pod
 func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(getHelperPod(experimentsDetails, chaosDetails))
+	return err
+}
+
+// getHelperPod derive the attributes for helper pod
+func getHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chaosDetails *types.ChaosDetails) *apiv1.Pod {
 	// This method is attaching emptyDir along with secret volume, and copy data from secret
 	// to the emptyDir, because secret is mounted as readonly and with 777 perms and it can't be changed
 	// because of: https://github.com/kubernetes/kubernetes/issues/57923
@@ -197,9 +232,7 @@ func createHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, chao
 			},
 		},
 	}
-
-	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
-	return err
+	return helperPod
 }
 
 // getInternalIP gets the internal ip of the given node
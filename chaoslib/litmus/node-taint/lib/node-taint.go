@@ -59,7 +59,7 @@ func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	}
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -106,6 +106,21 @@ func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	return nil
 }
 
+// PlanNodeTaint derives the target node and the taint of the node-taint chaos, without tainting the node
+func PlanNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
+
+	var err error
+	if experimentsDetails.TargetNode == "" {
+		experimentsDetails.TargetNode, err = common.GetNodeName(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.NodeLabel, clients)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	taintKey, taintValue, taintEffect := getTaintDetails(experimentsDetails)
+	return taintKey + "=" + taintValue + ":" + taintEffect, nil
+}
+
 // taintNode taint the application node
 func taintNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
@@ -257,7 +257,7 @@ func deploymentStatusCheck(experimentsDetails *experimentTypes.ExperimentDetails
 	}
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -307,7 +307,7 @@ func statefulsetStatusCheck(experimentsDetails *experimentTypes.ExperimentDetail
 	}
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -26,11 +26,11 @@ var inject chan os.Signal
 // stressCPU Uses the REST API to exec into the target container of the target pod
 // The function will be constantly increasing the CPU utilisation until it reaches the maximum available or allowed number.
 // Using the TOTAL_CHAOS_DURATION we will need to specify for how long this experiment will last
-func stressCPU(experimentsDetails *experimentTypes.ExperimentDetails, podName string, clients clients.ClientSets, stressErr chan error) {
+func stressCPU(experimentsDetails *experimentTypes.ExperimentDetails, podName, namespace string, clients clients.ClientSets, stressErr chan error) {
 	// It will contains all the pod & container details required for exec command
 	execCommandDetails := litmusexec.PodDetails{}
 	command := []string{"/bin/sh", "-c", experimentsDetails.ChaosInjectCmd}
-	litmusexec.SetExecCommandAttributes(&execCommandDetails, podName, experimentsDetails.TargetContainer, experimentsDetails.AppNS)
+	litmusexec.SetExecCommandAttributes(&execCommandDetails, podName, experimentsDetails.TargetContainer, namespace)
 	_, err := litmusexec.Exec(&execCommandDetails, clients, command)
 	stressErr <- err
 }
@@ -38,30 +38,11 @@ func stressCPU(experimentsDetails *experimentTypes.ExperimentDetails, podName st
 //experimentCPU function orchestrates the experiment by calling the StressCPU function for every core, of every container, of every pod that is targeted
 func experimentCPU(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
-	// Get the target pod details for the chaos execution
-	// if the target pod is not defined it will derive the random target pod list using pod affected percentage
-	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" {
-		return errors.Errorf("please provide one of the appLabel or TARGET_PODS")
-	}
-	targetPodList, err := common.GetPodList(experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
+	targetPodList, err := getTargetPods(experimentsDetails, clients, chaosDetails)
 	if err != nil {
 		return err
 	}
 
-	podNames := []string{}
-	for _, pod := range targetPodList.Items {
-		podNames = append(podNames, pod.Name)
-	}
-	log.Infof("Target pods list for chaos, %v", podNames)
-
-	//Get the target container name of the application pod
-	if experimentsDetails.TargetContainer == "" {
-		experimentsDetails.TargetContainer, err = common.GetTargetContainer(experimentsDetails.AppNS, targetPodList.Items[0].Name, clients)
-		if err != nil {
-			return errors.Errorf("unable to get the target container name, err: %v", err)
-		}
-	}
-
 	switch strings.ToLower(experimentsDetails.Sequence) {
 	case "serial":
 		if err = injectChaosInSerialMode(experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
@@ -78,11 +59,44 @@ func experimentCPU(experimentsDetails *experimentTypes.ExperimentDetails, client
 	return nil
 }
 
+// PlanCPUExecStress derives the target pods of the pod-cpu-hog-exec chaos, without injecting the chaos
+func PlanCPUExecStress(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (corev1.PodList, error) {
+	return getTargetPods(experimentsDetails, clients, chaosDetails)
+}
+
+// getTargetPods derive the target pods and the target container for the chaos execution
+// if the target pod is not defined it will derive the random target pod list using pod affected percentage
+func getTargetPods(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (corev1.PodList, error) {
+
+	if experimentsDetails.TargetPods == "" && chaosDetails.AppDetail.Label == "" && !chaosDetails.AppDetail.IsMultiTarget() {
+		return corev1.PodList{}, errors.Errorf("please provide one of the appLabel or TARGET_PODS")
+	}
+	targetPodList, err := common.GetPodList(experimentsDetails.TargetPods, experimentsDetails.PodsAffectedPerc, clients, chaosDetails)
+	if err != nil {
+		return corev1.PodList{}, err
+	}
+
+	podNames := []string{}
+	for _, pod := range targetPodList.Items {
+		podNames = append(podNames, pod.Name)
+	}
+	log.Infof("Target pods list for chaos, %v", podNames)
+
+	//Get the target container name of the application pod
+	if experimentsDetails.TargetContainer == "" {
+		experimentsDetails.TargetContainer, err = common.GetTargetContainer(targetPodList.Items[0].Namespace, targetPodList.Items[0].Name, clients)
+		if err != nil {
+			return corev1.PodList{}, errors.Errorf("unable to get the target container name, err: %v", err)
+		}
+	}
+	return targetPodList, nil
+}
+
 // injectChaosInSerialMode stressed the cpu of all target application serially (one by one)
 func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -120,7 +134,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 			})
 
 			for i := 0; i < experimentsDetails.CPUcores; i++ {
-				go stressCPU(experimentsDetails, pod.Name, clients, stressErr)
+				go stressCPU(experimentsDetails, pod.Name, pod.Namespace, clients, stressErr)
 			}
 
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
@@ -144,7 +158,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 					}
 				case <-signChan:
 					log.Info("[Chaos]: Revert Started")
-					err := killStressCPUSerial(experimentsDetails, pod.Name, clients, chaosDetails)
+					err := killStressCPUSerial(experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails)
 					if err != nil {
 						log.Errorf("Error in Kill stress after abortion, err: %v", err)
 					}
@@ -160,7 +174,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 					break loop
 				}
 			}
-			if err := killStressCPUSerial(experimentsDetails, pod.Name, clients, chaosDetails); err != nil {
+			if err := killStressCPUSerial(experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
 				return err
 			}
 		}
@@ -174,7 +188,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	stressErr := make(chan error)
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -208,7 +222,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 				"CPU CORE":         experimentsDetails.CPUcores,
 			})
 			for i := 0; i < experimentsDetails.CPUcores; i++ {
-				go stressCPU(experimentsDetails, pod.Name, clients, stressErr)
+				go stressCPU(experimentsDetails, pod.Name, pod.Namespace, clients, stressErr)
 			}
 			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
 		}
@@ -278,13 +292,13 @@ func PrepareCPUExecStress(experimentsDetails *experimentTypes.ExperimentDetails,
 
 // killStressCPUSerial function to kill a stress process running inside target container
 //  Triggered by either timeout of chaos duration or termination of the experiment
-func killStressCPUSerial(experimentsDetails *experimentTypes.ExperimentDetails, podName string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func killStressCPUSerial(experimentsDetails *experimentTypes.ExperimentDetails, podName, namespace string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	// It will contains all the pod & container details required for exec command
 	execCommandDetails := litmusexec.PodDetails{}
 
 	command := []string{"/bin/sh", "-c", experimentsDetails.ChaosKillCmd}
 
-	litmusexec.SetExecCommandAttributes(&execCommandDetails, podName, experimentsDetails.TargetContainer, experimentsDetails.AppNS)
+	litmusexec.SetExecCommandAttributes(&execCommandDetails, podName, experimentsDetails.TargetContainer, namespace)
 	_, err := litmusexec.Exec(&execCommandDetails, clients, command)
 	if err != nil {
 		return errors.Errorf("Unable to kill the stress process in %v pod, err: %v", podName, err)
@@ -299,7 +313,7 @@ func killStressCPUParallel(experimentsDetails *experimentTypes.ExperimentDetails
 
 	for _, pod := range targetPodList.Items {
 
-		if err := killStressCPUSerial(experimentsDetails, pod.Name, clients, chaosDetails); err != nil {
+		if err := killStressCPUSerial(experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"testing"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-dns-chaos/types"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
+)
+
+func TestGetDNSCommand(t *testing.T) {
+	runtime := containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: 4242, Running: true})
+	pid, err := runtime.PID("abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	experimentsDetails := &experimentTypes.ExperimentDetails{
+		ChaosType:       "spoof",
+		SpoofMap:        `{"google.com":"litmuschaos.io"}`,
+		TargetHostNames: `["google.com"]`,
+		ChaosDuration:   60,
+		MatchScheme:     "exact",
+	}
+	want := `sudo TARGET_PID=4242 CHAOS_TYPE=spoof SPOOF_MAP='{"google.com":"litmuschaos.io"}' TARGET_HOSTNAMES='["google.com"]' CHAOS_DURATION=60 MATCH_SCHEME=exact nsutil -p -n -t 4242 -- dns_interceptor`
+	if got := getDNSCommand(experimentsDetails, pid); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
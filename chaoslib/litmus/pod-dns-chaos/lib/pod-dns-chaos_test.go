@@ -0,0 +1,48 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-dns-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPlanDNSChaos(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "nginx-1", Namespace: "default"},
+		Spec:       apiv1.PodSpec{NodeName: "node-1", Containers: []apiv1.Container{{Name: "nginx"}}},
+	}
+	kubeClient := fake.NewSimpleClientset(pod)
+	experimentsDetails := &experimentTypes.ExperimentDetails{
+		ExperimentName:      "pod-dns-error",
+		ChaosNamespace:      "litmus",
+		ChaosServiceAccount: "litmus-admin",
+		TargetPods:          "nginx-1",
+		ChaosType:           "error",
+	}
+	chaosDetails := &types.ChaosDetails{AppDetail: types.AppDetails{Namespace: "default"}}
+
+	targetPodList, helperPods, err := PlanDNSChaos(experimentsDetails, clients.ClientSets{KubeClient: kubeClient}, chaosDetails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targetPodList.Items) != 1 || targetPodList.Items[0].Name != "nginx-1" {
+		t.Fatalf("expected nginx-1 target pod, got %v", targetPodList.Items)
+	}
+	if len(helperPods) != 1 || helperPods[0].Spec.NodeName != "node-1" || helperPods[0].Namespace != "litmus" {
+		t.Fatalf("expected the helper pod on node-1 in litmus namespace, got %+v", helperPods)
+	}
+
+	// the helper pods are only planned, not created
+	podList, err := kubeClient.CoreV1().Pods("litmus").List(v1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(podList.Items) != 0 {
+		t.Fatalf("expected no helper pod to be created, got %v", podList.Items)
+	}
+}
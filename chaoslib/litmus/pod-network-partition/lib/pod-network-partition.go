@@ -12,6 +12,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/apptargets"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
@@ -39,21 +40,15 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
 
 	// validate the appLabels
-	if chaosDetails.AppDetail.Label == "" {
+	if chaosDetails.AppDetail.Label == "" && !chaosDetails.AppDetail.IsMultiTarget() {
 		return errors.Errorf("please provide the appLabel")
 	}
-	// Get the target pod details for the chaos execution
-	targetPodList, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.AppNS).List(v1.ListOptions{LabelSelector: experimentsDetails.AppLabel})
+	// Get the target pods and the network policy of each application target
+	partitions, err := getPartitions(experimentsDetails, clients, chaosDetails)
 	if err != nil {
 		return err
 	}
 
-	podNames := []string{}
-	for _, pod := range targetPodList.Items {
-		podNames = append(podNames, pod.Name)
-	}
-	log.Infof("Target pods list for chaos, %v", podNames)
-
 	// generate a unique string
 	runID := common.GetRunID()
 
@@ -63,27 +58,24 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 		common.WaitForDuration(experimentsDetails.RampTime)
 	}
 
-	// collect all the data for the network policy
-	np := initialize()
-	if err := np.getNetworkPolicyDetails(experimentsDetails); err != nil {
-		return err
-	}
-
 	//DISPLAY THE NETWORK POLICY DETAILS
-	log.InfoWithValues("The Network policy details are as follows", logrus.Fields{
-		"Target Label":      np.TargetPodLabels,
-		"Policy Type":       np.PolicyType,
-		"PodSelector":       np.PodSelector,
-		"NamespaceSelector": np.NamespaceSelector,
-		"Destination IPs":   np.ExceptIPs,
-		"Ports":             np.Ports,
-	})
+	for _, partition := range partitions {
+		log.InfoWithValues("The Network policy details are as follows", logrus.Fields{
+			"Target Namespace":  partition.experimentsDetails.AppNS,
+			"Target Label":      partition.policy.TargetPodLabels,
+			"Policy Type":       partition.policy.PolicyType,
+			"PodSelector":       partition.policy.PodSelector,
+			"NamespaceSelector": partition.policy.NamespaceSelector,
+			"Destination IPs":   partition.policy.ExceptIPs,
+			"Ports":             partition.policy.Ports,
+		})
+	}
 
 	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, clients, chaosDetails, resultDetails, targetPodList, runID)
+	go abortWatcher(partitions, clients, chaosDetails, resultDetails, runID)
 
 	// run the probes during chaos
-	if len(resultDetails.ProbeDetails) != 0 {
+	if resultDetails.HasProbes() {
 		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
@@ -95,31 +87,32 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 		os.Exit(0)
 	default:
 		// creating the network policy to block the traffic
-		if err := createNetworkPolicy(experimentsDetails, clients, np, runID); err != nil {
-			return err
-		}
-		// updating chaos status to injected for the target pods
-		for _, pod := range targetPodList.Items {
-			common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
+		for _, partition := range partitions {
+			if err := createNetworkPolicy(partition.experimentsDetails, clients, partition.policy, runID); err != nil {
+				return err
+			}
+			// updating chaos status to injected for the target pods
+			for _, pod := range partition.targetPodList.Items {
+				common.SetTargets(pod.Name, "injected", "pod", chaosDetails)
+			}
 		}
 	}
 
 	// verify the presence of network policy inside cluster
-	if err := checkExistanceOfPolicy(experimentsDetails, clients, experimentsDetails.Timeout, experimentsDetails.Delay, runID); err != nil {
-		return err
+	for _, partition := range partitions {
+		if err := checkExistanceOfPolicy(partition.experimentsDetails, clients, experimentsDetails.Timeout, experimentsDetails.Delay, runID); err != nil {
+			return err
+		}
 	}
 
 	log.Infof("[Wait]: Wait for %v chaos duration", experimentsDetails.ChaosDuration)
 	common.WaitForDuration(experimentsDetails.ChaosDuration)
 
 	// deleting the network policy after chaos duration over
-	if err := deleteNetworkPolicy(experimentsDetails, clients, targetPodList, chaosDetails, experimentsDetails.Timeout, experimentsDetails.Delay, runID); err != nil {
-		return err
-	}
-
-	// updating chaos status to reverted for the target pods
-	for _, pod := range targetPodList.Items {
-		common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
+	for _, partition := range partitions {
+		if err := deleteNetworkPolicy(partition.experimentsDetails, clients, &partition.targetPodList, chaosDetails, experimentsDetails.Timeout, experimentsDetails.Delay, runID); err != nil {
+			return err
+		}
 	}
 
 	//Waiting for the ramp time after chaos injection
@@ -131,9 +124,87 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 	return nil
 }
 
+// partition contains the target pods and the network policy of the application target
+type partition struct {
+	// experimentsDetails contains the namespace and label of the application target
+	experimentsDetails *experimentTypes.ExperimentDetails
+	policy             *NetworkPolicy
+	targetPodList      corev1.PodList
+}
+
+// getPartitions derives the target pods and the network policy of each application target
+// the network policy is namespaced, so the policy is created for each namespace and label tuple of the applications
+func getPartitions(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]partition, error) {
+
+	targets := []types.AppTarget{{Namespace: experimentsDetails.AppNS, Label: experimentsDetails.AppLabel}}
+	if chaosDetails.AppDetail.IsMultiTarget() {
+		var err error
+		if targets, err = apptargets.Resolve(clients, chaosDetails.AppDetail); err != nil {
+			return nil, err
+		}
+	}
+
+	partitions := []partition{}
+	for _, target := range targets {
+		// the network policy with the empty pod selector blocks the traffic of all the pods of the namespace
+		if target.Label == "" {
+			return nil, errors.Errorf("please provide the label of the application target in %v namespace", target.Namespace)
+		}
+		targetDetails := *experimentsDetails
+		targetDetails.AppNS, targetDetails.AppLabel = target.Namespace, target.Label
+
+		targetPodList, err := clients.KubeClient.CoreV1().Pods(target.Namespace).List(v1.ListOptions{LabelSelector: target.Label})
+		if err != nil {
+			return nil, err
+		}
+		podNames := []string{}
+		for _, pod := range targetPodList.Items {
+			podNames = append(podNames, pod.Name)
+		}
+		log.Infof("Target pods list for chaos in %v namespace, %v", target.Namespace, podNames)
+
+		// collect all the data for the network policy
+		np := initialize()
+		if err := np.getNetworkPolicyDetails(&targetDetails); err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, partition{experimentsDetails: &targetDetails, policy: np, targetPodList: *targetPodList})
+	}
+	return partitions, nil
+}
+
+// PlanNetworkPartition derives the target pods and the network policies of the pod-network-partition chaos, without creating the network policies
+func PlanNetworkPartition(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (corev1.PodList, []networkv1.NetworkPolicy, error) {
+
+	// validate the appLabels
+	if chaosDetails.AppDetail.Label == "" && !chaosDetails.AppDetail.IsMultiTarget() {
+		return corev1.PodList{}, nil, errors.Errorf("please provide the appLabel")
+	}
+	partitions, err := getPartitions(experimentsDetails, clients, chaosDetails)
+	if err != nil {
+		return corev1.PodList{}, nil, err
+	}
+
+	runID := common.GetRunID()
+	targetPodList := corev1.PodList{}
+	policies := []networkv1.NetworkPolicy{}
+	for _, partition := range partitions {
+		targetPodList.Items = append(targetPodList.Items, partition.targetPodList.Items...)
+		policies = append(policies, *getNetworkPolicy(partition.experimentsDetails, partition.policy, runID))
+	}
+	return targetPodList, policies, nil
+}
+
 // createNetworkPolicy creates the network policy in the application namespace
 // it blocks ingress/egress traffic for the targeted application for specific/all IPs
 func createNetworkPolicy(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, networkPolicy *NetworkPolicy, runID string) error {
+	np := getNetworkPolicy(experimentsDetails, networkPolicy, runID)
+	_, err := clients.KubeClient.NetworkingV1().NetworkPolicies(experimentsDetails.AppNS).Create(np)
+	return err
+}
+
+// getNetworkPolicy derive the attributes of the network policy
+func getNetworkPolicy(experimentsDetails *experimentTypes.ExperimentDetails, networkPolicy *NetworkPolicy, runID string) *networkv1.NetworkPolicy {
 
 	np := &networkv1.NetworkPolicy{
 		ObjectMeta: v1.ObjectMeta{
@@ -154,9 +225,7 @@ func createNetworkPolicy(experimentsDetails *experimentTypes.ExperimentDetails,
 			Ingress:     networkPolicy.Ingress,
 		},
 	}
-
-	_, err := clients.KubeClient.NetworkingV1().NetworkPolicies(experimentsDetails.AppNS).Create(np)
-	return err
+	return np
 }
 
 // deleteNetworkPolicy deletes the network policy and wait until the network policy deleted completely
@@ -205,23 +274,25 @@ func checkExistanceOfPolicy(experimentsDetails *experimentTypes.ExperimentDetail
 }
 
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, targetPodList *corev1.PodList, runID string) {
+func abortWatcher(partitions []partition, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, runID string) {
 	// waiting till the abort signal received
 	<-abort
 
 	log.Info("[Chaos]: Killing process started because of terminated signal received")
 	log.Info("Chaos Revert Started")
-	// retry thrice for the chaos revert
-	retry := 3
-	for retry > 0 {
-		if err := checkExistanceOfPolicy(experimentsDetails, clients, 2, 1, runID); err != nil {
-			log.Infof("no active network policy found, err: %v", err)
-			retry--
-			continue
-		}
+	for _, partition := range partitions {
+		// retry thrice for the chaos revert
+		retry := 3
+		for retry > 0 {
+			if err := checkExistanceOfPolicy(partition.experimentsDetails, clients, 2, 1, runID); err != nil {
+				log.Infof("no active network policy found, err: %v", err)
+				retry--
+				continue
+			}
 
-		if err := deleteNetworkPolicy(experimentsDetails, clients, targetPodList, chaosDetails, 2, 1, runID); err != nil {
-			log.Errorf("unable to delete network policy, err: %v", err)
+			if err := deleteNetworkPolicy(partition.experimentsDetails, clients, &partition.targetPodList, chaosDetails, 2, 1, runID); err != nil {
+				log.Errorf("unable to delete network policy, err: %v", err)
+			}
 		}
 	}
 	// updating the chaosresult after stopped
@@ -0,0 +1,47 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-network-partition/types"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPlanNetworkPartition(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "nginx-1", Namespace: "default", Labels: map[string]string{"app": "nginx"}},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	}
+	kubeClient := fake.NewSimpleClientset(pod)
+	experimentsDetails := &experimentTypes.ExperimentDetails{
+		ExperimentName: "pod-network-partition",
+		AppNS:          "default",
+		AppLabel:       "app=nginx",
+		PolicyTypes:    "all",
+	}
+	chaosDetails := &types.ChaosDetails{AppDetail: types.AppDetails{Namespace: "default", Label: "app=nginx"}}
+
+	targetPodList, policies, err := PlanNetworkPartition(experimentsDetails, clients.ClientSets{KubeClient: kubeClient}, chaosDetails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targetPodList.Items) != 1 || targetPodList.Items[0].Name != "nginx-1" {
+		t.Fatalf("expected nginx-1 target pod, got %v", targetPodList.Items)
+	}
+	if len(policies) != 1 || policies[0].Namespace != "default" || policies[0].Spec.PodSelector.MatchLabels["app"] != "nginx" || len(policies[0].Spec.PolicyTypes) != 2 {
+		t.Fatalf("expected the ingress and egress network policy for app=nginx pods, got %+v", policies)
+	}
+
+	// the network policies are only planned, not created
+	policyList, err := kubeClient.NetworkingV1().NetworkPolicies("default").List(v1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(policyList.Items) != 0 {
+		t.Fatalf("expected no network policy to be created, got %v", policyList.Items)
+	}
+}
@@ -3,7 +3,6 @@ package helper
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -23,6 +22,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	clientTypes "k8s.io/apimachinery/pkg/types"
@@ -69,7 +69,9 @@ func Helper(clients clients.ClientSets) {
 	getENV(&experimentsDetails)
 
 	// Intialise the chaos attributes
-	types.InitialiseChaosVariables(&chaosDetails)
+	if err := types.InitialiseChaosVariables(&chaosDetails); err != nil {
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
 
 	// Intialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -77,13 +79,19 @@ func Helper(clients clients.ClientSets) {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	if err := prepareStressChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
+	runtime, err := containerRuntime.New(experimentsDetails.ContainerRuntime, experimentsDetails.SocketPath)
+	if err != nil {
+		log.Fatalf("helper pod failed, err: %v", err)
+	}
+	defer runtime.Close()
+
+	if err := prepareStressChaos(&experimentsDetails, runtime, clients, &eventsDetails, &chaosDetails, &resultDetails); err != nil {
 		log.Fatalf("helper pod failed, err: %v", err)
 	}
 }
 
 //prepareStressChaos contains the chaos preparation and injection steps
-func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, runtime containerRuntime.ContainerRuntime, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	select {
 	case <-inject:
@@ -91,12 +99,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		os.Exit(1)
 	default:
 
-		containerID, err := common.GetContainerID(experimentsDetails.AppNS, experimentsDetails.TargetPods, experimentsDetails.TargetContainer, clients)
-		if err != nil {
-			return err
-		}
-		// extract out the pid of the target container
-		targetPID, err := getPID(experimentsDetails, containerID)
+		containerID, targetPID, err := common.GetContainerPID(experimentsDetails.AppNS, experimentsDetails.TargetPods, experimentsDetails.TargetContainer, runtime, clients)
 		if err != nil {
 			return err
 		}
@@ -126,8 +129,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		if len(stressorList) == 0 {
 			return errors.Errorf("fail to prepare stressor for %v experiment", experimentsDetails.ExperimentName)
 		}
-		stressors := strings.Join(stressorList, " ")
-		stressCommand := "pause nsutil -t " + strconv.Itoa(targetPID) + " -p -- " + stressors
+		stressCommand := getStressCommand(targetPID, stressorList)
 		log.Infof("[Info]: starting process: %v", stressCommand)
 
 		// launch the stress-ng process on the target container in paused mode
@@ -140,7 +142,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		}
 
 		// watching for the abort signal and revert the chaos if an abort signal is received
-		go abortWatcher(cmd.Process.Pid, resultDetails.Name, chaosDetails.ChaosNamespace, experimentsDetails.TargetPods)
+		go abortWatcher(cmd.Process.Pid, clients, resultDetails.Name, chaosDetails.ChaosNamespace, experimentsDetails.TargetPods)
 
 		// add the stress process to the cgroup of target container
 		if err = control.Add(cgroups.Process{Pid: cmd.Process.Pid}); err != nil {
@@ -160,7 +162,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 			return errors.Errorf("fail to remove pause and start the stress process: %v", err)
 		}
 
-		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", experimentsDetails.TargetPods); err != nil {
+		if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "injected", "pod", experimentsDetails.TargetPods, clients); err != nil {
 			return err
 		}
 
@@ -179,7 +181,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 			log.Infof("[Timeout] Stress output: %v", buf.String())
 			log.Info("[Cleanup]: Killing the stress process")
 			terminateProcess(cmd.Process.Pid)
-			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods); err != nil {
+			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods, clients); err != nil {
 				return err
 			}
 			return errors.Errorf("the stress process is timeout after %vs", experimentsDetails.ChaosDuration+30)
@@ -198,7 +200,7 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 			}
 			log.Info("[Info]: Chaos injection completed")
 			terminateProcess(cmd.Process.Pid)
-			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods); err != nil {
+			if err = result.AnnotateChaosResult(resultDetails.Name, chaosDetails.ChaosNamespace, "reverted", "pod", experimentsDetails.TargetPods, clients); err != nil {
 				return err
 			}
 		}
@@ -206,6 +208,11 @@ func prepareStressChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 	return nil
 }
 
+// getStressCommand returns the command which launch the stressors inside the pid namespace of the target process in paused mode
+func getStressCommand(pid int, stressors []string) string {
+	return "pause nsutil -t " + strconv.Itoa(pid) + " -p -- " + strings.Join(stressors, " ")
+}
+
 //terminateProcess will remove the stress process from the target container after chaos completion
 func terminateProcess(pid int) error {
 	process, err := os.FindProcess(pid)
@@ -285,51 +292,6 @@ func prepareStressor(experimentDetails *experimentTypes.ExperimentDetails) []str
 	return stressArgs
 }
 
-//getPID extract out the PID of the target container
-func getPID(experimentDetails *experimentTypes.ExperimentDetails, containerID string) (int, error) {
-	var PID int
-
-	switch experimentDetails.ContainerRuntime {
-	case "docker":
-		host := "unix://" + experimentDetails.SocketPath
-		// deriving pid from the inspect out of target container
-		out, err := exec.Command("sudo", "docker", "--host", host, "inspect", containerID).CombinedOutput()
-		if err != nil {
-			log.Error(fmt.Sprintf("[docker]: Failed to run docker inspect: %s", string(out)))
-			return 0, err
-		}
-
-		// parsing data from the json output of inspect command
-		PID, err = parsePIDFromJSON(out, experimentDetails.ContainerRuntime)
-		if err != nil {
-			log.Error(fmt.Sprintf("[docker]: Failed to parse json from docker inspect output: %s", string(out)))
-			return 0, err
-		}
-
-	case "containerd", "crio":
-		// deriving pid from the inspect out of target container
-		endpoint := "unix://" + experimentDetails.SocketPath
-		out, err := exec.Command("sudo", "crictl", "-i", endpoint, "-r", endpoint, "inspect", containerID).CombinedOutput()
-		if err != nil {
-			log.Error(fmt.Sprintf("[cri]: Failed to run crictl: %s", string(out)))
-			return 0, err
-		}
-
-		// parsing data from the json output of inspect command
-		PID, err = parsePIDFromJSON(out, experimentDetails.ContainerRuntime)
-		if err != nil {
-			log.Errorf(fmt.Sprintf("[cri]: Failed to parse json from crictl output: %s", string(out)))
-			return 0, err
-		}
-	default:
-		return 0, errors.Errorf("%v container runtime not suported", experimentDetails.ContainerRuntime)
-	}
-
-	log.Info(fmt.Sprintf("[Info]: Container ID=%s has process PID=%d", containerID, PID))
-
-	return PID, nil
-}
-
 //pidPath will get the pid path of the container
 func pidPath(pid int) cgroups.Path {
 	processPath := "/proc/" + strconv.Itoa(pid) + "/cgroup"
@@ -452,47 +414,6 @@ func findValidCgroup(path cgroups.Path, target string) (string, error) {
 	return "", errors.Errorf("never found valid cgroup for %s", target)
 }
 
-//parsePIDFromJSON extract the pid from the json output
-func parsePIDFromJSON(j []byte, runtime string) (int, error) {
-	var pid int
-	switch runtime {
-	case "docker":
-		// in docker, pid is present inside state.pid attribute of inspect output
-		var resp []common.DockerInspectResponse
-		if err := json.Unmarshal(j, &resp); err != nil {
-			return 0, err
-		}
-		pid = resp[0].State.PID
-	case "containerd":
-		var resp common.CrictlInspectResponse
-		if err := json.Unmarshal(j, &resp); err != nil {
-			return 0, err
-		}
-		pid = resp.Info.PID
-
-	case "crio":
-		var info common.InfoDetails
-		if err := json.Unmarshal(j, &info); err != nil {
-			return 0, err
-		}
-		pid = info.PID
-		if pid == 0 {
-			var resp common.CrictlInspectResponse
-			if err := json.Unmarshal(j, &resp); err != nil {
-				return 0, err
-			}
-			pid = resp.Info.PID
-		}
-	default:
-		return 0, errors.Errorf("[cri]: No supported container runtime, runtime: %v", runtime)
-	}
-	if pid == 0 {
-		return 0, errors.Errorf("[cri]: No running target container found, pid: %d", pid)
-	}
-
-	return pid, nil
-}
-
 //getENV fetches all the env variables from the runner pod
 func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 	experimentDetails.ExperimentName = types.Getenv("EXPERIMENT_NAME", "")
@@ -516,7 +437,7 @@ func getENV(experimentDetails *experimentTypes.ExperimentDetails) {
 }
 
 // abortWatcher continuously watch for the abort signals
-func abortWatcher(targetPID int, resultName, chaosNS, targetPodName string) {
+func abortWatcher(targetPID int, clients clients.ClientSets, resultName, chaosNS, targetPodName string) {
 
 	<-abort
 
@@ -531,7 +452,7 @@ func abortWatcher(targetPID int, resultName, chaosNS, targetPodName string) {
 		retry--
 		time.Sleep(1 * time.Second)
 	}
-	if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", targetPodName); err != nil {
+	if err = result.AnnotateChaosResult(resultName, chaosNS, "reverted", "pod", targetPodName, clients); err != nil {
 		log.Errorf("unable to annotate the chaosresult, err :%v", err)
 	}
 	log.Info("[Abort]: Chaos Revert Completed")
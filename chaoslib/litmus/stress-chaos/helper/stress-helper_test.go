@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"testing"
+
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/stress-chaos/types"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
+)
+
+func TestGetStressCommand(t *testing.T) {
+	runtime := containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: 4242, Running: true})
+	pid, err := runtime.PID("abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name               string
+		experimentsDetails experimentTypes.ExperimentDetails
+		want               string
+	}{
+		{
+			name:               "pod-cpu-hog",
+			experimentsDetails: experimentTypes.ExperimentDetails{ExperimentName: "pod-cpu-hog", ChaosDuration: 60, CPUcores: 2},
+			want:               "pause nsutil -t 4242 -p -- stress-ng --timeout 60s --cpu 2",
+		},
+		{
+			name:               "pod-memory-hog",
+			experimentsDetails: experimentTypes.ExperimentDetails{ExperimentName: "pod-memory-hog", ChaosDuration: 30, NumberOfWorkers: 1, MemoryConsumption: 500},
+			want:               "pause nsutil -t 4242 -p -- stress-ng --timeout 30s --vm 1 --vm-bytes 500M",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getStressCommand(pid, prepareStressor(&tt.experimentsDetails)); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
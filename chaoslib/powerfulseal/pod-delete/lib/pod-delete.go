@@ -45,6 +45,9 @@ func PreparePodDelete(experimentsDetails *experimentTypes.ExperimentDetails, cli
 		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	}
 
+	// notify the chaos injection, the recovery of the targets is measured from here
+	chaosDetails.MarkInjected()
+
 	// Creating configmap for powerfulseal deployment
 	err := CreateConfigMap(experimentsDetails, clients, runID)
 	if err != nil {
@@ -31,10 +31,16 @@ func AzureDiskLoss(clients clients.ClientSets) {
 
 	//Fetching all the ENV passed from the runner pod
 	log.Infof("[PreReq]: Getting the ENV for the %v experiment", os.Getenv("EXPERIMENT_NAME"))
-	experimentEnv.GetENV(&experimentsDetails)
+	envErr := experimentEnv.GetENV(&experimentsDetails)
 
 	// Initialize the chaos attributes
-	types.InitialiseChaosVariables(&chaosDetails)
+	chaosErr := types.InitialiseChaosVariables(&chaosDetails)
+
+	// record the invalid configuration as the pre-chaos failure, along with all the validation errors
+	if envErr != nil || chaosErr != nil {
+		result.RecordConfigFailure(&chaosDetails, &resultDetails, clients, &eventsDetails, envErr, chaosErr)
+		return
+	}
 
 	// Initialize Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -116,7 +122,7 @@ func AzureDiskLoss(clients clients.ClientSets) {
 		msg := "AUT: Running"
 
 		// run the probes in the pre-chaos check
-		if len(resultDetails.ProbeDetails) != 0 {
+		if resultDetails.HasProbes() {
 
 			if err := probe.RunProbes(&chaosDetails, clients, &resultDetails, "PreChaos", &eventsDetails); err != nil {
 				log.Errorf("Probe Failed, err: %v", err)
@@ -187,7 +193,7 @@ func AzureDiskLoss(clients clients.ClientSets) {
 		msg := "AUT: Running"
 
 		// run the probes in the post-chaos check
-		if len(resultDetails.ProbeDetails) != 0 {
+		if resultDetails.HasProbes() {
 			if err = probe.RunProbes(&chaosDetails, clients, &resultDetails, "PostChaos", &eventsDetails); err != nil {
 				log.Errorf("Probes Failed, err: %v", err)
 				failStep := "[post-chaos]: Failed while running probes, err: " + err.Error()
@@ -31,10 +31,16 @@ func CasssandraPodDelete(clients clients.ClientSets) {
 
 	//Fetching all the ENV passed from the runner pod
 	log.Infof("[PreReq]: Getting the ENV for the %v experiment", os.Getenv("EXPERIMENT_NAME"))
-	experimentEnv.GetENV(&experimentsDetails)
+	envErr := experimentEnv.GetENV(&experimentsDetails)
 
 	// Initialize the chaos attributes
-	types.InitialiseChaosVariables(&chaosDetails)
+	chaosErr := types.InitialiseChaosVariables(&chaosDetails)
+
+	// record the invalid configuration as the pre-chaos failure, along with all the validation errors
+	if envErr != nil || chaosErr != nil {
+		result.RecordConfigFailure(&chaosDetails, &resultDetails, clients, &eventsDetails, envErr, chaosErr)
+		return
+	}
 
 	// Initialize Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -103,7 +109,7 @@ func CasssandraPodDelete(clients clients.ClientSets) {
 		msg := common.GetStatusMessage(chaosDetails.DefaultAppHealthCheck, "AUT: Running", "")
 
 		// run the probes in the pre-chaos check
-		if len(resultDetails.ProbeDetails) != 0 {
+		if resultDetails.HasProbes() {
 
 			if err = probe.RunProbes(&chaosDetails, clients, &resultDetails, "PreChaos", &eventsDetails); err != nil {
 				log.Errorf("Probes Failed, err: %v", err)
@@ -181,7 +187,7 @@ func CasssandraPodDelete(clients clients.ClientSets) {
 		msg := common.GetStatusMessage(chaosDetails.DefaultAppHealthCheck, "AUT: Running", "")
 
 		// run the probes in the post-chaos check
-		if len(resultDetails.ProbeDetails) != 0 {
+		if resultDetails.HasProbes() {
 			if err = probe.RunProbes(&chaosDetails, clients, &resultDetails, "PostChaos", &eventsDetails); err != nil {
 				log.Errorf("Probes Failed, err: %v", err)
 				failStep := "[post-chaos]: Failed while running probes, err: " + err.Error()
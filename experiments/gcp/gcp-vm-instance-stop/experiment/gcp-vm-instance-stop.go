@@ -1,245 +1,129 @@
 package experiment
 
 import (
-	"os"
-
-	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/gcp-vm-instance-stop/lib"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/cloud/gcp"
-	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/experiment"
 	experimentEnv "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-instance-stop/environment"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/gcp/gcp-vm-instance-stop/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/litmuschaos/litmus-go/pkg/probe"
-	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/status"
-	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-// VMInstanceStop executes the experiment steps by injecting chaos into the specified vm instances
-func VMInstanceStop(clients clients.ClientSets) {
-	var err error
-	var activeNodeCount int
-
-	experimentsDetails := experimentTypes.ExperimentDetails{}
-	resultDetails := types.ResultDetails{}
-	eventsDetails := types.EventDetails{}
-	chaosDetails := types.ChaosDetails{}
-
-	//Fetching all the ENV passed from the runner pod
-	experimentEnv.GetENV(&experimentsDetails)
-	log.Infof("[PreReq]: Procured the ENV for the %v experiment", os.Getenv("EXPERIMENT_NAME"))
+// vmInstanceStop contains the lifecycle hooks of the gcp-vm-instance-stop experiment
+type vmInstanceStop struct {
+	experimentsDetails experimentTypes.ExperimentDetails
+	// activeNodeCount is the number of active nodes before the chaos, it is used for the autoscaling group
+	activeNodeCount int
+}
 
-	// Initialize the chaos attributes
-	types.InitialiseChaosVariables(&chaosDetails)
+func init() {
+	experiment.Register("gcp-vm-instance-stop", func() experiment.Experiment { return &vmInstanceStop{} })
+}
 
-	// Initialize Chaos Result Parameters
-	types.SetResultAttributes(&resultDetails, chaosDetails)
+// VMInstanceStop executes the experiment steps by injecting chaos into the specified vm instances
+func VMInstanceStop(clients clients.ClientSets) {
+	experiment.Run(&vmInstanceStop{}, clients)
+}
 
-	if experimentsDetails.EngineName != "" {
-		// Initialize the probe details. Bail out upon error, as we haven't entered exp business logic yet
-		if err = probe.InitializeProbesInChaosResultDetails(&chaosDetails, clients, &resultDetails); err != nil {
-			log.Errorf("Unable to initialize the probes, err: %v", err)
-			return
-		}
+// Prepare fetches all the ENV passed from the runner pod
+func (vmInstanceStop *vmInstanceStop) Prepare(details *experiment.Details) error {
+	if err := experimentEnv.GetENV(&vmInstanceStop.experimentsDetails); err != nil {
+		return err
 	}
 
-	//Updating the chaos result in the beginning of experiment
-	log.Infof("[PreReq]: Updating the chaos result of %v experiment (SOT)", experimentsDetails.ExperimentName)
-	if err = result.ChaosResult(&chaosDetails, clients, &resultDetails, "SOT"); err != nil {
-		log.Errorf("Unable to Create the Chaos Result, err: %v", err)
-		failStep := "[pre-chaos]: Failed to update the chaos result of gcp vm instance stop experiment (SOT), err: " + err.Error()
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-		return
+	details.AppInfo = logrus.Fields{
+		"Chaos Duration":  vmInstanceStop.experimentsDetails.ChaosDuration,
+		"Chaos Namespace": vmInstanceStop.experimentsDetails.ChaosNamespace,
+		"Instance Names":  vmInstanceStop.experimentsDetails.VMInstanceName,
+		"Zones":           vmInstanceStop.experimentsDetails.InstanceZone,
 	}
+	// the chaoslib starts the instances and exits on abort
+	details.RevertOnAbort = true
+	return nil
+}
 
-	// Set the chaos result uid
-	result.SetResultUID(&resultDetails, clients, &chaosDetails)
-
-	// generating the event in chaosresult to marked the verdict as awaited
-	msg := "experiment: " + experimentsDetails.ExperimentName + ", Result: Awaited"
-	types.SetResultEventAttributes(&eventsDetails, types.AwaitedVerdict, msg, "Normal", &resultDetails)
-	events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosResult")
-
-	// Calling AbortWatcher go routine, it will continuously watch for the abort signal and generate the required events and result
-	go common.AbortWatcherWithoutExit(experimentsDetails.ExperimentName, clients, &resultDetails, &chaosDetails, &eventsDetails)
-
-	//DISPLAY THE INSTANCE INFORMATION
-	log.InfoWithValues("The instance information is as follows", logrus.Fields{
-		"Chaos Duration":  experimentsDetails.ChaosDuration,
-		"Chaos Namespace": experimentsDetails.ChaosNamespace,
-		"Instance Names":  experimentsDetails.VMInstanceName,
-		"Zones":           experimentsDetails.InstanceZone,
-	})
+// Validate verifies that the AUT (Application Under Test), the auxiliary applications and the vm instances are running
+// the active node count is verified as well after the chaos, if the instances are part of the autoscaling group
+func (vmInstanceStop *vmInstanceStop) Validate(details *experiment.Details, phase experiment.Phase) error {
+	experimentsDetails := vmInstanceStop.experimentsDetails
+	autoScalingGroup := experimentsDetails.AutoScalingGroup == "enable"
 
-	//PRE-CHAOS NODE STATUS CHECK
-	if experimentsDetails.AutoScalingGroup == "enable" {
-		activeNodeCount, err = common.PreChaosNodeStatusCheck(experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	if autoScalingGroup && phase == experiment.PreChaos {
+		activeNodeCount, err := common.PreChaosNodeStatusCheck(experimentsDetails.Timeout, experimentsDetails.Delay, details.Clients)
 		if err != nil {
-			log.Errorf("Pre chaos node status check failed, err: %v", err)
-			failStep := "[pre-chaos]: Failed to verify that the NUT (Node Under Test) is running, err: " + err.Error()
-			result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-			return
+			return errors.Errorf("nodes are not in the ready state, err: %v", err)
 		}
+		vmInstanceStop.activeNodeCount = activeNodeCount
 	}
 
-	//PRE-CHAOS APPLICATION STATUS CHECK
-	log.Info("[Status]: Verify that the AUT (Application Under Test) is running (pre-chaos)")
-	if err = status.AUTStatusCheck(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.TargetContainer, experimentsDetails.Timeout, experimentsDetails.Delay, clients, &chaosDetails); err != nil {
-		log.Errorf("Application status check failed, err: %v", err)
-		failStep := "[pre-chaos]: Failed to verify that the AUT (Application Under Test) is in running state, err: " + err.Error()
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-		return
+	if err := status.AUTStatusCheck(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.TargetContainer, experimentsDetails.Timeout, experimentsDetails.Delay, details.Clients, details.ChaosDetails); err != nil {
+		return err
 	}
 
-	//PRE-CHAOS AUXILIARY APPLICATION STATUS CHECK
 	if experimentsDetails.AuxiliaryAppInfo != "" {
-		log.Info("[Status]: Verify that the Auxiliary Applications are running (pre-chaos)")
-		if err = status.CheckAuxiliaryApplicationStatus(experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
-			log.Errorf("Auxiliary Application status check failed, err: %v", err)
-			failStep := "[pre-chaos]: Failed to verify that the Auxiliary Applications are in running state, err: " + err.Error()
-			result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-			return
+		log.Info("[Status]: Verify that the Auxiliary Applications are running")
+		if err := status.CheckAuxiliaryApplicationStatus(experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, details.Clients); err != nil {
+			return errors.Errorf("auxiliary applications are not running, err: %v", err)
 		}
 	}
 
-	if experimentsDetails.EngineName != "" {
-		// marking AUT as running, as we already checked the status of application under test
-		msg := "AUT: Running"
-
-		// run the probes in the pre-chaos check
-		if len(resultDetails.ProbeDetails) != 0 {
-
-			if err = probe.RunProbes(&chaosDetails, clients, &resultDetails, "PreChaos", &eventsDetails); err != nil {
-				log.Errorf("Probe Failed, err: %v", err)
-				failStep := "[pre-chaos]: Failed while running probes, err: " + err.Error()
-				msg := "AUT: Running, Probes: Unsuccessful"
-				types.SetEngineEventAttributes(&eventsDetails, types.PreChaosCheck, msg, "Warning", &chaosDetails)
-				events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosEngine")
-				result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-				return
+	state := "pre-chaos"
+	if phase == experiment.PostChaos {
+		state = "post-chaos"
+		if autoScalingGroup {
+			if err := common.PostChaosActiveNodeCountCheck(vmInstanceStop.activeNodeCount, experimentsDetails.Timeout, experimentsDetails.Delay, details.Clients); err != nil {
+				return errors.Errorf("active number of nodes is not restored, err: %v", err)
 			}
-			msg = "AUT: Running, Probes: Successful"
 		}
-		// generating the events for the pre-chaos check
-		types.SetEngineEventAttributes(&eventsDetails, types.PreChaosCheck, msg, "Normal", &chaosDetails)
-		events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosEngine")
 	}
 
-	//Verify that the GCP VM instance(s) is in RUNNING state (pre chaos)
-	if err = gcp.InstanceStatusCheckByName(experimentsDetails.AutoScalingGroup, experimentsDetails.Delay, experimentsDetails.Timeout, "pre-chaos", experimentsDetails.VMInstanceName, experimentsDetails.GCPProjectID, experimentsDetails.InstanceZone); err != nil {
-		log.Errorf("failed to get the vm instance status, err: %v", err)
-		failStep := "[pre-chaos]: Failed to verify the GCP VM instance status, err: " + err.Error()
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-		return
+	if err := gcp.InstanceStatusCheckByName(experimentsDetails.AutoScalingGroup, experimentsDetails.Delay, experimentsDetails.Timeout, state, experimentsDetails.VMInstanceName, experimentsDetails.GCPProjectID, experimentsDetails.InstanceZone); err != nil {
+		return errors.Errorf("vm instance is not in the running state, err: %v", err)
 	}
 	log.Info("[Status]: VM instance is in running state")
+	return nil
+}
 
-	// Including the litmus lib for GCP vm-instance-stop
+// Inject includes the litmus lib for gcp-vm-instance-stop
+func (vmInstanceStop *vmInstanceStop) Inject(details *experiment.Details) error {
+	experimentsDetails := &vmInstanceStop.experimentsDetails
 	switch experimentsDetails.ChaosLib {
 	case "litmus":
-		if err = litmusLIB.PrepareVMStop(&experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
-			log.Errorf("Chaos injection failed, err: %v", err)
-			failStep := "[chaos]: Failed inside the chaoslib, err: " + err.Error()
-			result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-			return
-		}
+		return litmusLIB.PrepareVMStop(experimentsDetails, details.Clients, details.ResultDetails, details.EventsDetails, details.ChaosDetails)
 	default:
 		log.Error("[Invalid]: Please Provide the correct LIB")
-		failStep := "[chaos]: no match was found for the specified lib"
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-		return
-	}
-
-	log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentsDetails.ExperimentName)
-	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
-
-	// POST-CHAOS ACTIVE NODE COUNT TEST
-	if experimentsDetails.AutoScalingGroup == "enable" {
-		if err = common.PostChaosActiveNodeCountCheck(activeNodeCount, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
-			log.Errorf("Post chaos active node count check failed, err: %v", err)
-			failStep := "[post-chaos]: Failed to verify active number of nodes post chaos, err: " + err.Error()
-			result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-			return
-		}
-	}
-
-	//Verify the GCP VM instance is in RUNNING status (post chaos)
-	if err = gcp.InstanceStatusCheckByName(experimentsDetails.AutoScalingGroup, experimentsDetails.Delay, experimentsDetails.Timeout, "post-chaos", experimentsDetails.VMInstanceName, experimentsDetails.GCPProjectID, experimentsDetails.InstanceZone); err != nil {
-		log.Errorf("failed to get the vm instance status, err: %v", err)
-		failStep := "[post-chaos]: Failed to verify the GCP VM instance status, err: " + err.Error()
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-		return
+		return errors.Errorf("no match was found for the specified lib")
 	}
-	log.Info("[Status]: VM instance is in running state (post chaos)")
-
-	//POST-CHAOS APPLICATION STATUS CHECK
-	log.Info("[Status]: Verify that the AUT (Application Under Test) is running (post-chaos)")
-	if err = status.AUTStatusCheck(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.TargetContainer, experimentsDetails.Timeout, experimentsDetails.Delay, clients, &chaosDetails); err != nil {
-		log.Errorf("Application status check failed, err: %v", err)
-		failStep := "[post-chaos]: Failed to verify that the AUT (Application Under Test) is running, err: " + err.Error()
-		result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-		return
-	}
-
-	//POST-CHAOS AUXILIARY APPLICATION STATUS CHECK
-	if experimentsDetails.AuxiliaryAppInfo != "" {
-		log.Info("[Status]: Verify that the Auxiliary Applications are running (post-chaos)")
-		if err = status.CheckAuxiliaryApplicationStatus(experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
-			log.Errorf("Auxiliary Application status check failed, err: %v", err)
-			failStep := "[post-chaos]: Failed to verify that the Auxiliary Applications are running, err: " + err.Error()
-			result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-			return
-		}
-	}
-
-	if experimentsDetails.EngineName != "" {
-		// marking AUT as running, as we already checked the status of application under test
-		msg := "AUT: Running"
+}
 
-		// run the probes in the post-chaos check
-		if len(resultDetails.ProbeDetails) != 0 {
-			if err = probe.RunProbes(&chaosDetails, clients, &resultDetails, "PostChaos", &eventsDetails); err != nil {
-				log.Errorf("Probes Failed, err: %v", err)
-				failStep := "[post-chaos]: Failed while running probes, err: " + err.Error()
-				msg := "AUT: Running, Probes: Unsuccessful"
-				types.SetEngineEventAttributes(&eventsDetails, types.PostChaosCheck, msg, "Warning", &chaosDetails)
-				events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosEngine")
-				result.RecordAfterFailure(&chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
-				return
-			}
-			msg = "AUT: Running, Probes: Successful"
-		}
+// Revert is a no-op, the chaoslib starts the instances itself
+func (vmInstanceStop *vmInstanceStop) Revert(details *experiment.Details) error {
+	return nil
+}
 
-		// generating post chaos event
-		types.SetEngineEventAttributes(&eventsDetails, types.PostChaosCheck, msg, "Normal", &chaosDetails)
-		events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosEngine")
+// Plan derives the target instances and the compute api calls of the gcp-vm-instance-stop chaos, without stopping the instances
+func (vmInstanceStop *vmInstanceStop) Plan(details *experiment.Details, plan *experiment.Plan) error {
+	experimentsDetails := &vmInstanceStop.experimentsDetails
+	if experimentsDetails.ChaosLib != "litmus" {
+		return errors.Errorf("dry-run is not supported for %v lib", experimentsDetails.ChaosLib)
 	}
-
-	//Updating the chaosResult in the end of experiment
-	log.Infof("[The End]: Updating the chaos result of %v experiment (EOT)", experimentsDetails.ExperimentName)
-	if err = result.ChaosResult(&chaosDetails, clients, &resultDetails, "EOT"); err != nil {
-		log.Errorf("Unable to Update the Chaos Result, err:  %v", err)
-		return
+	instanceNamesList, instanceZonesList, err := litmusLIB.PlanVMStop(experimentsDetails)
+	if err != nil {
+		return err
 	}
-
-	// generating the event in chaosresult to marked the verdict as pass/fail
-	msg = "experiment: " + experimentsDetails.ExperimentName + ", Result: " + string(resultDetails.Verdict)
-	reason := types.PassVerdict
-	eventType := "Normal"
-	if resultDetails.Verdict != "Pass" {
-		reason = types.FailVerdict
-		eventType = "Warning"
+	revertAction := "instances.start"
+	if experimentsDetails.AutoScalingGroup == "enable" {
+		revertAction = ""
+		plan.AddNote("the stopped instances are started by the autoscaling group, they are not started by the experiment")
 	}
-	types.SetResultEventAttributes(&eventsDetails, reason, msg, eventType, &resultDetails)
-	events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosResult")
-
-	if experimentsDetails.EngineName != "" {
-		msg := experimentsDetails.ExperimentName + " experiment has been " + string(resultDetails.Verdict) + "ed"
-		types.SetEngineEventAttributes(&eventsDetails, types.Summary, msg, "Normal", &chaosDetails)
-		events.GenerateEvents(&eventsDetails, clients, &chaosDetails, "ChaosEngine")
+	for i := range instanceNamesList {
+		parameters := map[string]string{"project": experimentsDetails.GCPProjectID, "zone": instanceZonesList[i], "sequence": experimentsDetails.Sequence}
+		plan.AddCloudTargets("gcp-vm-instance", "gcp", "instances.stop", revertAction, instanceNamesList[i:i+1], parameters)
 	}
+	return nil
 }
@@ -0,0 +1,84 @@
+package experiment
+
+import (
+	litmusLIB "github.com/litmuschaos/litmus-go/chaoslib/litmus/network-chaos/lib/rate-limit"
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/experiment"
+	experimentEnv "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/environment"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/network-chaos/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/status"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// podNetworkRateLimit contains the lifecycle hooks of the pod-network-rate-limit experiment
+type podNetworkRateLimit struct {
+	experimentsDetails experimentTypes.ExperimentDetails
+}
+
+func init() {
+	experiment.Register("pod-network-rate-limit", func() experiment.Experiment { return &podNetworkRateLimit{} })
+}
+
+// PodNetworkRateLimit inject the pod-network-rate-limit chaos
+func PodNetworkRateLimit(clients clients.ClientSets) {
+	experiment.Run(&podNetworkRateLimit{}, clients)
+}
+
+// Prepare fetches all the ENV passed from the runner pod
+func (podNetworkRateLimit *podNetworkRateLimit) Prepare(details *experiment.Details) error {
+	if err := experimentEnv.GetENV(&podNetworkRateLimit.experimentsDetails, "pod-network-rate-limit"); err != nil {
+		return err
+	}
+
+	details.AppInfo = logrus.Fields{
+		"Namespace":           podNetworkRateLimit.experimentsDetails.AppNS,
+		"Label":               podNetworkRateLimit.experimentsDetails.AppLabel,
+		"Bandwidth":           podNetworkRateLimit.experimentsDetails.NetworkBandwidth,
+		"Shaper":              podNetworkRateLimit.experimentsDetails.Shaper,
+		"Latency":             podNetworkRateLimit.experimentsDetails.NetworkLatency,
+		"Jitter":              podNetworkRateLimit.experimentsDetails.Jitter,
+		"Jitter Distribution": podNetworkRateLimit.experimentsDetails.JitterDistribution,
+		"Chaos Duration":      podNetworkRateLimit.experimentsDetails.ChaosDuration,
+		"Container Runtime":   podNetworkRateLimit.experimentsDetails.ContainerRuntime,
+	}
+	return nil
+}
+
+// Validate verifies that the AUT (Application Under Test) is running
+func (podNetworkRateLimit *podNetworkRateLimit) Validate(details *experiment.Details, phase experiment.Phase) error {
+	experimentsDetails := podNetworkRateLimit.experimentsDetails
+	return status.AUTStatusCheck(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.TargetContainer, experimentsDetails.Timeout, experimentsDetails.Delay, details.Clients, details.ChaosDetails)
+}
+
+// Inject includes the litmus lib for pod-network-rate-limit
+func (podNetworkRateLimit *podNetworkRateLimit) Inject(details *experiment.Details) error {
+	experimentsDetails := &podNetworkRateLimit.experimentsDetails
+	switch experimentsDetails.ChaosLib {
+	case "litmus":
+		return litmusLIB.PodNetworkRateLimitChaos(experimentsDetails, details.Clients, details.ResultDetails, details.EventsDetails, details.ChaosDetails)
+	default:
+		log.Error("[Invalid]: Please Provide the correct LIB")
+		return errors.Errorf("no match was found for the specified lib")
+	}
+}
+
+// Revert is a no-op, the helper pods revert the chaos themselves
+func (podNetworkRateLimit *podNetworkRateLimit) Revert(details *experiment.Details) error {
+	return nil
+}
+
+// Plan derives the target pods and the helper pods of the pod-network-rate-limit chaos, without creating them
+func (podNetworkRateLimit *podNetworkRateLimit) Plan(details *experiment.Details, plan *experiment.Plan) error {
+	experimentsDetails := &podNetworkRateLimit.experimentsDetails
+	if experimentsDetails.ChaosLib != "litmus" {
+		return errors.Errorf("dry-run is not supported for %v lib", experimentsDetails.ChaosLib)
+	}
+	targetPodList, helperPods, err := litmusLIB.PlanPodNetworkRateLimitChaos(experimentsDetails, details.Clients, details.ChaosDetails)
+	if err != nil {
+		return err
+	}
+	plan.AddPodTargets(targetPodList, helperPods, experimentsDetails.TargetPods)
+	return nil
+}
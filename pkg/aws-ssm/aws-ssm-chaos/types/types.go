@@ -6,37 +6,37 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName       string
-	EngineName           string
-	RampTime             int
-	AppNS                string
-	AppLabel             string
-	AppKind              string
-	AuxiliaryAppInfo     string
-	ChaosLib             string
-	ChaosDuration        int
-	ChaosInterval        int
-	ChaosUID             clientTypes.UID
-	InstanceID           string
-	ChaosNamespace       string
-	ChaosPodName         string
-	Timeout              int
-	Delay                int
-	EC2InstanceID        string
-	EC2InstanceTag       string
-	Region               string
-	InstanceAffectedPerc int
-	Sequence             string
+	ExperimentName       string          `env:"EXPERIMENT_NAME"`
+	EngineName           string          `env:"CHAOSENGINE"`
+	RampTime             int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	AppNS                string          `env:"APP_NAMESPACE"`
+	AppLabel             string          `env:"APP_LABEL"`
+	AppKind              string          `env:"APP_KIND"`
+	AuxiliaryAppInfo     string          `env:"AUXILIARY_APPINFO"`
+	ChaosLib             string          `env:"LIB" default:"litmus"`
+	ChaosDuration        int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	ChaosInterval        int             `env:"CHAOS_INTERVAL" default:"60" validate:"duration=s"`
+	ChaosUID             clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID           string          `env:"INSTANCE_ID"`
+	ChaosNamespace       string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName         string          `env:"POD_NAME"`
+	Timeout              int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	EC2InstanceID        string          `env:"EC2_INSTANCE_ID"`
+	EC2InstanceTag       string          `env:"EC2_INSTANCE_TAG"`
+	Region               string          `env:"REGION"`
+	InstanceAffectedPerc int             `env:"INSTANCE_AFFECTED_PERC" validate:"percentage"`
+	Sequence             string          `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
 	LIBImagePullPolicy   string
-	TargetContainer      string
-	Cpu                  int
-	NumberOfWorkers      int
-	MemoryPercentage     int
-	InstallDependencies  string
-	DocumentName         string
-	DocumentType         string
-	DocumentFormat       string
-	DocumentPath         string
+	TargetContainer      string `env:"TARGET_CONTAINER"`
+	Cpu                  int    `env:"CPU_CORE" default:"0"`
+	NumberOfWorkers      int    `env:"NUMBER_OF_WORKERS" default:"1"`
+	MemoryPercentage     int    `env:"MEMORY_PERCENTAGE" default:"80" validate:"percentage"`
+	InstallDependencies  string `env:"INSTALL_DEPENDENCIES" default:"True"`
+	DocumentName         string `env:"DOCUMENT_NAME" default:"LitmusChaos-AWS-SSM-Doc"`
+	DocumentType         string `env:"DOCUMENT_TYPE" default:"Command"`
+	DocumentFormat       string `env:"DOCUMENT_FORMAT" default:"YAML"`
+	DocumentPath         string `env:"DOCUMENT_PATH" default:"LitmusChaos-AWS-SSM-Docs.yml"`
 	IsDocsUploaded       bool
 	CommandIDs           []string
 	TargetInstanceIDList []string
@@ -6,27 +6,27 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	ChaosInterval      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	AuxiliaryAppInfo   string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	Timeout            int
-	Delay              int
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"azure-disk-loss"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s"`
+	ChaosInterval      int             `env:"CHAOS_INTERVAL" default:"30" validate:"duration=s"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
 	LIBImagePullPolicy string
-	TargetContainer    string
-	ScaleSet           string
-	ResourceGroup      string
-	SubscriptionID     string
-	VirtualDiskNames   string
-	Sequence           string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	ScaleSet           string `env:"SCALE_SET" default:"disable"`
+	ResourceGroup      string `env:"RESOURCE_GROUP"`
+	SubscriptionID     string `env:"SUBSCRIPTION_ID"`
+	VirtualDiskNames   string `env:"VIRTUAL_DISK_NAMES"`
+	Sequence           string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
 }
@@ -6,26 +6,26 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	RampTime           int
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	AuxiliaryAppInfo   string
-	ChaosLib           string
-	ChaosDuration      int
-	ChaosInterval      int
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	Timeout            int
-	Delay              int
-	AzureInstanceName  string
-	ResourceGroup      string
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"azure-instance-stop"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s"`
+	ChaosInterval      int             `env:"CHAOS_INTERVAL" default:"30" validate:"duration=s"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	AzureInstanceName  string          `env:"AZURE_INSTANCE_NAME"`
+	ResourceGroup      string          `env:"RESOURCE_GROUP"`
 	SubscriptionID     string
-	ScaleSet           string
+	ScaleSet           string `env:"SCALE_SET" default:"disable"`
 	LIBImagePullPolicy string
-	Sequence           string
+	Sequence           string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
 }
@@ -6,24 +6,24 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	TargetContainer    string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	AuxiliaryAppInfo   string
-	Timeout            int
-	Delay              int
+	ExperimentName     string          `env:"EXPERIMENT_NAME"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	TargetContainer    string          `env:"TARGET_CONTAINER"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
 	LIBImagePullPolicy string
-	IPMIIP             string
-	User               string
-	Password           string
+	IPMIIP             string `env:"IPMI_IP"`
+	User               string `env:"USER"`
+	Password           string `env:"PASSWORD"`
 }
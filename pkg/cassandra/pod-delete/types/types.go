@@ -7,12 +7,12 @@ import (
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
 	ChaoslibDetail         *exp.ExperimentDetails
-	CassandraServiceName   string
-	KeySpaceReplicaFactor  string
-	CassandraPort          int
-	LivenessServicePort    int
-	CassandraLivenessImage string
-	CassandraLivenessCheck string
-	RunID                  string
+	CassandraServiceName   string `env:"CASSANDRA_SVC_NAME"`
+	KeySpaceReplicaFactor  string `env:"KEYSPACE_REPLICATION_FACTOR"`
+	CassandraPort          int    `env:"CASSANDRA_PORT" default:"9042"`
+	LivenessServicePort    int    `env:"LIVENESS_SVC_PORT" default:"8088"`
+	CassandraLivenessImage string `env:"CASSANDRA_LIVENESS_IMAGE" default:"litmuschaos/cassandra-client:latest"`
+	CassandraLivenessCheck string `env:"CASSANDRA_LIVENESS_CHECK"`
+	RunID                  string `env:"RunID"`
 	Sequence               string
 }
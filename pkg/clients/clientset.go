@@ -13,7 +13,7 @@ import (
 
 // ClientSets is a collection of clientSets and kubeConfig needed
 type ClientSets struct {
-	KubeClient    *kubernetes.Clientset
+	KubeClient    kubernetes.Interface
 	LitmusClient  *chaosClient.LitmuschaosV1alpha1Client
 	KubeConfig    *rest.Config
 	DynamicClient dynamic.Interface
@@ -26,6 +26,23 @@ func (clientSets *ClientSets) GenerateClientSetFromKubeConfig() error {
 	if err != nil {
 		return err
 	}
+	return clientSets.generateClientSets(config)
+}
+
+// GenerateClientSetFromKubeConfigPath will generate the ClientSets from the given kubeconfig path
+// It uses the current context of the default kubeconfig (KUBECONFIG env or ~/.kube/config), if the path is not specified
+func (clientSets *ClientSets) GenerateClientSetFromKubeConfigPath(kubeconfig string) error {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return err
+	}
+	return clientSets.generateClientSets(config)
+}
+
+// generateClientSets will generate all the ClientSets from the given config
+func (clientSets *ClientSets) generateClientSets(config *rest.Config) error {
 	k8sClientSet, err := generateK8sClientSet(config)
 	if err != nil {
 		return err
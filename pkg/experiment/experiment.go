@@ -0,0 +1,78 @@
+package experiment
+
+import (
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+// Phase of the experiment, in which the application under test is validated
+type Phase string
+
+const (
+	// PreChaos phase validates the application before the chaos injection
+	PreChaos Phase = "PreChaos"
+	// PostChaos phase validates the application after the chaos injection
+	PostChaos Phase = "PostChaos"
+)
+
+// Experiment defines the lifecycle hooks of the chaos experiment
+// the runner owns the chaosresult, events, probes and abort handling around these hooks
+type Experiment interface {
+	// Prepare fetch and validate the inputs of the experiment
+	// it is called once the chaosresult is created, so that the failures are recorded inside the chaosresult
+	Prepare(details *Details) error
+	// Validate checks the health of the application under test in the pre-chaos and post-chaos phases
+	Validate(details *Details, phase Phase) error
+	// Inject injects the chaos, it contains the chaoslib selection
+	Inject(details *Details) error
+	// Revert reverts the chaos, it is called if the injection fails
+	Revert(details *Details) error
+}
+
+// NodeValidator is implemented by the experiments, which target the nodes
+// the target nodes are validated in every run, irrespective of the default app health check
+type NodeValidator interface {
+	// ValidateNodes checks the readiness of the target nodes in the pre-chaos and post-chaos phases
+	ValidateNodes(details *Details, phase Phase) error
+}
+
+// Details contains the attributes shared by the runner with the experiment hooks
+type Details struct {
+	Clients       clients.ClientSets
+	ChaosDetails  *types.ChaosDetails
+	ResultDetails *types.ResultDetails
+	EventsDetails *types.EventDetails
+	// AppInfo contains the details of the application under test, which are logged before the chaos
+	// it can be populated by the prepare hook
+	AppInfo logrus.Fields
+	// RevertOnAbort is set by the experiments, whose chaoslib reverts the chaos and exits on abort
+	// the abort watcher of the runner doesn't exit for these experiments
+	RevertOnAbort bool
+}
+
+// newDetails initialise the details of the experiment from the ENV
+// the details are returned along with the validation errors, so that the invalid configuration can be recorded
+func newDetails(clients clients.ClientSets) (*Details, error) {
+	details := &Details{
+		Clients:       clients,
+		ChaosDetails:  &types.ChaosDetails{},
+		ResultDetails: &types.ResultDetails{},
+		EventsDetails: &types.EventDetails{},
+	}
+
+	// Initialize the chaos attributes
+	if err := types.InitialiseChaosVariables(details.ChaosDetails); err != nil {
+		return details, err
+	}
+
+	// Initialize Chaos Result Parameters
+	types.SetResultAttributes(details.ResultDetails, *details.ChaosDetails)
+
+	details.AppInfo = logrus.Fields{
+		"Namespace":      details.ChaosDetails.AppDetail.Namespace,
+		"Label":          details.ChaosDetails.AppDetail.Label,
+		"Chaos Duration": details.ChaosDetails.ChaosDuration,
+	}
+	return details, nil
+}
@@ -0,0 +1,147 @@
+package experiment
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// PlanAnnotation is the chaosresult annotation, which contains the plan of the dry-run
+const PlanAnnotation = "litmuschaos.io/dry-run-plan"
+
+// Planner is implemented by the experiments, which supports the dry-run mode
+type Planner interface {
+	// Plan resolves the targets and derives the actions of the chaos, without injecting it
+	Plan(details *Details, plan *Plan) error
+}
+
+// Plan contains the actions of the experiment, which are derived in the dry-run mode
+type Plan struct {
+	Experiment string      `json:"experiment"`
+	Engine     string      `json:"engine,omitempty"`
+	Targets    []Target    `json:"targets"`
+	HelperPods []apiv1.Pod `json:"helperPods,omitempty"`
+	CloudCalls []CloudCall `json:"cloudCalls,omitempty"`
+	Notes      []string    `json:"notes,omitempty"`
+}
+
+// Target contains the details of the planned target
+type Target struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Node      string `json:"node,omitempty"`
+}
+
+// CloudCall contains the details of the planned cloud api call
+type CloudCall struct {
+	Provider   string            `json:"provider"`
+	Action     string            `json:"action"`
+	Resources  []string          `json:"resources"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AddTarget adds the target to the plan
+func (plan *Plan) AddTarget(kind, namespace, name, node string) {
+	plan.Targets = append(plan.Targets, Target{Kind: kind, Namespace: namespace, Name: name, Node: node})
+}
+
+// AddHelperPod adds the helper pod to the plan
+func (plan *Plan) AddHelperPod(pod apiv1.Pod) {
+	plan.HelperPods = append(plan.HelperPods, pod)
+}
+
+// AddPodTargets adds the target pods and the helper pods of the pod level chaos to the plan
+// the target pods are selected randomly at the injection, if the target pods are not provided
+func (plan *Plan) AddPodTargets(targetPodList apiv1.PodList, helperPods []apiv1.Pod, targetPods string) {
+	for _, pod := range targetPodList.Items {
+		plan.AddTarget("pod", pod.Namespace, pod.Name, pod.Spec.NodeName)
+	}
+	for _, pod := range helperPods {
+		plan.AddHelperPod(pod)
+	}
+	if targetPods == "" {
+		plan.AddNote("target pods are selected randomly, the listed pods are a sample of the selection")
+	}
+}
+
+// AddNodeTargets adds the target nodes and the helper pods of the node level chaos to the plan
+// the target nodes are selected randomly at the injection, if the target nodes are not provided
+func (plan *Plan) AddNodeTargets(targetNodeList []string, helperPods []apiv1.Pod, targetNodes string) {
+	for _, node := range targetNodeList {
+		plan.AddTarget("node", "", node, node)
+	}
+	for _, pod := range helperPods {
+		plan.AddHelperPod(pod)
+	}
+	if targetNodes == "" {
+		plan.AddNote("target nodes are selected randomly, the listed nodes are a sample of the selection")
+	}
+}
+
+// AddCloudCall adds the cloud api call to the plan
+func (plan *Plan) AddCloudCall(provider, action string, resources []string, parameters map[string]string) {
+	plan.CloudCalls = append(plan.CloudCalls, CloudCall{Provider: provider, Action: action, Resources: resources, Parameters: parameters})
+}
+
+// AddCloudTargets adds the cloud resources and the cloud api calls, which inject and revert the chaos on them, to the plan
+// the revert call is skipped if the revert action is empty, as the resources are restored by the provider itself
+func (plan *Plan) AddCloudTargets(kind, provider, injectAction, revertAction string, resources []string, parameters map[string]string) {
+	for _, resource := range resources {
+		plan.AddTarget(kind, "", resource, "")
+	}
+	plan.AddCloudCall(provider, injectAction, resources, parameters)
+	if revertAction != "" {
+		plan.AddCloudCall(provider, revertAction, resources, parameters)
+	}
+}
+
+// AddNote adds the note to the plan, it describes the behaviour which can't be derived upfront
+func (plan *Plan) AddNote(note string) {
+	plan.Notes = append(plan.Notes, note)
+}
+
+// plan derives the plan of the experiment and emits it as json to the stdout and the chaosresult annotation
+func (runner Runner) plan(experiment Experiment, details *Details) error {
+
+	experimentName := details.ChaosDetails.ExperimentName
+
+	planner, ok := experiment.(Planner)
+	if !ok {
+		err := errors.Errorf("dry-run is not supported by the %v experiment", experimentName)
+		log.Errorf("[DryRun]: %v", err)
+		runner.Steps.RecordAfterFailure(details, "[dry-run]: "+err.Error())
+		return err
+	}
+
+	log.Infof("[DryRun]: Deriving the plan of the %v experiment, the chaos will not be injected", experimentName)
+	plan := &Plan{
+		Experiment: experimentName,
+		Engine:     details.ChaosDetails.EngineName,
+		Targets:    []Target{},
+	}
+	if err := planner.Plan(details, plan); err != nil {
+		log.Errorf("Unable to derive the plan, err: %v", err)
+		failStep := "[dry-run]: Failed to derive the plan of the " + experimentName + " experiment, err: " + err.Error()
+		runner.Steps.RecordAfterFailure(details, failStep)
+		return err
+	}
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+
+	if err := runner.Steps.AnnotateResult(details, map[string]string{PlanAnnotation: string(data)}); err != nil {
+		log.Errorf("Unable to annotate the chaosresult with the plan, err: %v", err)
+		failStep := "[dry-run]: Failed to annotate the chaosresult with the plan, err: " + err.Error()
+		runner.Steps.RecordAfterFailure(details, failStep)
+		return err
+	}
+	log.Infof("[DryRun]: The plan of the %v experiment has been added to the %v chaosresult", experimentName, details.ResultDetails.Name)
+	return nil
+}
@@ -0,0 +1,86 @@
+package experiment
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/recovery"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
+)
+
+// RecoveryAnnotation is the chaosresult annotation, which contains the recovery summary of the target workloads
+const RecoveryAnnotation = "litmuschaos.io/mttr"
+
+// Disruptive is implemented by the experiments, which disrupt the target pods
+// the recovery of the owner workloads of the target pods is measured by the runner
+type Disruptive interface {
+	// RecoveryScope returns the namespace and label of the target pods
+	RecoveryScope(details *Details) (namespace, label string)
+}
+
+// startRecovery starts the recovery tracker of the disruptive experiment, before the chaos injection
+// it returns nil tracker, if the measurement is disabled or not supported by the experiment
+func (runner Runner) startRecovery(experiment Experiment, details *Details) (*recovery.Tracker, recovery.Options, error) {
+
+	var opts recovery.Options
+	disruptive, ok := experiment.(Disruptive)
+	if !ok {
+		return nil, opts, nil
+	}
+	sources, err := config.DefaultSources()
+	if err != nil {
+		return nil, opts, err
+	}
+	if err := config.Load(&opts, sources...); err != nil {
+		return nil, opts, err
+	}
+	if !opts.Enabled {
+		return nil, opts, nil
+	}
+
+	namespace, label := disruptive.RecoveryScope(details)
+	log.Infof("[Recovery]: Measuring the recovery of the workloads in %v namespace with %v label", namespace, label)
+	tracker, err := recovery.Start(details.Clients, namespace, label)
+	if err != nil {
+		// the measurement is best effort, it should not fail the experiment
+		log.Warnf("[Recovery]: Unable to measure the recovery, err: %v", err)
+		return nil, opts, nil
+	}
+	// the chaoslib marks the injection, the disruptions before it are not recorded
+	details.ChaosDetails.OnInject = tracker.MarkInjected
+	return tracker, opts, nil
+}
+
+// recordRecovery waits for the recovery of the disrupted workloads and records the summary inside the chaosresult
+// it returns the error, if any workload is not recovered within the MTTR_SLO
+func (runner Runner) recordRecovery(tracker *recovery.Tracker, opts recovery.Options, details *Details) error {
+
+	defer tracker.Stop()
+	tracker.Wait(time.Duration(details.ChaosDetails.Timeout) * time.Second)
+
+	summary := tracker.Summary()
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	log.InfoWithValues("[Recovery]: The recovery of the target workloads is as follows", map[string]interface{}{
+		"MTTR (sec)":         summary.MTTRSeconds,
+		"Max Recovery (sec)": summary.MaxRecoverySeconds,
+		"Targets":            len(summary.Targets),
+		"Unrecovered":        strings.Join(summary.Unrecovered, ","),
+	})
+	if err := runner.Steps.AnnotateResult(details, map[string]string{RecoveryAnnotation: string(data)}); err != nil {
+		log.Warnf("[Recovery]: Unable to annotate the chaosresult with the recovery summary, err: %v", err)
+	}
+
+	if opts.SLO <= 0 {
+		return nil
+	}
+	if violations := summary.Violations(opts.SLO); len(violations) != 0 {
+		return errors.Errorf("recovery of the target workloads exceeds the MTTR SLO, %v", strings.Join(violations, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package experiment
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	registryMu sync.Mutex
+	// registry contains the constructors of the experiments, which are built on the runner
+	registry = map[string]func() Experiment{}
+)
+
+// Register registers the constructor of the experiment with the given name
+// it is called from the init of the experiment packages, so that they can be run in the standalone mode
+func Register(name string, newExperiment func() Experiment) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = newExperiment
+}
+
+// lookup returns a new instance of the registered experiment
+func lookup(name string) (Experiment, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	newExperiment, ok := registry[name]
+	if !ok {
+		return nil, false
+	}
+	return newExperiment(), true
+}
+
+// Registered returns the names of all the registered experiments
+func Registered() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := []string{}
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SupportsDryRun checks whether the registered experiment implements the planner
+func SupportsDryRun(name string) bool {
+	experiment, ok := lookup(name)
+	if !ok {
+		return false
+	}
+	_, ok = experiment.(Planner)
+	return ok
+}
+
+// DryRunSupported returns the names of the registered experiments, which implement the planner
+func DryRunSupported() []string {
+	names := []string{}
+	for _, name := range Registered() {
+		if SupportsDryRun(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,260 @@
+package experiment
+
+import (
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
+)
+
+// Runner runs the lifecycle of the experiment
+type Runner struct {
+	// Steps contains the steps of the lifecycle, which interacts with the cluster
+	Steps Steps
+}
+
+// Run runs the lifecycle of the given experiment with the cluster steps
+func Run(experiment Experiment, clients clients.ClientSets) error {
+	runner := Runner{Steps: clusterSteps{}}
+	details, err := newDetails(clients)
+	if err != nil {
+		// the config of the experiment is loaded as well, to report all the validation errors together
+		err = config.Merge(err, experiment.Prepare(details))
+		runner.RecordConfigFailure(details, err)
+		return err
+	}
+	return runner.Run(experiment, details)
+}
+
+// RecordConfigFailure records the invalid configuration of the experiment as the pre-chaos failure
+// the chaosresult is created first, as the configuration is loaded before the start of the experiment
+func (runner Runner) RecordConfigFailure(details *Details, err error) {
+	log.Errorf("Unable to load the configuration, err: %v", err)
+
+	types.SetResultAttributes(details.ResultDetails, *details.ChaosDetails)
+	if err := runner.Steps.UpdateResult(details, "SOT"); err != nil {
+		log.Errorf("Unable to Create the Chaos Result, err: %v", err)
+		return
+	}
+	failStep := "[pre-chaos]: Invalid configuration of " + details.ChaosDetails.ExperimentName + " experiment, err: " + err.Error()
+	runner.Steps.RecordAfterFailure(details, failStep)
+}
+
+// Run runs the lifecycle of the given experiment:
+// SOT, prepare, pre-chaos validation & probes, inject, post-chaos validation & probes and EOT
+// in the dry-run mode, the plan of the chaos is derived instead of the injection and the post-chaos checks
+// it returns the error of the failed step, which is already recorded inside the chaosresult
+func (runner Runner) Run(experiment Experiment, details *Details) error {
+
+	chaosDetails := details.ChaosDetails
+	resultDetails := details.ResultDetails
+	eventsDetails := details.EventsDetails
+	experimentName := chaosDetails.ExperimentName
+
+	if chaosDetails.EngineName != "" || len(chaosDetails.Probes) != 0 {
+		// Initialize the probe details. Bail out upon error, as we haven't entered exp business logic yet
+		if err := runner.Steps.InitializeProbes(details); err != nil {
+			log.Errorf("Unable to initialize the probes, err: %v", err)
+			return err
+		}
+	}
+
+	//Updating the chaos result in the beginning of experiment
+	log.Infof("[PreReq]: Updating the chaos result of %v experiment (SOT)", experimentName)
+	if err := runner.Steps.UpdateResult(details, "SOT"); err != nil {
+		log.Errorf("Unable to Create the Chaos Result, err: %v", err)
+		failStep := "[pre-chaos]: Failed to update the chaos result of " + experimentName + " experiment (SOT), err: " + err.Error()
+		runner.Steps.RecordAfterFailure(details, failStep)
+		return err
+	}
+
+	// Set the chaos result uid
+	runner.Steps.SetResultUID(details)
+
+	// generating the event in chaosresult to marked the verdict as awaited
+	msg := "experiment: " + experimentName + ", Result: Awaited"
+	types.SetResultEventAttributes(eventsDetails, types.AwaitedVerdict, msg, "Normal", resultDetails)
+	runner.Steps.GenerateEvents(details, "ChaosResult")
+
+	//Fetching all the ENV passed from the runner pod
+	log.Infof("[PreReq]: Getting the ENV for the %v experiment", experimentName)
+	if err := experiment.Prepare(details); err != nil {
+		log.Errorf("Unable to prepare the experiment, err: %v", err)
+		failStep := "[pre-chaos]: Failed to prepare the " + experimentName + " experiment, err: " + err.Error()
+		runner.Steps.RecordAfterFailure(details, failStep)
+		return err
+	}
+
+	//DISPLAY THE APP INFORMATION
+	log.InfoWithValues("The application information is as follows", details.AppInfo)
+
+	// Calling AbortWatcher go routine, it will continuously watch for the abort signal and generate the required events and result
+	runner.Steps.WatchAbort(details)
+
+	//PRE-CHAOS APPLICATION STATUS CHECK AND PROBES
+	if err := runner.check(experiment, details, PreChaos); err != nil {
+		return err
+	}
+
+	if chaosDetails.DryRun {
+		// derive the plan of the chaos, instead of injecting it
+		if err := runner.plan(experiment, details); err != nil {
+			return err
+		}
+		resultDetails.Verdict = v1alpha1.ResultVerdictPassed
+	} else {
+		// start measuring the recovery of the target workloads, before the chaos injection
+		tracker, recoveryOpts, err := runner.startRecovery(experiment, details)
+		if err != nil {
+			log.Errorf("Unable to start the recovery measurement, err: %v", err)
+			failStep := "[pre-chaos]: Failed to start the recovery measurement, err: " + err.Error()
+			runner.Steps.RecordAfterFailure(details, failStep)
+			return err
+		}
+		if tracker != nil {
+			defer tracker.Stop()
+		}
+
+		// Including the chaoslib of the experiment
+		if err := experiment.Inject(details); err != nil {
+			log.Errorf("Chaos injection failed, err: %v", err)
+			if revertErr := experiment.Revert(details); revertErr != nil {
+				log.Errorf("Unable to revert the chaos, err: %v", revertErr)
+				err = errors.Errorf("%v, revert failed, err: %v", err, revertErr)
+			}
+			failStep := "[chaos]: Failed inside the chaoslib, err: " + err.Error()
+			runner.Steps.RecordAfterFailure(details, failStep)
+			return err
+		}
+
+		log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentName)
+		resultDetails.Verdict = v1alpha1.ResultVerdictPassed
+
+		//POST-CHAOS APPLICATION STATUS CHECK AND PROBES
+		if err := runner.check(experiment, details, PostChaos); err != nil {
+			return err
+		}
+
+		// record the recovery of the target workloads, the verdict is failed if the recovery exceeds the MTTR_SLO
+		if tracker != nil {
+			if err := runner.recordRecovery(tracker, recoveryOpts, details); err != nil {
+				log.Errorf("Recovery check failed, err: %v", err)
+				failStep := "[post-chaos]: " + err.Error()
+				runner.Steps.RecordAfterFailure(details, failStep)
+				return err
+			}
+		}
+	}
+
+	//Updating the chaosResult in the end of experiment
+	log.Infof("[The End]: Updating the chaos result of %v experiment (EOT)", experimentName)
+	if err := runner.Steps.UpdateResult(details, "EOT"); err != nil {
+		log.Errorf("Unable to Update the Chaos Result, err: %v", err)
+		return err
+	}
+
+	// generating the event in chaosresult to marked the verdict as pass/fail
+	msg = "experiment: " + experimentName + ", Result: " + string(resultDetails.Verdict)
+	reason := types.PassVerdict
+	eventType := "Normal"
+	if resultDetails.Verdict != "Pass" {
+		reason = types.FailVerdict
+		eventType = "Warning"
+	}
+	types.SetResultEventAttributes(eventsDetails, reason, msg, eventType, resultDetails)
+	runner.Steps.GenerateEvents(details, "ChaosResult")
+
+	if chaosDetails.EngineName != "" {
+		msg := experimentName + " experiment has been " + string(resultDetails.Verdict) + "ed"
+		types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Normal", chaosDetails)
+		runner.Steps.GenerateEvents(details, "ChaosEngine")
+	}
+	return nil
+}
+
+// check validates the application under test and runs the probes for the given phase
+// it records the failure inside the chaosresult, if any check fails
+func (runner Runner) check(experiment Experiment, details *Details, phase Phase) error {
+
+	chaosDetails := details.ChaosDetails
+	eventsDetails := details.EventsDetails
+
+	reason, failStepPrefix, state := types.PreChaosCheck, "[pre-chaos]", "pre-chaos"
+	if phase == PostChaos {
+		reason, failStepPrefix, state = types.PostChaosCheck, "[post-chaos]", "post-chaos"
+	}
+
+	if chaosDetails.DefaultAppHealthCheck {
+		log.Infof("[Status]: Verify that the AUT (Application Under Test) is running (%v)", state)
+		if err := experiment.Validate(details, phase); err != nil {
+			log.Errorf("Application status check failed, err: %v", err)
+			failStep := failStepPrefix + ": Failed to verify that the AUT (Application Under Test) is in running state, err: " + err.Error()
+			types.SetEngineEventAttributes(eventsDetails, reason, "AUT: Not Running", "Warning", chaosDetails)
+			runner.Steps.GenerateEvents(details, "ChaosEngine")
+			runner.Steps.RecordAfterFailure(details, failStep)
+			return err
+		}
+	}
+
+	if err := runner.checkNodes(experiment, details, phase); err != nil {
+		return err
+	}
+
+	// marking AUT as running, as we already checked the status of application under test
+	msg := common.GetStatusMessage(chaosDetails.DefaultAppHealthCheck, "AUT: Running", "")
+
+	// run the probes in the pre-chaos or post-chaos check
+	if details.ResultDetails.HasProbes() {
+		if err := runner.Steps.RunProbes(details, string(phase)); err != nil {
+			log.Errorf("Probes Failed, err: %v", err)
+			failStep := failStepPrefix + ": Failed while running probes, err: " + err.Error()
+			if chaosDetails.EngineName != "" {
+				msg = common.GetStatusMessage(chaosDetails.DefaultAppHealthCheck, "AUT: Running", "Unsuccessful")
+				types.SetEngineEventAttributes(eventsDetails, reason, msg, "Warning", chaosDetails)
+				runner.Steps.GenerateEvents(details, "ChaosEngine")
+			}
+			runner.Steps.RecordAfterFailure(details, failStep)
+			return err
+		}
+		msg = common.GetStatusMessage(chaosDetails.DefaultAppHealthCheck, "AUT: Running", "Successful")
+	}
+
+	// generating the events for the pre-chaos or post-chaos check
+	if chaosDetails.EngineName != "" {
+		types.SetEngineEventAttributes(eventsDetails, reason, msg, "Normal", chaosDetails)
+		runner.Steps.GenerateEvents(details, "ChaosEngine")
+	}
+	return nil
+}
+
+// checkNodes validates the target nodes of the experiment, which implements the NodeValidator
+// the nodes are required to be ready before the chaos, they may need to be recovered manually after the chaos
+func (runner Runner) checkNodes(experiment Experiment, details *Details, phase Phase) error {
+	nodeValidator, ok := experiment.(NodeValidator)
+	if !ok {
+		return nil
+	}
+
+	log.Info("[Status]: Getting the status of target nodes")
+	err := nodeValidator.ValidateNodes(details, phase)
+	if err == nil {
+		return nil
+	}
+
+	if phase == PostChaos {
+		log.Warnf("Target nodes are not in the ready state, you may need to manually recover the node, err: %v", err)
+		types.SetEngineEventAttributes(details.EventsDetails, types.PostChaosCheck, "NUT: Not Ready", "Warning", details.ChaosDetails)
+		runner.Steps.GenerateEvents(details, "ChaosEngine")
+		return nil
+	}
+
+	log.Errorf("Target nodes are not in the ready state, err: %v", err)
+	failStep := "[pre-chaos]: Failed to verify the status of nodes, err: " + err.Error()
+	types.SetEngineEventAttributes(details.EventsDetails, types.PreChaosCheck, "NUT: Not Ready", "Warning", details.ChaosDetails)
+	runner.Steps.GenerateEvents(details, "ChaosEngine")
+	runner.Steps.RecordAfterFailure(details, failStep)
+	return err
+}
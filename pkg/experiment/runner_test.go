@@ -0,0 +1,377 @@
+package experiment
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/recovery"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeSteps records the steps of the lifecycle, instead of updating the cluster
+type fakeSteps struct {
+	mu          sync.Mutex
+	states      []string
+	failSteps   []string
+	annotations map[string]string
+}
+
+func (steps *fakeSteps) InitializeProbes(details *Details) error { return nil }
+
+func (steps *fakeSteps) UpdateResult(details *Details, state string) error {
+	steps.mu.Lock()
+	defer steps.mu.Unlock()
+	steps.states = append(steps.states, state)
+	return nil
+}
+
+func (steps *fakeSteps) SetResultUID(details *Details) error { return nil }
+
+func (steps *fakeSteps) RecordAfterFailure(details *Details, failStep string) {
+	steps.mu.Lock()
+	defer steps.mu.Unlock()
+	steps.failSteps = append(steps.failSteps, failStep)
+}
+
+func (steps *fakeSteps) GenerateEvents(details *Details, kind string) error { return nil }
+
+func (steps *fakeSteps) RunProbes(details *Details, phase string) error { return nil }
+
+func (steps *fakeSteps) WatchAbort(details *Details) {}
+
+func (steps *fakeSteps) AnnotateResult(details *Details, annotations map[string]string) error {
+	steps.mu.Lock()
+	defer steps.mu.Unlock()
+	if steps.annotations == nil {
+		steps.annotations = map[string]string{}
+	}
+	for key, value := range annotations {
+		steps.annotations[key] = value
+	}
+	return nil
+}
+
+// fakeExperiment records the calls of the lifecycle hooks
+type fakeExperiment struct {
+	calls       []string
+	validateErr error
+	inject      func(details *Details) error
+}
+
+func (experiment *fakeExperiment) Prepare(details *Details) error {
+	experiment.calls = append(experiment.calls, "prepare")
+	return nil
+}
+
+func (experiment *fakeExperiment) Validate(details *Details, phase Phase) error {
+	experiment.calls = append(experiment.calls, "validate:"+string(phase))
+	return experiment.validateErr
+}
+
+func (experiment *fakeExperiment) Inject(details *Details) error {
+	experiment.calls = append(experiment.calls, "inject")
+	if experiment.inject != nil {
+		return experiment.inject(details)
+	}
+	return nil
+}
+
+func (experiment *fakeExperiment) Revert(details *Details) error {
+	experiment.calls = append(experiment.calls, "revert")
+	return nil
+}
+
+// plannedExperiment plans the pods of the application as the targets
+type plannedExperiment struct {
+	*fakeExperiment
+}
+
+func (experiment plannedExperiment) Plan(details *Details, plan *Plan) error {
+	podList, err := details.Clients.KubeClient.CoreV1().Pods("default").List(v1.ListOptions{LabelSelector: "app=nginx"})
+	if err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		plan.AddTarget("pod", pod.Namespace, pod.Name, pod.Spec.NodeName)
+	}
+	return nil
+}
+
+// disruptiveExperiment measures the recovery of the application pods
+type disruptiveExperiment struct {
+	*fakeExperiment
+}
+
+func (experiment disruptiveExperiment) RecoveryScope(details *Details) (string, string) {
+	return "default", "app=nginx"
+}
+
+// nodeExperiment validates the target nodes with the given error
+type nodeExperiment struct {
+	*fakeExperiment
+	nodeErr error
+}
+
+func (experiment nodeExperiment) ValidateNodes(details *Details, phase Phase) error {
+	experiment.calls = append(experiment.calls, "validate-nodes:"+string(phase))
+	return experiment.nodeErr
+}
+
+// appPod returns the ready pod of the nginx deployment
+func appPod(name string) *corev1.Pod {
+	controller := true
+	return &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			UID:             clientTypes.UID("uid-" + name),
+			Labels:          map[string]string{"app": "nginx", "pod-template-hash": "abc"},
+			OwnerReferences: []v1.OwnerReference{{Kind: "ReplicaSet", Name: "nginx-abc", Controller: &controller}},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+// newTestDetails returns the details of the experiment with the fake clientset
+func newTestDetails(objects ...*corev1.Pod) *Details {
+	kubeClient := fake.NewSimpleClientset()
+	for _, pod := range objects {
+		kubeClient.Tracker().Add(pod)
+	}
+	return &Details{
+		Clients: clients.ClientSets{KubeClient: kubeClient},
+		ChaosDetails: &types.ChaosDetails{
+			ExperimentName:        "fake-experiment",
+			DefaultAppHealthCheck: true,
+			Timeout:               10,
+			Delay:                 1,
+		},
+		ResultDetails: &types.ResultDetails{Name: "fake-experiment-result"},
+		EventsDetails: &types.EventDetails{},
+	}
+}
+
+func TestRunPassed(t *testing.T) {
+	steps := &fakeSteps{}
+	experiment := &fakeExperiment{}
+	details := newTestDetails()
+
+	if err := (Runner{Steps: steps}).Run(experiment, details); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "prepare,validate:PreChaos,inject,validate:PostChaos"
+	if got := strings.Join(experiment.calls, ","); got != want {
+		t.Fatalf("expected %v hooks, got %v", want, got)
+	}
+	if got := strings.Join(steps.states, ","); got != "SOT,EOT" {
+		t.Fatalf("expected SOT and EOT updates, got %v", got)
+	}
+	if details.ResultDetails.Verdict != "Pass" {
+		t.Fatalf("expected the passed verdict, got %v", details.ResultDetails.Verdict)
+	}
+}
+
+func TestRunRevertsOnInjectFailure(t *testing.T) {
+	steps := &fakeSteps{}
+	experiment := &fakeExperiment{inject: func(details *Details) error { return errors.Errorf("inject failed") }}
+
+	if err := (Runner{Steps: steps}).Run(experiment, newTestDetails()); err == nil {
+		t.Fatalf("expected the inject error")
+	}
+
+	want := "prepare,validate:PreChaos,inject,revert"
+	if got := strings.Join(experiment.calls, ","); got != want {
+		t.Fatalf("expected %v hooks, got %v", want, got)
+	}
+	if len(steps.failSteps) != 1 || !strings.HasPrefix(steps.failSteps[0], "[chaos]") {
+		t.Fatalf("expected the chaos failure to be recorded, got %v", steps.failSteps)
+	}
+	if got := strings.Join(steps.states, ","); got != "SOT" {
+		t.Fatalf("expected only the SOT update, got %v", got)
+	}
+}
+
+func TestRunSkipsInjectOnPreChaosFailure(t *testing.T) {
+	steps := &fakeSteps{}
+	experiment := &fakeExperiment{validateErr: errors.Errorf("application is not running")}
+
+	if err := (Runner{Steps: steps}).Run(experiment, newTestDetails()); err == nil {
+		t.Fatalf("expected the validation error")
+	}
+
+	if got := strings.Join(experiment.calls, ","); got != "prepare,validate:PreChaos" {
+		t.Fatalf("expected the injection to be skipped, got %v", got)
+	}
+	if len(steps.failSteps) != 1 || !strings.HasPrefix(steps.failSteps[0], "[pre-chaos]") {
+		t.Fatalf("expected the pre-chaos failure to be recorded, got %v", steps.failSteps)
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	steps := &fakeSteps{}
+	experiment := plannedExperiment{&fakeExperiment{}}
+	details := newTestDetails(appPod("nginx-1"), appPod("nginx-2"))
+	details.ChaosDetails.DryRun = true
+
+	if err := (Runner{Steps: steps}).Run(experiment, details); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, call := range experiment.calls {
+		if call == "inject" {
+			t.Fatalf("expected the chaos not to be injected in the dry-run mode")
+		}
+	}
+	plan := Plan{}
+	if err := json.Unmarshal([]byte(steps.annotations[PlanAnnotation]), &plan); err != nil {
+		t.Fatalf("unable to decode the plan annotation, err: %v", err)
+	}
+	if len(plan.Targets) != 2 {
+		t.Fatalf("expected 2 planned targets, got %v", plan.Targets)
+	}
+}
+
+func TestRunDryRunWithoutPlanner(t *testing.T) {
+	steps := &fakeSteps{}
+	experiment := &fakeExperiment{}
+	details := newTestDetails()
+	details.ChaosDetails.DryRun = true
+
+	if err := (Runner{Steps: steps}).Run(experiment, details); err == nil {
+		t.Fatalf("expected the dry-run to be refused")
+	}
+	if len(steps.failSteps) != 1 || !strings.HasPrefix(steps.failSteps[0], "[dry-run]") {
+		t.Fatalf("expected the dry-run failure to be recorded, got %v", steps.failSteps)
+	}
+}
+
+func TestDryRunSupported(t *testing.T) {
+	Register("test-planned", func() Experiment { return plannedExperiment{&fakeExperiment{}} })
+	Register("test-unplanned", func() Experiment { return &fakeExperiment{} })
+
+	supported := strings.Join(DryRunSupported(), ",")
+	if !strings.Contains(supported, "test-planned") || strings.Contains(supported, "test-unplanned") {
+		t.Fatalf("expected only the planned experiment to support the dry-run, got %v", supported)
+	}
+}
+
+func TestAddCloudTargets(t *testing.T) {
+	plan := &Plan{}
+	parameters := map[string]string{"region": "us-east-1"}
+	plan.AddCloudTargets("ec2-instance", "aws", "StopInstances", "StartInstances", []string{"i-1", "i-2"}, parameters)
+	plan.AddCloudTargets("ec2-instance", "aws", "StopInstances", "", []string{"i-3"}, parameters)
+
+	if len(plan.Targets) != 3 || plan.Targets[2].Name != "i-3" || plan.Targets[2].Kind != "ec2-instance" {
+		t.Fatalf("expected 3 ec2-instance targets, got %v", plan.Targets)
+	}
+	// the revert call is skipped for the resources, which are restored by the provider
+	if len(plan.CloudCalls) != 3 {
+		t.Fatalf("expected 3 cloud calls, got %v", plan.CloudCalls)
+	}
+	if plan.CloudCalls[1].Action != "StartInstances" || len(plan.CloudCalls[1].Resources) != 2 || plan.CloudCalls[2].Action != "StopInstances" {
+		t.Fatalf("expected the stop and start calls of the first targets and the stop call of the last target, got %v", plan.CloudCalls)
+	}
+}
+
+func TestRunRecordsRecovery(t *testing.T) {
+	steps := &fakeSteps{}
+	details := newTestDetails(appPod("nginx-1"))
+	experiment := disruptiveExperiment{&fakeExperiment{inject: func(details *Details) error {
+		details.ChaosDetails.MarkInjected()
+		pods := details.Clients.KubeClient.CoreV1().Pods("default")
+		if err := pods.Delete("nginx-1", &v1.DeleteOptions{}); err != nil {
+			return err
+		}
+		if _, err := pods.Create(appPod("nginx-2")); err != nil {
+			return err
+		}
+		// wait for the informer of the tracker to receive the events
+		time.Sleep(500 * time.Millisecond)
+		return nil
+	}}}
+
+	if err := (Runner{Steps: steps}).Run(experiment, details); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary := recovery.Summary{}
+	if err := json.Unmarshal([]byte(steps.annotations[RecoveryAnnotation]), &summary); err != nil {
+		t.Fatalf("unable to decode the recovery annotation, err: %v", err)
+	}
+	if summary.InjectedAt.IsZero() {
+		t.Fatalf("expected the injection time to be recorded")
+	}
+	if len(summary.Targets) != 1 || summary.Targets[0].Kind != "deployment" || len(summary.Targets[0].Recoveries) != 1 {
+		t.Fatalf("expected 1 recovery of the nginx deployment, got %+v", summary.Targets)
+	}
+	if len(summary.Unrecovered) != 0 {
+		t.Fatalf("expected no unrecovered target, got %v", summary.Unrecovered)
+	}
+}
+
+func TestRecordConfigFailure(t *testing.T) {
+	steps := &fakeSteps{}
+	details := newTestDetails()
+	details.ChaosDetails.ExperimentName = "pod-delete"
+
+	(Runner{Steps: steps}).RecordConfigFailure(details, errors.Errorf("invalid configuration: TOTAL_CHAOS_DURATION: \"60x\" is not a valid duration"))
+
+	if len(steps.states) != 1 || steps.states[0] != "SOT" {
+		t.Fatalf("expected the chaosresult to be created, got %v states", steps.states)
+	}
+	if len(steps.failSteps) != 1 || !strings.HasPrefix(steps.failSteps[0], "[pre-chaos]: Invalid configuration of pod-delete experiment") {
+		t.Fatalf("expected the pre-chaos failure to be recorded, got %v", steps.failSteps)
+	}
+	if !strings.Contains(steps.failSteps[0], "TOTAL_CHAOS_DURATION") {
+		t.Fatalf("expected the validation error inside the fail step, got %v", steps.failSteps[0])
+	}
+}
+
+func TestRunValidatesNodesWithoutHealthCheck(t *testing.T) {
+	tests := map[string]struct {
+		nodeErr   error
+		wantCalls string
+		wantErr   bool
+	}{
+		"ready nodes": {
+			wantCalls: "prepare,validate-nodes:PreChaos,inject,validate-nodes:PostChaos",
+		},
+		"not ready nodes": {
+			nodeErr:   errors.Errorf("node-1 is not ready"),
+			wantCalls: "prepare,validate-nodes:PreChaos",
+			wantErr:   true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			steps := &fakeSteps{}
+			experiment := nodeExperiment{fakeExperiment: &fakeExperiment{}, nodeErr: test.nodeErr}
+			details := newTestDetails()
+			details.ChaosDetails.DefaultAppHealthCheck = false
+
+			err := (Runner{Steps: steps}).Run(experiment, details)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error: %v, got %v", test.wantErr, err)
+			}
+			if got := strings.Join(experiment.calls, ","); got != test.wantCalls {
+				t.Fatalf("expected %v hooks, got %v", test.wantCalls, got)
+			}
+			if test.wantErr && (len(steps.failSteps) != 1 || !strings.HasPrefix(steps.failSteps[0], "[pre-chaos]: Failed to verify the status of nodes")) {
+				t.Fatalf("expected the node failure to be recorded, got %v", steps.failSteps)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package experiment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/abort"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// litmusGroupVersion is the group version of the litmus crds
+const litmusGroupVersion = "litmuschaos.io/v1alpha1"
+
+// Spec contains the definition of the experiment, which is run without the chaosengine
+type Spec struct {
+	// Name of the experiment
+	Name string `json:"name"`
+	// Namespace in which the chaos resources are created, it defaults to default namespace
+	Namespace string `json:"namespace,omitempty"`
+	// ServiceAccount used by the helper pods
+	ServiceAccount string  `json:"serviceAccount,omitempty"`
+	App            AppSpec `json:"app,omitempty"`
+	// Parameters contains the tunables of the experiment, which are passed as the env of the experiment
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// Probes contains the inline probes of the experiment
+	Probes []types.ProbeAttributes `json:"probes,omitempty"`
+}
+
+// AppSpec contains the details of the application under test
+type AppSpec struct {
+	Namespace string `json:"namespace,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+}
+
+// Report contains the outcome of the experiment, which is run without the chaosresult
+type Report struct {
+	Experiment             string                 `json:"experiment"`
+	Verdict                string                 `json:"verdict"`
+	Phase                  string                 `json:"phase"`
+	FailStep               string                 `json:"failStep,omitempty"`
+	ProbeSuccessPercentage string                 `json:"probeSuccessPercentage"`
+	Probes                 []v1alpha1.ProbeStatus `json:"probes,omitempty"`
+	Annotations            map[string]string      `json:"annotations,omitempty"`
+}
+
+// LoadSpec reads the experiment definition from the given yaml or json file
+// the experiment is required to be registered on the runner
+func LoadSpec(path string) (*Spec, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Errorf("unable to open the %v file, err: %v", path, err)
+	}
+	defer file.Close()
+
+	spec := &Spec{}
+	if err := yaml.NewYAMLOrJSONDecoder(file, 4096).Decode(spec); err != nil {
+		return nil, errors.Errorf("unable to decode the %v file, err: %v", path, err)
+	}
+	if spec.Name == "" {
+		return nil, errors.Errorf("name of the experiment is not provided in the %v file", path)
+	}
+	if _, ok := lookup(spec.Name); !ok {
+		return nil, errors.Errorf("%v experiment is not supported in the standalone mode, supported experiments: %v", spec.Name, Registered())
+	}
+	for key, value := range spec.Parameters {
+		switch value.(type) {
+		case string, bool, float64, int64, nil:
+		default:
+			return nil, errors.Errorf("%v parameter should be a string, number or boolean", key)
+		}
+	}
+	return spec, nil
+}
+
+// env returns the env of the experiment derived from the spec
+func (spec *Spec) env() map[string]string {
+	env := map[string]string{
+		"EXPERIMENT_NAME":       spec.Name,
+		"CHAOS_NAMESPACE":       "default",
+		"CHAOSENGINE":           "",
+		"APP_NAMESPACE":         spec.App.Namespace,
+		"APP_LABEL":             spec.App.Label,
+		"APP_KIND":              spec.App.Kind,
+		"CHAOS_SERVICE_ACCOUNT": spec.ServiceAccount,
+	}
+	if spec.Namespace != "" {
+		env["CHAOS_NAMESPACE"] = spec.Namespace
+	}
+	// the parameters take precedence over the derived env
+	for key, value := range spec.Parameters {
+		if value == nil {
+			continue
+		}
+		env[key] = fmt.Sprint(value)
+	}
+	return env
+}
+
+// RunStandalone runs the experiment defined inside the spec against the given cluster, without the chaosengine
+// the result is recorded in the chaosresult if the litmus crds are installed, otherwise the report is written to the out
+func RunStandalone(spec *Spec, clients clients.ClientSets, out io.Writer) error {
+
+	experiment, ok := lookup(spec.Name)
+	if !ok {
+		return errors.Errorf("%v experiment is not supported in the standalone mode, supported experiments: %v", spec.Name, Registered())
+	}
+
+	installed, err := isLitmusInstalled(clients)
+	if err != nil {
+		return err
+	}
+
+	env := spec.env()
+	if !installed {
+		log.Info("[Standalone]: Litmus crds are not installed, the result of the experiment is written to the output")
+		env["STANDALONE"] = "true"
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			return errors.Errorf("unable to set the %v env, err: %v", key, err)
+		}
+	}
+
+	details, configErr := newDetails(clients)
+	details.ChaosDetails.Probes = spec.Probes
+
+	if installed {
+		runner := Runner{Steps: clusterSteps{}}
+		if configErr != nil {
+			configErr = config.Merge(configErr, experiment.Prepare(details))
+			runner.RecordConfigFailure(details, configErr)
+			return configErr
+		}
+		if err := runner.Run(experiment, details); err != nil {
+			return err
+		}
+		log.Infof("[Standalone]: The result of the experiment is recorded in the %v chaosresult", details.ResultDetails.Name)
+		return verdictError(details)
+	}
+
+	steps := &standaloneSteps{out: out}
+	runner := Runner{Steps: steps}
+	var runErr error
+	if configErr != nil {
+		runErr = config.Merge(configErr, experiment.Prepare(details))
+		runner.RecordConfigFailure(details, runErr)
+	} else {
+		runErr = runner.Run(experiment, details)
+	}
+	if err := steps.writeReport(details); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return runErr
+	}
+	return verdictError(details)
+}
+
+// verdictError returns the error, if the experiment is not passed
+func verdictError(details *Details) error {
+	if details.ResultDetails.Verdict != v1alpha1.ResultVerdictPassed {
+		return errors.Errorf("%v experiment has been %ved", details.ChaosDetails.ExperimentName, details.ResultDetails.Verdict)
+	}
+	return nil
+}
+
+// isLitmusInstalled checks whether the chaosresult crd is available in the cluster
+func isLitmusInstalled(clients clients.ClientSets) (bool, error) {
+	resources, err := clients.KubeClient.Discovery().ServerResourcesForGroupVersion(litmusGroupVersion)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Errorf("unable to discover the %v resources, err: %v", litmusGroupVersion, err)
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "chaosresults" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// standaloneSteps contains the steps, which keeps the result in memory instead of the chaosresult
+type standaloneSteps struct {
+	out                    io.Writer
+	mu                     sync.Mutex
+	reported               bool
+	probeStatus            []v1alpha1.ProbeStatus
+	probeSuccessPercentage string
+	annotations            map[string]string
+}
+
+// InitializeProbes set the inline probes inside the chaosresult details
+func (steps *standaloneSteps) InitializeProbes(details *Details) error {
+	return probe.InitializeProbesInChaosResultDetails(details.ChaosDetails, details.Clients, details.ResultDetails)
+}
+
+// UpdateResult derives the final verdict and probe status in the end of experiment
+func (steps *standaloneSteps) UpdateResult(details *Details, state string) error {
+	switch state {
+	case "SOT":
+		log.SetPhase("PreChaos")
+		steps.probeSuccessPercentage = "Awaited"
+	case "EOT":
+		log.SetPhase(types.Summary)
+		metrics.SetInjectionEnd(details.ChaosDetails, false)
+		steps.mu.Lock()
+		steps.probeStatus, steps.probeSuccessPercentage, steps.annotations = result.CompleteResult(details.ResultDetails, steps.annotations)
+		steps.mu.Unlock()
+		metrics.SetVerdict(details.ChaosDetails, string(details.ResultDetails.Verdict))
+	}
+	return nil
+}
+
+// SetResultUID is a no-op, as there is no chaosresult
+func (steps *standaloneSteps) SetResultUID(details *Details) error {
+	return nil
+}
+
+// RecordAfterFailure records the failure inside the report
+func (steps *standaloneSteps) RecordAfterFailure(details *Details, failStep string) {
+	// the result is already updated by the abort watcher, if the experiment is aborted
+	if abort.IsAborted() {
+		return
+	}
+	types.SetResultAfterCompletion(details.ResultDetails, "Fail", "Completed", failStep)
+	steps.UpdateResult(details, "EOT")
+}
+
+// GenerateEvents logs the events, as there is no chaosengine or chaosresult to attach them to
+func (steps *standaloneSteps) GenerateEvents(details *Details, kind string) error {
+	log.Infof("[Event]: %v %v: %v", kind, details.EventsDetails.Reason, details.EventsDetails.Message)
+	return nil
+}
+
+// RunProbes runs the inline probes for the given phase
+func (steps *standaloneSteps) RunProbes(details *Details, phase string) error {
+	return probe.RunProbes(details.ChaosDetails, details.Clients, details.ResultDetails, phase, details.EventsDetails)
+}
+
+// WatchAbort watches for the abort signal in the background
+// it writes the report with the stopped verdict and exits, once the experiment is aborted
+// it doesn't exit, if the chaoslib reverts the chaos and exits on abort
+func (steps *standaloneSteps) WatchAbort(details *Details) {
+	go func() {
+		signChan := make(chan os.Signal, 1)
+		signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+		<-signChan
+
+		log.Info("[Chaos]: Chaos Experiment Abortion started because of terminated signal received")
+		types.SetResultAfterCompletion(details.ResultDetails, "Stopped", "Stopped", "Chaos injection stopped!")
+		steps.UpdateResult(details, "EOT")
+		abort.Trigger()
+		if err := steps.writeReport(details); err != nil {
+			log.Errorf("Unable to write the report, err: %v", err)
+		}
+		if !details.RevertOnAbort {
+			os.Exit(1)
+		}
+	}()
+}
+
+// AnnotateResult adds the annotations to the report
+func (steps *standaloneSteps) AnnotateResult(details *Details, annotations map[string]string) error {
+	steps.mu.Lock()
+	defer steps.mu.Unlock()
+	if steps.annotations == nil {
+		steps.annotations = map[string]string{}
+	}
+	for key, value := range annotations {
+		steps.annotations[key] = value
+	}
+	return nil
+}
+
+// writeReport writes the report of the experiment as json, it is written only once
+func (steps *standaloneSteps) writeReport(details *Details) error {
+	steps.mu.Lock()
+	defer steps.mu.Unlock()
+	if steps.reported {
+		return nil
+	}
+	steps.reported = true
+
+	report := Report{
+		Experiment:             details.ChaosDetails.ExperimentName,
+		Verdict:                string(details.ResultDetails.Verdict),
+		Phase:                  string(details.ResultDetails.Phase),
+		FailStep:               details.ResultDetails.FailStep,
+		ProbeSuccessPercentage: steps.probeSuccessPercentage,
+		Probes:                 steps.probeStatus,
+		Annotations:            steps.annotations,
+	}
+	encoder := json.NewEncoder(steps.out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return errors.Errorf("unable to write the report, err: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package experiment
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// setTestEnv restores the env set by the standalone run, once the test is completed
+func setTestEnv(t *testing.T, keys ...string) {
+	for _, key := range keys {
+		key := key
+		value, ok := os.LookupEnv(key)
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(key, value)
+				return
+			}
+			os.Unsetenv(key)
+		})
+	}
+}
+
+func TestRunStandaloneWithoutLitmus(t *testing.T) {
+	// the api server stand-in doesn't serve the litmus crds
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("unable to create the kube client, err: %v", err)
+	}
+
+	experiment := &fakeExperiment{}
+	Register("test-standalone", func() Experiment { return experiment })
+	spec := &Spec{
+		Name:       "test-standalone",
+		Namespace:  "litmus",
+		App:        AppSpec{Namespace: "default", Label: "app=nginx", Kind: "deployment"},
+		Parameters: map[string]interface{}{"TOTAL_CHAOS_DURATION": int64(10)},
+	}
+	keys := []string{"STANDALONE"}
+	for key := range spec.env() {
+		keys = append(keys, key)
+	}
+	setTestEnv(t, keys...)
+
+	out := &bytes.Buffer{}
+	if err := RunStandalone(spec, clients.ClientSets{KubeClient: kubeClient}, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls := strings.Join(experiment.calls, ","); calls != "prepare,validate:PreChaos,inject,validate:PostChaos" {
+		t.Fatalf("expected the experiment to be run, got %v calls", calls)
+	}
+	// the report is written to the output, as there is no chaosresult
+	report := Report{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("unable to decode the report %q, err: %v", out.String(), err)
+	}
+	if report.Experiment != "test-standalone" || report.Verdict != "Pass" || report.Phase != "Completed" {
+		t.Fatalf("expected the passed verdict in the report, got %+v", report)
+	}
+}
+
+func TestLoadSpecUnsupportedExperiment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "experiment.yaml")
+	if err := os.WriteFile(path, []byte("name: test-unregistered\nnamespace: litmus\n"), 0644); err != nil {
+		t.Fatalf("unable to write the spec, err: %v", err)
+	}
+	if _, err := LoadSpec(path); err == nil || !strings.Contains(err.Error(), "not supported in the standalone mode") {
+		t.Fatalf("expected the unsupported experiment error, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package experiment
+
+import (
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+)
+
+// Steps contains the steps of the lifecycle, which interacts with the cluster
+// the cluster steps can be replaced by the fakes, to test the runner and the experiments without the cluster
+type Steps interface {
+	// InitializeProbes set the probes of the chaosengine inside the chaosresult details
+	InitializeProbes(details *Details) error
+	// UpdateResult creates or updates the chaosresult, state can be SOT or EOT
+	UpdateResult(details *Details, state string) error
+	// SetResultUID sets the uid of the chaosresult inside the chaosresult details
+	SetResultUID(details *Details) error
+	// RecordAfterFailure records the failure inside the chaosresult
+	RecordAfterFailure(details *Details, failStep string)
+	// GenerateEvents generates the events for the given kind of resource
+	GenerateEvents(details *Details, kind string) error
+	// RunProbes runs the probes for the given phase
+	RunProbes(details *Details, phase string) error
+	// WatchAbort watches for the abort signal, it should be non-blocking
+	WatchAbort(details *Details)
+	// AnnotateResult sets the given annotations on the chaosresult
+	AnnotateResult(details *Details, annotations map[string]string) error
+}
+
+// clusterSteps contains the steps, which interacts with the cluster
+type clusterSteps struct{}
+
+// InitializeProbes set the probes of the chaosengine inside the chaosresult details
+func (clusterSteps) InitializeProbes(details *Details) error {
+	return probe.InitializeProbesInChaosResultDetails(details.ChaosDetails, details.Clients, details.ResultDetails)
+}
+
+// UpdateResult creates or updates the chaosresult
+func (clusterSteps) UpdateResult(details *Details, state string) error {
+	return result.ChaosResult(details.ChaosDetails, details.Clients, details.ResultDetails, state)
+}
+
+// SetResultUID sets the uid of the chaosresult inside the chaosresult details
+func (clusterSteps) SetResultUID(details *Details) error {
+	return result.SetResultUID(details.ResultDetails, details.Clients, details.ChaosDetails)
+}
+
+// RecordAfterFailure records the failure inside the chaosresult
+func (clusterSteps) RecordAfterFailure(details *Details, failStep string) {
+	result.RecordAfterFailure(details.ChaosDetails, details.ResultDetails, failStep, details.Clients, details.EventsDetails)
+}
+
+// GenerateEvents generates the events for the given kind of resource
+func (clusterSteps) GenerateEvents(details *Details, kind string) error {
+	return events.GenerateEvents(details.EventsDetails, details.Clients, details.ChaosDetails, kind)
+}
+
+// RunProbes runs the probes for the given phase
+func (clusterSteps) RunProbes(details *Details, phase string) error {
+	return probe.RunProbes(details.ChaosDetails, details.Clients, details.ResultDetails, phase, details.EventsDetails)
+}
+
+// WatchAbort watches for the abort signal in the background
+// it generates the required events and result and exits, once the experiment is aborted
+// it doesn't exit, if the chaoslib reverts the chaos and exits on abort
+func (clusterSteps) WatchAbort(details *Details) {
+	if details.RevertOnAbort {
+		go common.AbortWatcherWithoutExit(details.ChaosDetails.ExperimentName, details.Clients, details.ResultDetails, details.ChaosDetails, details.EventsDetails)
+		return
+	}
+	go common.AbortWatcher(details.ChaosDetails.ExperimentName, details.Clients, details.ResultDetails, details.ChaosDetails, details.EventsDetails)
+}
+
+// AnnotateResult sets the given annotations on the chaosresult
+func (clusterSteps) AnnotateResult(details *Details, annotations map[string]string) error {
+	return result.SetResultAnnotations(details.ResultDetails.Name, details.ChaosDetails.ChaosNamespace, annotations, details.Clients)
+}
@@ -6,31 +6,31 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                string
-	EngineName                    string
-	ChaosDuration                 int
-	ChaosInterval                 int
-	RampTime                      int
-	ChaosLib                      string
-	AppNS                         string
-	AppLabel                      string
-	AppKind                       string
-	ChaosUID                      clientTypes.UID
-	TerminationGracePeriodSeconds int
-	InstanceID                    string
-	ChaosNamespace                string
-	ChaosPodName                  string
-	LIBImage                      string
-	LIBImagePullPolicy            string
-	TargetContainer               string
-	SocketPath                    string
-	ChaosServiceAccount           string
+	ExperimentName                string          `env:"EXPERIMENT_NAME" default:"container-kill"`
+	EngineName                    string          `env:"CHAOSENGINE"`
+	ChaosDuration                 int             `env:"TOTAL_CHAOS_DURATION" default:"20" validate:"duration=s"`
+	ChaosInterval                 int             `env:"CHAOS_INTERVAL" default:"10" validate:"duration=s"`
+	RampTime                      int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                      string          `env:"LIB" default:"litmus" validate:"enum=litmus|pumba"`
+	AppNS                         string          `env:"APP_NAMESPACE"`
+	AppLabel                      string          `env:"APP_LABEL"`
+	AppKind                       string          `env:"APP_KIND"`
+	ChaosUID                      clientTypes.UID `env:"CHAOS_UID"`
+	TerminationGracePeriodSeconds int             `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
+	InstanceID                    string          `env:"INSTANCE_ID"`
+	ChaosNamespace                string          `env:"CHAOS_NAMESPACE" validate:"required"`
+	ChaosPodName                  string          `env:"POD_NAME"`
+	LIBImage                      string          `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy            string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always" validate:"enum=Always|IfNotPresent|Never"`
+	TargetContainer               string          `env:"TARGET_CONTAINER"`
+	SocketPath                    string          `env:"SOCKET_PATH" default:"/var/run/docker.sock"`
+	ChaosServiceAccount           string          `env:"CHAOS_SERVICE_ACCOUNT"`
 	RunID                         string
-	Timeout                       int
-	Delay                         int
-	TargetPods                    string
-	ContainerRuntime              string
-	PodsAffectedPerc              int
-	Sequence                      string
-	Signal                        string
+	Timeout                       int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                         int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s"`
+	TargetPods                    string `env:"TARGET_PODS"`
+	ContainerRuntime              string `env:"CONTAINER_RUNTIME" default:"docker" validate:"enum=docker|containerd|crio"`
+	PodsAffectedPerc              int    `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	Sequence                      string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
+	Signal                        string `env:"SIGNAL" default:"SIGKILL"`
 }
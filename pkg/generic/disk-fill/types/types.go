@@ -6,32 +6,32 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                string
-	EngineName                    string
-	ChaosDuration                 int
-	RampTime                      int
-	ChaosLib                      string
-	AppNS                         string
-	AppLabel                      string
-	AppKind                       string
-	ChaosUID                      clientTypes.UID
-	InstanceID                    string
-	ChaosNamespace                string
-	ChaosPodName                  string
-	TargetContainer               string
-	AuxiliaryAppInfo              string
-	FillPercentage                int
-	ContainerPath                 string
+	ExperimentName                string          `env:"EXPERIMENT_NAME" default:"disk-fill"`
+	EngineName                    string          `env:"CHAOSENGINE"`
+	ChaosDuration                 int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	RampTime                      int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                      string          `env:"LIB" default:"litmus"`
+	AppNS                         string          `env:"APP_NAMESPACE"`
+	AppLabel                      string          `env:"APP_LABEL"`
+	AppKind                       string          `env:"APP_KIND"`
+	ChaosUID                      clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                    string          `env:"INSTANCE_ID"`
+	ChaosNamespace                string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                  string          `env:"POD_NAME"`
+	TargetContainer               string          `env:"TARGET_CONTAINER"`
+	AuxiliaryAppInfo              string          `env:"AUXILIARY_APPINFO"`
+	FillPercentage                int             `env:"FILL_PERCENTAGE" default:"80" validate:"percentage"`
+	ContainerPath                 string          `env:"CONTAINER_PATH" default:"/var/lib/docker/containers"`
 	RunID                         string
-	Timeout                       int
-	Delay                         int
-	LIBImage                      string
-	LIBImagePullPolicy            string
-	TargetPods                    string
-	PodsAffectedPerc              int
-	Sequence                      string
+	Timeout                       int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                         int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	LIBImage                      string `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy            string `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
+	TargetPods                    string `env:"TARGET_PODS"`
+	PodsAffectedPerc              int    `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	Sequence                      string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
 	ChaosServiceAccount           string
-	EphemeralStorageMebibytes     int
-	TerminationGracePeriodSeconds int
-	DataBlockSize                 int
+	EphemeralStorageMebibytes     int `env:"EPHEMERAL_STORAGE_MEBIBYTES"`
+	TerminationGracePeriodSeconds int `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
+	DataBlockSize                 int `env:"DATA_BLOCK_SIZE" default:"256"`
 }
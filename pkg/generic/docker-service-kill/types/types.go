@@ -6,26 +6,26 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                string
-	EngineName                    string
-	ChaosDuration                 int
-	RampTime                      int
-	ChaosLib                      string
-	AppNS                         string
-	AppLabel                      string
-	AppKind                       string
-	ChaosUID                      clientTypes.UID
-	TerminationGracePeriodSeconds int
-	InstanceID                    string
-	ChaosNamespace                string
-	ChaosPodName                  string
-	AuxiliaryAppInfo              string
+	ExperimentName                string          `env:"EXPERIMENT_NAME" default:"docker-service-kill"`
+	EngineName                    string          `env:"CHAOSENGINE"`
+	ChaosDuration                 int             `env:"TOTAL_CHAOS_DURATION" default:"90" validate:"duration=s"`
+	RampTime                      int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                      string          `env:"LIB" default:"litmus"`
+	AppNS                         string          `env:"APP_NAMESPACE"`
+	AppLabel                      string          `env:"APP_LABEL"`
+	AppKind                       string          `env:"APP_KIND"`
+	ChaosUID                      clientTypes.UID `env:"CHAOS_UID"`
+	TerminationGracePeriodSeconds int             `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
+	InstanceID                    string          `env:"INSTANCE_ID"`
+	ChaosNamespace                string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                  string          `env:"POD_NAME"`
+	AuxiliaryAppInfo              string          `env:"AUXILIARY_APPINFO"`
 	RunID                         string
-	TargetNode                    string
-	NodeLabel                     string
-	Timeout                       int
-	Delay                         int
-	LIBImage                      string
-	LIBImagePullPolicy            string
-	TargetContainer               string
+	TargetNode                    string `env:"TARGET_NODE"`
+	NodeLabel                     string `env:"NODE_LABEL"`
+	Timeout                       int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                         int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	LIBImage                      string `env:"LIB_IMAGE" default:"ubuntu:16.04"`
+	LIBImagePullPolicy            string `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
+	TargetContainer               string `env:"TARGET_CONTAINER"`
 }
@@ -6,37 +6,48 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                     string
-	EngineName                         string
-	ChaosDuration                      int
-	LIBImage                           string
-	LIBImagePullPolicy                 string
-	RampTime                           int
-	ChaosLib                           string
-	AppNS                              string
-	AppLabel                           string
-	AppKind                            string
-	ChaosUID                           clientTypes.UID
-	InstanceID                         string
-	ChaosNamespace                     string
-	ChaosPodName                       string
+	ExperimentName                     string          `env:"EXPERIMENT_NAME"`
+	EngineName                         string          `env:"CHAOSENGINE"`
+	ChaosDuration                      int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	LIBImage                           string          `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy                 string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
+	RampTime                           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                           string          `env:"LIB" default:"litmus"`
+	AppNS                              string          `env:"APP_NAMESPACE"`
+	AppLabel                           string          `env:"APP_LABEL"`
+	AppKind                            string          `env:"APP_KIND"`
+	ChaosUID                           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                         string          `env:"INSTANCE_ID"`
+	ChaosNamespace                     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                       string          `env:"POD_NAME"`
 	RunID                              string
-	NetworkPacketDuplicationPercentage int
-	NetworkInterface                   string
-	TargetContainer                    string
-	NetworkLatency                     int
-	NetworkPacketLossPercentage        int
-	NetworkPacketCorruptionPercentage  int
-	TCImage                            string
-	Timeout                            int
-	Delay                              int
-	TargetPods                         string
-	PodsAffectedPerc                   int
-	DestinationIPs                     string
-	DestinationHosts                   string
-	ContainerRuntime                   string
-	ChaosServiceAccount                string
-	SocketPath                         string
-	Sequence                           string
-	TerminationGracePeriodSeconds      int
+	NetworkPacketDuplicationPercentage int    `env:"NETWORK_PACKET_DUPLICATION_PERCENTAGE" validate:"percentage"`
+	NetworkInterface                   string `env:"NETWORK_INTERFACE" default:"eth0"`
+	TargetContainer                    string `env:"TARGET_CONTAINER"`
+	NetworkLatency                     int    `env:"NETWORK_LATENCY" validate:"duration=ms"`
+	NetworkPacketLossPercentage        int    `env:"NETWORK_PACKET_LOSS_PERCENTAGE" validate:"percentage"`
+	NetworkPacketCorruptionPercentage  int    `env:"NETWORK_PACKET_CORRUPTION_PERCENTAGE" validate:"percentage"`
+	TCImage                            string `env:"TC_IMAGE" default:"gaiadocker/iproute2"`
+	Timeout                            int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                              int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	TargetPods                         string `env:"TARGET_PODS"`
+	PodsAffectedPerc                   int    `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	DestinationIPs                     string `env:"DESTINATION_IPS"`
+	DestinationHosts                   string `env:"DESTINATION_HOSTS"`
+	DestinationPorts                   string `env:"DESTINATION_PORTS"`
+	SourcePorts                        string `env:"SOURCE_PORTS"`
+	Protocols                          string `env:"PROTOCOLS"`
+	TrafficDirection                   string `env:"TRAFFIC_DIRECTION" default:"egress"`
+	NetworkBandwidth                   string `env:"NETWORK_BANDWIDTH"`
+	Burst                              string `env:"BURST"`
+	Limit                              string `env:"LIMIT"`
+	Shaper                             string `env:"SHAPER"`
+	Jitter                             int    `env:"JITTER" validate:"duration=ms"`
+	JitterDistribution                 string `env:"JITTER_DISTRIBUTION"`
+	LossCorrelation                    int    `env:"LOSS_CORRELATION" validate:"percentage"`
+	ContainerRuntime                   string `env:"CONTAINER_RUNTIME" default:"docker"`
+	ChaosServiceAccount                string `env:"CHAOS_SERVICE_ACCOUNT"`
+	SocketPath                         string `env:"SOCKET_PATH" default:"/var/run/docker.sock"`
+	Sequence                           string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
+	TerminationGracePeriodSeconds      int    `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
 }
@@ -6,23 +6,28 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	TargetNode         string
-	AuxiliaryAppInfo   string
-	Timeout            int
-	Delay              int
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"node-drain"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	TargetNode         string          `env:"TARGET_NODE"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
 	LIBImagePullPolicy string
-	TargetContainer    string
-	NodeLabel          string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	NodeLabel          string `env:"NODE_LABEL"`
+	// DrainPodSelector is the label selector of the pods, which are evicted during the drain
+	DrainPodSelector      string `env:"DRAIN_POD_SELECTOR"`
+	IgnoreDaemonSets      bool   `env:"IGNORE_DAEMONSETS" default:"true"`
+	SkipMirrorPods        bool   `env:"SKIP_MIRROR_PODS" default:"true"`
+	EvictionRetryInterval int    `env:"EVICTION_RETRY_INTERVAL" default:"5"`
 }
@@ -6,33 +6,33 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                  string
-	EngineName                      string
-	ChaosDuration                   int
-	RampTime                        int
-	ChaosLib                        string
-	AppNS                           string
-	AppLabel                        string
-	AppKind                         string
-	ChaosUID                        clientTypes.UID
-	InstanceID                      string
-	TerminationGracePeriodSeconds   int
-	ChaosNamespace                  string
-	ChaosPodName                    string
+	ExperimentName                  string          `env:"EXPERIMENT_NAME" default:"node-io-stress"`
+	EngineName                      string          `env:"CHAOSENGINE"`
+	ChaosDuration                   int             `env:"TOTAL_CHAOS_DURATION" default:"120" validate:"duration=s"`
+	RampTime                        int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                        string          `env:"LIB" default:"litmus"`
+	AppNS                           string          `env:"APP_NAMESPACE"`
+	AppLabel                        string          `env:"APP_LABEL"`
+	AppKind                         string          `env:"APP_KIND"`
+	ChaosUID                        clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                      string          `env:"INSTANCE_ID"`
+	TerminationGracePeriodSeconds   int             `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
+	ChaosNamespace                  string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                    string          `env:"POD_NAME"`
 	RunID                           string
-	LIBImage                        string
-	LIBImagePullPolicy              string
-	AuxiliaryAppInfo                string
-	Timeout                         int
-	Delay                           int
-	TargetNodes                     string
-	FilesystemUtilizationPercentage int
-	FilesystemUtilizationBytes      int
-	CPU                             int
-	NumberOfWorkers                 int
-	VMWorkers                       int
-	NodesAffectedPerc               int
-	Sequence                        string
-	TargetContainer                 string
-	NodeLabel                       string
+	LIBImage                        string `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy              string `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
+	AuxiliaryAppInfo                string `env:"AUXILIARY_APPINFO"`
+	Timeout                         int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                           int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	TargetNodes                     string `env:"TARGET_NODES"`
+	FilesystemUtilizationPercentage int    `env:"FILESYSTEM_UTILIZATION_PERCENTAGE" validate:"percentage"`
+	FilesystemUtilizationBytes      int    `env:"FILESYSTEM_UTILIZATION_BYTES"`
+	CPU                             int    `env:"CPU" default:"1"`
+	NumberOfWorkers                 int    `env:"NUMBER_OF_WORKERS" default:"4"`
+	VMWorkers                       int    `env:"VM_WORKERS" default:"1"`
+	NodesAffectedPerc               int    `env:"NODES_AFFECTED_PERC" default:"0" validate:"percentage"`
+	Sequence                        string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
+	TargetContainer                 string `env:"TARGET_CONTAINER"`
+	NodeLabel                       string `env:"NODE_LABEL"`
 }
@@ -6,31 +6,31 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                string
-	EngineName                    string
-	ChaosDuration                 int
-	RampTime                      int
-	ChaosLib                      string
-	AppNS                         string
-	AppLabel                      string
-	AppKind                       string
-	ChaosUID                      clientTypes.UID
-	TerminationGracePeriodSeconds int
-	InstanceID                    string
-	ChaosNamespace                string
-	ChaosPodName                  string
-	MemoryConsumptionPercentage   int
-	MemoryConsumptionMebibytes    int
-	NumberOfWorkers               int
+	ExperimentName                string          `env:"EXPERIMENT_NAME" default:"node-memory-hog"`
+	EngineName                    string          `env:"CHAOSENGINE"`
+	ChaosDuration                 int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	RampTime                      int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                      string          `env:"LIB" default:"litmus"`
+	AppNS                         string          `env:"APP_NAMESPACE"`
+	AppLabel                      string          `env:"APP_LABEL"`
+	AppKind                       string          `env:"APP_KIND"`
+	ChaosUID                      clientTypes.UID `env:"CHAOS_UID"`
+	TerminationGracePeriodSeconds int             `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
+	InstanceID                    string          `env:"INSTANCE_ID"`
+	ChaosNamespace                string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                  string          `env:"POD_NAME"`
+	MemoryConsumptionPercentage   int             `env:"MEMORY_CONSUMPTION_PERCENTAGE" validate:"percentage"`
+	MemoryConsumptionMebibytes    int             `env:"MEMORY_CONSUMPTION_MEBIBYTES"`
+	NumberOfWorkers               int             `env:"NUMBER_OF_WORKERS" default:"1"`
 	RunID                         string
-	LIBImage                      string
-	LIBImagePullPolicy            string
-	AuxiliaryAppInfo              string
-	Timeout                       int
-	Delay                         int
-	TargetNodes                   string
-	NodesAffectedPerc             int
-	Sequence                      string
-	TargetContainer               string
-	NodeLabel                     string
+	LIBImage                      string `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy            string `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
+	AuxiliaryAppInfo              string `env:"AUXILIARY_APPINFO"`
+	Timeout                       int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                         int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	TargetNodes                   string `env:"TARGET_NODES"`
+	NodesAffectedPerc             int    `env:"NODES_AFFECTED_PERC" default:"0" validate:"percentage"`
+	Sequence                      string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
+	TargetContainer               string `env:"TARGET_CONTAINER"`
+	NodeLabel                     string `env:"NODE_LABEL"`
 }
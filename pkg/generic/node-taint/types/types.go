@@ -6,24 +6,24 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	RampTime           int
-	ChaosDuration      int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	TargetNode         string
-	AuxiliaryAppInfo   string
-	Taints             string
-	Timeout            int
-	Delay              int
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"node-taint"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	TargetNode         string          `env:"TARGET_NODE"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
+	Taints             string          `env:"TAINTS"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
 	LIBImagePullPolicy string
-	TargetContainer    string
-	NodeLabel          string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	NodeLabel          string `env:"NODE_LABEL"`
 }
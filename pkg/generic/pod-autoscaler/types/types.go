@@ -6,26 +6,26 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName      string
-	EngineName          string
-	ChaosDuration       int
-	RampTime            int
-	Replicas            int
-	ChaosLib            string
-	AppNS               string
-	AppLabel            string
-	AppKind             string
-	AppAffectPercentage int
-	ChaosUID            clientTypes.UID
-	InstanceID          string
-	ChaosNamespace      string
-	ChaosPodName        string
+	ExperimentName      string          `env:"EXPERIMENT_NAME" default:"pod-autoscaler"`
+	EngineName          string          `env:"CHAOSENGINE"`
+	ChaosDuration       int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	RampTime            int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	Replicas            int             `env:"REPLICA_COUNT"`
+	ChaosLib            string          `env:"LIB" default:"litmus"`
+	AppNS               string          `env:"APP_NAMESPACE"`
+	AppLabel            string          `env:"APP_LABEL"`
+	AppKind             string          `env:"APP_KIND"`
+	AppAffectPercentage int             `env:"APP_AFFECT_PERC" default:"100" validate:"percentage"`
+	ChaosUID            clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID          string          `env:"INSTANCE_ID"`
+	ChaosNamespace      string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName        string          `env:"POD_NAME"`
 	RunID               string
-	AuxiliaryAppInfo    string
-	Timeout             int
-	Delay               int
+	AuxiliaryAppInfo    string `env:"AUXILIARY_APPINFO"`
+	Timeout             int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay               int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
 	LIBImagePullPolicy  string
-	TargetContainer     string
+	TargetContainer     string `env:"TARGET_CONTAINER"`
 }
 
 // ApplicationUnderTest contains the name of the deployment object and the current replica count
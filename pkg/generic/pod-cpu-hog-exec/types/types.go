@@ -7,31 +7,31 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                string
-	EngineName                    string
-	ChaosDuration                 int
-	ChaosInterval                 int
-	RampTime                      int
-	ChaosLib                      string
-	AppNS                         string
-	AppLabel                      string
-	AppKind                       string
-	ChaosUID                      clientTypes.UID
-	InstanceID                    string
-	ChaosNamespace                string
-	ChaosPodName                  string
-	CPUcores                      int
-	PodsAffectedPerc              int
-	Timeout                       int
-	Delay                         int
-	TargetPods                    string
-	ChaosInjectCmd                string
-	ChaosKillCmd                  string
+	ExperimentName                string          `env:"EXPERIMENT_NAME" default:"pod-cpu-hog"`
+	EngineName                    string          `env:"CHAOSENGINE"`
+	ChaosDuration                 int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	ChaosInterval                 int             `env:"CHAOS_INTERVAL" default:"10" validate:"duration=s"`
+	RampTime                      int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                      string          `env:"LIB" default:"litmus"`
+	AppNS                         string          `env:"APP_NAMESPACE"`
+	AppLabel                      string          `env:"APP_LABEL"`
+	AppKind                       string          `env:"APP_KIND"`
+	ChaosUID                      clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                    string          `env:"INSTANCE_ID"`
+	ChaosNamespace                string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                  string          `env:"POD_NAME"`
+	CPUcores                      int             `env:"CPU_CORES" default:"1"`
+	PodsAffectedPerc              int             `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	Timeout                       int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                         int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	TargetPods                    string          `env:"TARGET_PODS"`
+	ChaosInjectCmd                string          `env:"CHAOS_INJECT_COMMAND" default:"md5sum /dev/zero"`
+	ChaosKillCmd                  string          `env:"CHAOS_KILL_COMMAND" default:"kill $(find /proc -name exe -lname '*/md5sum' 2>&1 | grep -v 'Permission denied' | awk -F/ '{print $(NF-1)}')"`
 	LIBImagePullPolicy            string
 	Annotations                   map[string]string
-	TargetContainer               string
-	Sequence                      string
+	TargetContainer               string `env:"TARGET_CONTAINER"`
+	Sequence                      string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
 	Resources                     corev1.ResourceRequirements
 	ImagePullSecrets              []corev1.LocalObjectReference
-	TerminationGracePeriodSeconds int
+	TerminationGracePeriodSeconds int `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
 }
@@ -6,26 +6,26 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName      string
-	EngineName          string
-	ChaosDuration       int
-	ChaosInterval       string
-	RampTime            int
-	Force               bool
-	ChaosLib            string
-	ChaosServiceAccount string
-	AppNS               string
-	AppLabel            string
-	AppKind             string
-	ChaosUID            clientTypes.UID
-	InstanceID          string
-	ChaosNamespace      string
-	ChaosPodName        string
-	Timeout             int
-	Delay               int
-	TargetPods          string
-	PodsAffectedPerc    int
-	Sequence            string
+	ExperimentName      string          `env:"EXPERIMENT_NAME" default:"pod-delete"`
+	EngineName          string          `env:"CHAOSENGINE"`
+	ChaosDuration       int             `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s"`
+	ChaosInterval       string          `env:"CHAOS_INTERVAL" default:"10" validate:"interval"`
+	RampTime            int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	Force               bool            `env:"FORCE" default:"false"`
+	ChaosLib            string          `env:"LIB" default:"litmus" validate:"enum=litmus|powerfulseal"`
+	ChaosServiceAccount string          `env:"CHAOS_SERVICE_ACCOUNT"`
+	AppNS               string          `env:"APP_NAMESPACE"`
+	AppLabel            string          `env:"APP_LABEL"`
+	AppKind             string          `env:"APP_KIND"`
+	ChaosUID            clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID          string          `env:"INSTANCE_ID"`
+	ChaosNamespace      string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName        string          `env:"POD_NAME"`
+	Timeout             int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay               int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s"`
+	TargetPods          string          `env:"TARGET_PODS"`
+	PodsAffectedPerc    int             `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	Sequence            string          `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
 	LIBImagePullPolicy  string
-	TargetContainer     string
+	TargetContainer     string `env:"TARGET_CONTAINER"`
 }
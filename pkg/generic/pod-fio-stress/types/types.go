@@ -6,33 +6,33 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	ChaosInterval      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	Timeout            int
-	Delay              int
-	TargetContainer    string
+	ExperimentName     string          `env:"EXPERIMENT_NAME"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s"`
+	ChaosInterval      int             `env:"CHAOS_INTERVAL" default:"10" validate:"duration=s"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	TargetContainer    string          `env:"TARGET_CONTAINER"`
 	ChaosInjectCmd     string
-	ChaosKillCmd       string
-	PodsAffectedPerc   int
-	TargetPods         string
+	ChaosKillCmd       string `env:"CHAOS_KILL_COMMAND" default:"killall fio"`
+	PodsAffectedPerc   int    `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	TargetPods         string `env:"TARGET_PODS"`
 	LIBImagePullPolicy string
-	Sequence           string
-	IOEngine           string
-	IODepth            int
-	ReadWrite          string
-	BlockSize          string
-	Size               string
-	NumJobs            int
-	GroupReporting     bool
+	Sequence           string `env:"SEQUENCE" validate:"enum=serial|parallel"`
+	IOEngine           string `env:"IO_ENGINE"`
+	IODepth            int    `env:"IO_DEPTH"`
+	ReadWrite          string `env:"READ_WRITE_MODE"`
+	BlockSize          string `env:"BLOCK_SIZE"`
+	Size               string `env:"SIZE"`
+	NumJobs            int    `env:"NUMBER_OF_JOBS"`
+	GroupReporting     bool   `env:"GROUP_REPORTING" default:"true"`
 }
@@ -7,29 +7,29 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	ChaosInterval      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	PodsAffectedPerc   int
-	MemoryConsumption  int
-	Timeout            int
-	Delay              int
-	TargetPods         string
-	ChaosKillCmd       string
-	LIBImagePullPolicy string
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"pod-memory-hog"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s"`
+	ChaosInterval      int             `env:"CHAOS_INTERVAL" default:"10" validate:"duration=s"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	PodsAffectedPerc   int             `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	MemoryConsumption  int             `env:"MEMORY_CONSUMPTION" default:"500"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	TargetPods         string          `env:"TARGET_PODS"`
+	ChaosKillCmd       string          `env:"CHAOS_KILL_COMMAND" default:"kill $(find /proc -name exe -lname '*/dd' 2>&1 | grep -v 'Permission denied' | awk -F/ '{print $(NF-1)}' | head -n 1)"`
+	LIBImagePullPolicy string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
 	Annotations        map[string]string
-	TargetContainer    string
-	Sequence           string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	Sequence           string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
 	Resources          corev1.ResourceRequirements
 	ImagePullSecrets   []corev1.LocalObjectReference
 }
@@ -6,36 +6,36 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                  string
-	EngineName                      string
-	ChaosDuration                   int
-	LIBImage                        string
-	LIBImagePullPolicy              string
-	RampTime                        int
-	ChaosLib                        string
-	AppNS                           string
-	AppLabel                        string
-	AppKind                         string
-	ChaosUID                        clientTypes.UID
-	InstanceID                      string
-	ChaosNamespace                  string
-	ChaosPodName                    string
+	ExperimentName                  string          `env:"EXPERIMENT_NAME"`
+	EngineName                      string          `env:"CHAOSENGINE"`
+	ChaosDuration                   int             `env:"TOTAL_CHAOS_DURATION" default:"60" validate:"duration=s"`
+	LIBImage                        string          `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy              string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
+	RampTime                        int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib                        string          `env:"LIB" default:"litmus"`
+	AppNS                           string          `env:"APP_NAMESPACE"`
+	AppLabel                        string          `env:"APP_LABEL"`
+	AppKind                         string          `env:"APP_KIND"`
+	ChaosUID                        clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                      string          `env:"INSTANCE_ID"`
+	ChaosNamespace                  string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                    string          `env:"POD_NAME"`
 	RunID                           string
-	TargetContainer                 string
-	StressImage                     string
-	Timeout                         int
-	Delay                           int
-	TargetPods                      string
-	PodsAffectedPerc                int
-	ContainerRuntime                string
-	ChaosServiceAccount             string
-	SocketPath                      string
-	Sequence                        string
-	TerminationGracePeriodSeconds   int
-	CPUcores                        int
-	FilesystemUtilizationPercentage int
-	FilesystemUtilizationBytes      int
-	NumberOfWorkers                 int
-	MemoryConsumption               int
-	VolumeMountPath                 string
+	TargetContainer                 string `env:"TARGET_CONTAINER"`
+	StressImage                     string `env:"STRESS_IMAGE" default:"alexeiled/stress-ng:latest-ubuntu"`
+	Timeout                         int    `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                           int    `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	TargetPods                      string `env:"TARGET_PODS"`
+	PodsAffectedPerc                int    `env:"PODS_AFFECTED_PERC" default:"0" validate:"percentage"`
+	ContainerRuntime                string `env:"CONTAINER_RUNTIME" default:"docker"`
+	ChaosServiceAccount             string `env:"CHAOS_SERVICE_ACCOUNT"`
+	SocketPath                      string `env:"SOCKET_PATH" default:"/var/run/docker.sock"`
+	Sequence                        string `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
+	TerminationGracePeriodSeconds   int    `env:"TERMINATION_GRACE_PERIOD_SECONDS" validate:"duration=s"`
+	CPUcores                        int    `env:"CPU_CORES"`
+	FilesystemUtilizationPercentage int    `env:"FILESYSTEM_UTILIZATION_PERCENTAGE" validate:"percentage"`
+	FilesystemUtilizationBytes      int    `env:"FILESYSTEM_UTILIZATION_BYTES"`
+	NumberOfWorkers                 int    `env:"NUMBER_OF_WORKERS"`
+	MemoryConsumption               int    `env:"MEMORY_CONSUMPTION"`
+	VolumeMountPath                 string `env:"VOLUME_MOUNT_PATH"`
 }
@@ -6,28 +6,28 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	ChaosInterval      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	AuxiliaryAppInfo   string
-	Timeout            int
-	Delay              int
-	Sequence           string
-	VolumeTag          string
-	Region             string
+	ExperimentName     string          `env:"EXPERIMENT_NAME"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s"`
+	ChaosInterval      int             `env:"CHAOS_INTERVAL" default:"30" validate:"duration=s"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" validate:"duration=s"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
+	Sequence           string          `env:"SEQUENCE" default:"parallel" validate:"enum=serial|parallel"`
+	VolumeTag          string          `env:"EBS_VOLUME_TAG"`
+	Region             string          `env:"REGION"`
 	LIBImagePullPolicy string
-	TargetContainer    string
-	VolumeAffectedPerc int
-	EBSVolumeID        string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	VolumeAffectedPerc int    `env:"VOLUME_AFFECTED_PERC" default:"0" validate:"percentage"`
+	EBSVolumeID        string `env:"EBS_VOLUME_ID"`
 	TargetVolumeIDList []string
 }
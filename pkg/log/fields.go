@@ -0,0 +1,109 @@
+package log
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+const (
+	// ExperimentField contains the name of the chaos experiment
+	ExperimentField = "experiment"
+	// EngineField contains the name of the chaosengine
+	EngineField = "engine"
+	// InstanceIDField contains the instance id of the chaosengine
+	InstanceIDField = "instanceID"
+	// RunIDField contains the id of the experiment run, it is shared by the experiment and helper pods
+	RunIDField = "runID"
+	// PhaseField contains the current phase of the experiment
+	PhaseField = "phase"
+	// TargetPodField contains the name of the target pod(s)
+	TargetPodField = "targetPod"
+)
+
+var (
+	mu sync.RWMutex
+	// defaultFields contains the fields, which are added to all the log entries
+	defaultFields = logrus.Fields{}
+)
+
+// Init configure the formatter, level and default fields of the logger from the env
+// LOG_FORMAT can be text (default) or json and LOG_LEVEL can be any of the logrus levels
+func Init() {
+	switch strings.ToLower(os.Getenv("LOG_FORMAT")) {
+	case "json":
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		logrus.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp:          true,
+			DisableSorting:         true,
+			DisableLevelTruncation: true,
+		})
+	}
+
+	if level, err := logrus.ParseLevel(os.Getenv("LOG_LEVEL")); err == nil {
+		logrus.SetLevel(level)
+	}
+
+	// run id defaults to the name of the experiment pod
+	// it is provided explicitly to the helper pods
+	runID := os.Getenv("RUN_ID")
+	if runID == "" {
+		runID = os.Getenv("POD_NAME")
+	}
+	// target pod defaults to the APP_POD env, which is provided to the helper pods
+	targetPod := os.Getenv("TARGET_POD")
+	if targetPod == "" {
+		targetPod = os.Getenv("APP_POD")
+	}
+
+	SetField(ExperimentField, os.Getenv("EXPERIMENT_NAME"))
+	SetField(EngineField, os.Getenv("CHAOSENGINE"))
+	SetField(InstanceIDField, os.Getenv("INSTANCE_ID"))
+	SetField(RunIDField, runID)
+	SetField(PhaseField, os.Getenv("CHAOS_PHASE"))
+	SetField(TargetPodField, targetPod)
+}
+
+// SetField set the default field, which is added to all the log entries
+// the field is removed, if the value is empty
+func SetField(key, value string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if value == "" {
+		delete(defaultFields, key)
+		return
+	}
+	defaultFields[key] = value
+}
+
+// SetPhase set the current phase of the experiment
+func SetPhase(phase string) {
+	SetField(PhaseField, phase)
+}
+
+// SetTargetPod set the name of the target pod(s)
+func SetTargetPod(podName string) {
+	SetField(TargetPodField, podName)
+}
+
+// GetField returns the value of the default field
+func GetField(key string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	value, _ := defaultFields[key].(string)
+	return value
+}
+
+// entry returns the log entry with the default fields
+func entry() *logrus.Entry {
+	mu.RLock()
+	defer mu.RUnlock()
+	fields := make(logrus.Fields, len(defaultFields))
+	for key, value := range defaultFields {
+		fields[key] = value
+	}
+	return logrus.WithFields(fields)
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const namespace = "litmuschaos"
+
+// labels contains the common labels derived from the chaos details
+var labels = []string{"chaos_experiment", "chaos_engine", "chaos_namespace", "instance_id", "app_namespace", "app_kind"}
+
+var (
+	injectionStartTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "experiment_injection_start_timestamp_seconds",
+		Help:      "Unix timestamp of the start of the chaos injection",
+	}, labels)
+
+	injectionEndTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "experiment_injection_end_timestamp_seconds",
+		Help:      "Unix timestamp of the end of the chaos injection",
+	}, labels)
+
+	targetStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "experiment_target_status",
+		Help:      "Chaos status of the target, the current status of the target is set to 1",
+	}, append(labels, "target", "kind", "status"))
+
+	probeResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "probe_results_total",
+		Help:      "Total number of the probe evaluations by result",
+	}, append(labels, "probe", "type", "phase", "result"))
+
+	probeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "probe_duration_seconds",
+		Help:      "Duration of the probe executions",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, append(labels, "probe", "type", "phase"))
+
+	verdict = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "experiment_verdict",
+		Help:      "Verdict of the chaos experiment, the current verdict is set to 1",
+	}, append(labels, "verdict"))
+)
+
+// verdicts contains all the possible verdicts of the experiment
+var verdicts = []string{"Awaited", "Pass", "Fail", "Stopped"}
+
+// targetStatuses contains all the possible chaos statuses of the target
+var targetStatuses = []string{"targeted", "injected", "reverted", "detached", "re-attached"}
+
+func init() {
+	prometheus.MustRegister(injectionStartTime, injectionEndTime, targetStatus, probeResults, probeDuration, verdict)
+}
+
+// Serve exposes the metrics on the /metrics path of the given address
+// it is a no-op, if the address is not provided
+func Serve(address string) {
+	if address == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		log.Infof("[Metrics]: Serving the metrics on %v/metrics", address)
+		if err := http.ListenAndServe(address, mux); err != nil {
+			log.Errorf("unable to serve the metrics, err: %v", err)
+		}
+	}()
+}
+
+// getLabels returns the values of the common labels from the chaos details
+func getLabels(chaosDetails *types.ChaosDetails, extra ...string) []string {
+	return append([]string{
+		chaosDetails.ExperimentName,
+		chaosDetails.EngineName,
+		chaosDetails.ChaosNamespace,
+		chaosDetails.InstanceID,
+		chaosDetails.AppDetail.Namespace,
+		chaosDetails.AppDetail.Kind,
+	}, extra...)
+}
+
+// SetInjectionStart records the start of the chaos injection, if not recorded already
+func SetInjectionStart(chaosDetails *types.ChaosDetails) {
+	gauge := injectionStartTime.WithLabelValues(getLabels(chaosDetails)...)
+	if getValue(gauge) == 0 {
+		gauge.SetToCurrentTime()
+	}
+}
+
+// SetInjectionEnd records the end of the chaos injection
+// it is a no-op, if the injection is not started yet or the end is recorded already (unless override is true)
+func SetInjectionEnd(chaosDetails *types.ChaosDetails, override bool) {
+	if getValue(injectionStartTime.WithLabelValues(getLabels(chaosDetails)...)) == 0 {
+		return
+	}
+	gauge := injectionEndTime.WithLabelValues(getLabels(chaosDetails)...)
+	if override || getValue(gauge) == 0 {
+		gauge.SetToCurrentTime()
+	}
+}
+
+// SetTargetStatus records the chaos status of the target
+func SetTargetStatus(chaosDetails *types.ChaosDetails, target, kind, status string) {
+	for _, s := range targetStatuses {
+		targetStatus.WithLabelValues(getLabels(chaosDetails, target, kind, s)...).Set(0)
+	}
+	targetStatus.WithLabelValues(getLabels(chaosDetails, target, kind, status)...).Set(1)
+}
+
+// RecordProbe records the result and the duration of the probe execution
+// duration is not recorded, if it is zero
+func RecordProbe(chaosDetails *types.ChaosDetails, probeName, probeType, phase, result string, duration time.Duration) {
+	probeResults.WithLabelValues(getLabels(chaosDetails, probeName, probeType, phase, result)...).Inc()
+	if duration > 0 {
+		probeDuration.WithLabelValues(getLabels(chaosDetails, probeName, probeType, phase)...).Observe(duration.Seconds())
+	}
+}
+
+// SetVerdict records the current verdict of the experiment
+func SetVerdict(chaosDetails *types.ChaosDetails, experimentVerdict string) {
+	for _, v := range verdicts {
+		verdict.WithLabelValues(getLabels(chaosDetails, v)...).Set(0)
+	}
+	verdict.WithLabelValues(getLabels(chaosDetails, experimentVerdict)...).Set(1)
+}
+
+// getValue returns the current value of the gauge
+func getValue(gauge prometheus.Gauge) float64 {
+	var metric dto.Metric
+	if err := gauge.Write(&metric); err != nil {
+		return 0
+	}
+	return metric.GetGauge().GetValue()
+}
@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
@@ -28,7 +27,7 @@ import (
 // cmd probe can be used to add the command probes
 // it can be of two types one: which need a source(an external image)
 // another: any inline command which can be run without source image, directly via go-runner image
-func prepareCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+func prepareCmdProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
 
 	switch strings.ToLower(phase) {
 	case "prechaos":
@@ -50,8 +49,9 @@ func prepareCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 }
 
 // triggerInlineCmdProbe trigger the cmd probe and storing the output into the out buffer
-func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+func triggerInlineCmdProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails) error {
 
+	var err error
 	// It parse the templated command and return normal string
 	// if command doesn't have template, it will return the same command
 	probe.CmdProbeInputs.Command, err = parseCommand(probe.CmdProbeInputs.Command, resultDetails)
@@ -63,9 +63,7 @@ func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.
 	// it will retry for some retry count, in each iterations of try it contains following things
 	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
 	// for a timeout, it will run the command, if it fails wait for the iterval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
+	return probeRetry(probe).
 		TryWithTimeout(func(attempt uint) error {
 			var out, errOut bytes.Buffer
 			// run the inline command probe
@@ -82,16 +80,15 @@ func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.
 				return err
 			}
 
-			probes := types.ProbeArtifact{}
-			probes.ProbeArtifacts.Register = strings.TrimSpace(out.String())
-			resultDetails.ProbeArtifacts[probe.Name] = probes
+			resultDetails.SetProbeArtifact(probe.Name, strings.TrimSpace(out.String()))
 			return nil
 		})
 }
 
 // triggerSourceCmdProbe trigger the cmd probe inside the external pod
-func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+func triggerSourceCmdProbe(probe types.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
 
+	var err error
 	// It parse the templated command and return normal string
 	// if command doesn't have template, it will return the same command
 	probe.CmdProbeInputs.Command, err = parseCommand(probe.CmdProbeInputs.Command, resultDetails)
@@ -103,9 +100,7 @@ func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails li
 	// it will retry for some retry count, in each iterations of try it contains following things
 	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
 	// for a timeout, it will run the command, if it fails wait for the iterval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
+	return probeRetry(probe).
 		TryWithTimeout(func(attempt uint) error {
 			command := append([]string{"/bin/sh", "-c"}, probe.CmdProbeInputs.Command)
 			// exec inside the external pod to get the o/p of given command
@@ -120,15 +115,13 @@ func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails li
 				return err
 			}
 
-			probes := types.ProbeArtifact{}
-			probes.ProbeArtifacts.Register = strings.TrimSpace(output)
-			resultDetails.ProbeArtifacts[probe.Name] = probes
+			resultDetails.SetProbeArtifact(probe.Name, strings.TrimSpace(output))
 			return nil
 		})
 }
 
 // createProbePod creates an external pod with source image for the cmd probe
-func createProbePod(clients clients.ClientSets, chaosDetails *types.ChaosDetails, runID string, source v1alpha1.SourceDetails) error {
+func createProbePod(clients clients.ClientSets, chaosDetails *types.ChaosDetails, runID string, source types.SourceDetails) error {
 	//deriving serviceAccount name for the probe pod
 	svcAccount, err := getServiceAccount(chaosDetails.ChaosNamespace, chaosDetails.ChaosPodName, clients)
 	if err != nil {
@@ -203,7 +196,8 @@ func getRunID() string {
 }
 
 // triggerInlineContinuousCmdProbe trigger the inline continuous cmd probes
-func triggerInlineContinuousCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerInlineContinuousCmdProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	var err error
 	var isExperimentFailed bool
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
@@ -218,14 +212,10 @@ loop:
 		err = triggerInlineCmdProbe(probe, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
-			for index := range chaosresult.ProbeDetails {
-				if chaosresult.ProbeDetails[index].Name == probe.Name {
-					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-					log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
-					isExperimentFailed = true
-					break loop
-				}
-			}
+			setProbeError(chaosresult, probe.Name, err)
+			log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+			isExperimentFailed = true
+			break loop
 		}
 		// waiting for the probe polling interval
 		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -241,7 +231,8 @@ loop:
 }
 
 // triggerInlineOnChaosCmdProbe trigger the inline onchaos cmd probes
-func triggerInlineOnChaosCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerInlineOnChaosCmdProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	var err error
 	var isExperimentFailed bool
 	duration := chaosDetails.ChaosDuration
 	// waiting for initial delay
@@ -267,14 +258,10 @@ loop:
 		default:
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err = triggerInlineCmdProbe(probe, chaosresult); err != nil {
-				for index := range chaosresult.ProbeDetails {
-					if chaosresult.ProbeDetails[index].Name == probe.Name {
-						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-						log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
-						isExperimentFailed = true
-						break loop
-					}
-				}
+				setProbeError(chaosresult, probe.Name, err)
+				log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+				isExperimentFailed = true
+				break loop
 			}
 			// waiting for the probe polling interval
 			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -291,8 +278,9 @@ loop:
 }
 
 // triggerSourceOnChaosCmdProbe trigger the onchaos cmd probes having need some external source image
-func triggerSourceOnChaosCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerSourceOnChaosCmdProbe(probe types.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 
+	var err error
 	var isExperimentFailed bool
 	duration := chaosDetails.ChaosDuration
 	// waiting for initial delay
@@ -318,14 +306,10 @@ loop:
 		default:
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err = triggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult); err != nil {
-				for index := range chaosresult.ProbeDetails {
-					if chaosresult.ProbeDetails[index].Name == probe.Name {
-						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-						log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
-						isExperimentFailed = true
-						break loop
-					}
-				}
+				setProbeError(chaosresult, probe.Name, err)
+				log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+				isExperimentFailed = true
+				break loop
 			}
 			// waiting for the probe polling interval
 			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -343,8 +327,9 @@ loop:
 }
 
 // triggerSourceContinuousCmdProbe trigger the continuous cmd probes having need some external source image
-func triggerSourceContinuousCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerSourceContinuousCmdProbe(probe types.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 
+	var err error
 	var isExperimentFailed bool
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
@@ -359,14 +344,10 @@ loop:
 		err = triggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
-			for index := range chaosresult.ProbeDetails {
-				if chaosresult.ProbeDetails[index].Name == probe.Name {
-					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-					log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
-					isExperimentFailed = true
-					break loop
-				}
-			}
+			setProbeError(chaosresult, probe.Name, err)
+			log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+			isExperimentFailed = true
+			break loop
 		}
 		// waiting for the probe polling interval
 		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -383,7 +364,7 @@ loop:
 
 // validateResult validate the probe result to specified comparison operation
 // it supports int, float, string operands
-func validateResult(comparator v1alpha1.ComparatorInfo, cmdOutput string, rc int) error {
+func validateResult(comparator types.ComparatorInfo, cmdOutput string, rc int) error {
 
 	compare := cmp.RunCount(rc).
 		FirstValue(cmdOutput).
@@ -392,15 +373,15 @@ func validateResult(comparator v1alpha1.ComparatorInfo, cmdOutput string, rc int
 
 	switch strings.ToLower(comparator.Type) {
 	case "int":
-		if err = compare.CompareInt(); err != nil {
+		if err := compare.CompareInt(); err != nil {
 			return err
 		}
 	case "float":
-		if err = compare.CompareFloat(); err != nil {
+		if err := compare.CompareFloat(); err != nil {
 			return err
 		}
 	case "string":
-		if err = compare.CompareString(); err != nil {
+		if err := compare.CompareString(); err != nil {
 			return err
 		}
 	default:
@@ -410,8 +391,9 @@ func validateResult(comparator v1alpha1.ComparatorInfo, cmdOutput string, rc int
 }
 
 //preChaosCmdProbe trigger the cmd probe for prechaos phase
-func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func preChaosCmdProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch probe.Mode {
 	case "SOT", "Edge":
 
@@ -433,12 +415,12 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		}
 
 		// triggering the cmd probe for the inline mode
-		if reflect.DeepEqual(probe.CmdProbeInputs.Source, v1alpha1.SourceDetails{}) {
+		if reflect.DeepEqual(probe.CmdProbeInputs.Source, types.SourceDetails{}) {
 			err = triggerInlineCmdProbe(probe, resultDetails)
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
-			if err := markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+			if err := markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PreChaos"); err != nil {
 				return err
 			}
 		} else {
@@ -453,7 +435,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
-			if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+			if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PreChaos"); err != nil {
 				return err
 			}
 
@@ -478,7 +460,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			"Mode":           probe.Mode,
 			"Phase":          "PreChaos",
 		})
-		if reflect.DeepEqual(probe.CmdProbeInputs.Source, v1alpha1.SourceDetails{}) {
+		if reflect.DeepEqual(probe.CmdProbeInputs.Source, types.SourceDetails{}) {
 			go triggerInlineContinuousCmdProbe(probe, clients, resultDetails, chaosDetails)
 		} else {
 
@@ -496,8 +478,9 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 }
 
 //postChaosCmdProbe trigger cmd probe for post chaos phase
-func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func postChaosCmdProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch probe.Mode {
 	case "EOT", "Edge":
 
@@ -519,12 +502,12 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 		}
 
 		// triggering the cmd probe for the inline mode
-		if reflect.DeepEqual(probe.CmdProbeInputs.Source, v1alpha1.SourceDetails{}) {
+		if reflect.DeepEqual(probe.CmdProbeInputs.Source, types.SourceDetails{}) {
 			err = triggerInlineCmdProbe(probe, resultDetails)
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
-			if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 				return err
 			}
 		} else {
@@ -539,7 +522,7 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
-			if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 				return err
 			}
 
@@ -552,11 +535,11 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			}
 		}
 	case "Continuous", "OnChaos":
-		if reflect.DeepEqual(probe.CmdProbeInputs.Source, v1alpha1.SourceDetails{}) {
+		if reflect.DeepEqual(probe.CmdProbeInputs.Source, types.SourceDetails{}) {
 			// it will check for the error, It will detect the error if any error encountered in probe during chaos
 			err = checkForErrorInContinuousProbe(resultDetails, probe.Name)
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-			if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 				return err
 			}
 		} else {
@@ -564,7 +547,7 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			err = checkForErrorInContinuousProbe(resultDetails, probe.Name)
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-			if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 				return err
 			}
 			// get runId
@@ -580,7 +563,7 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 }
 
 //onChaosCmdProbe trigger the cmd probe for DuringChaos phase
-func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func onChaosCmdProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	switch probe.Mode {
 	case "OnChaos":
@@ -595,7 +578,7 @@ func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 			"Mode":           probe.Mode,
 			"Phase":          "DuringChaos",
 		})
-		if reflect.DeepEqual(probe.CmdProbeInputs.Source, v1alpha1.SourceDetails{}) {
+		if reflect.DeepEqual(probe.CmdProbeInputs.Source, types.SourceDetails{}) {
 			go triggerInlineOnChaosCmdProbe(probe, clients, resultDetails, chaosDetails)
 		} else {
 
@@ -613,7 +596,8 @@ func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 
 // createHelperPod create the helper pod with the source image
 // it will be created if the mode is not inline
-func createHelperPod(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (litmusexec.PodDetails, error) {
+func createHelperPod(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (litmusexec.PodDetails, error) {
+	var err error
 	// Generate the run_id
 	runID := getRunID()
 	setRunIDForProbe(resultDetails, probe.Name, probe.Type, runID)
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// defaultDialTimeout is the timeout to connect with the grpc server, if the context doesn't contain any deadline
+const defaultDialTimeout = 10 * time.Second
+
+// Client is the grpc client used by the grpc probe
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// NewClient creates the connection with the given grpc server
+// it uses the tls credentials, if tls config is provided otherwise plaintext connection
+// it blocks until the connection is established or the deadline of the context expires
+func NewClient(ctx context.Context, address string, tlsConfig *tls.Config) (*Client, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultDialTimeout)
+		defer cancel()
+	}
+
+	opts := []grpc.DialOption{grpc.WithBlock()}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return nil, errors.Errorf("unable to connect with %v grpc server, err: %v", address, err)
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Close closes the connection with the grpc server
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// HealthCheck calls the grpc.health.v1.Health/Check method and returns the serving status of the service
+func (c *Client) HealthCheck(ctx context.Context, service string) (string, error) {
+	resp, err := healthpb.NewHealthClient(c.conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return "", errors.Errorf("health check failed, err: %v", err)
+	}
+	return resp.GetStatus().String(), nil
+}
+
+// Invoke calls the unary method with the JSON request and returns the JSON representation of the response
+// the method descriptors are resolved through the server reflection
+func (c *Client) Invoke(ctx context.Context, method, request string) (string, error) {
+	serviceName, methodName, err := parseMethod(method)
+	if err != nil {
+		return "", err
+	}
+
+	methodDesc, err := c.resolveMethod(ctx, serviceName, methodName)
+	if err != nil {
+		return "", err
+	}
+	if methodDesc.IsStreamingClient() || methodDesc.IsStreamingServer() {
+		return "", errors.Errorf("%v is a streaming method, only unary methods are supported", method)
+	}
+
+	req := dynamicpb.NewMessage(methodDesc.Input())
+	if strings.TrimSpace(request) == "" {
+		request = "{}"
+	}
+	if err := protojson.Unmarshal([]byte(request), req); err != nil {
+		return "", errors.Errorf("unable to parse the request of %v method, err: %v", method, err)
+	}
+
+	resp := dynamicpb.NewMessage(methodDesc.Output())
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/"+methodName, req, resp); err != nil {
+		return "", errors.Errorf("unable to call %v method, err: %v", method, err)
+	}
+
+	out, err := protojson.Marshal(resp)
+	if err != nil {
+		return "", errors.Errorf("unable to marshal the response of %v method, err: %v", method, err)
+	}
+	return string(out), nil
+}
+
+// resolveMethod returns the descriptor of the method, resolved through the server reflection
+func (c *Client) resolveMethod(ctx context.Context, serviceName, methodName string) (protoreflect.MethodDescriptor, error) {
+	files, err := resolveFiles(ctx, c.conn, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, errors.Errorf("unable to find %v service, err: %v", serviceName, err)
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, errors.Errorf("%v is not a service", serviceName)
+	}
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(methodName))
+	if methodDesc == nil {
+		return nil, errors.Errorf("%v method not found in %v service", methodName, serviceName)
+	}
+	return methodDesc, nil
+}
+
+// parseMethod parse the full method name into the service and method names
+// it supports package.Service/Method and package.Service.Method formats
+func parseMethod(method string) (string, string, error) {
+	method = strings.TrimPrefix(method, "/")
+	index := strings.LastIndex(method, "/")
+	if index == -1 {
+		index = strings.LastIndex(method, ".")
+	}
+	if index <= 0 || index == len(method)-1 {
+		return "", "", errors.Errorf("invalid method: '%v', it should be in package.Service/Method format", method)
+	}
+	return method[:index], method[index+1:], nil
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// resolveFiles fetch the file descriptors of the given symbol along with its dependencies
+// through the server reflection. The well-known types are resolved from the local registry
+// if the server doesn't serve them
+func resolveFiles(ctx context.Context, conn *grpc.ClientConn, symbol string) (*protoregistry.Files, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, errors.Errorf("unable to use the server reflection, err: %v", err)
+	}
+	defer stream.CloseSend()
+
+	files := map[string]*descriptorpb.FileDescriptorProto{}
+	fileProtos, err := request(stream, &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve the dependencies, which are not returned by the server in the first response
+	pending := fileProtos
+	for len(pending) != 0 {
+		file := pending[0]
+		pending = pending[1:]
+		if _, ok := files[file.GetName()]; ok {
+			continue
+		}
+		files[file.GetName()] = file
+
+		for _, dependency := range file.GetDependency() {
+			if _, ok := files[dependency]; ok {
+				continue
+			}
+			if fd, err := protoregistry.GlobalFiles.FindFileByPath(dependency); err == nil {
+				pending = append(pending, protodesc.ToFileDescriptorProto(fd))
+				continue
+			}
+			dependencies, err := request(stream, &reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dependency},
+			})
+			if err != nil {
+				return nil, err
+			}
+			pending = append(pending, dependencies...)
+		}
+	}
+
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	for _, file := range files {
+		fileSet.File = append(fileSet.File, file)
+	}
+	registry, err := protodesc.NewFiles(fileSet)
+	if err != nil {
+		return nil, errors.Errorf("unable to build the descriptors of %v, err: %v", symbol, err)
+	}
+	return registry, nil
+}
+
+// request sends the reflection request and returns the file descriptors from the response
+func request(stream reflectionpb.ServerReflection_ServerReflectionInfoClient, req *reflectionpb.ServerReflectionRequest) ([]*descriptorpb.FileDescriptorProto, error) {
+	if err := stream.Send(req); err != nil {
+		return nil, errors.Errorf("unable to send the reflection request, err: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, errors.Errorf("unable to receive the reflection response, err: %v", err)
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, errors.Errorf("reflection request failed, err: %v", errResp.GetErrorMessage())
+	}
+
+	var fileProtos []*descriptorpb.FileDescriptorProto
+	for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(raw, file); err != nil {
+			return nil, errors.Errorf("unable to parse the file descriptor, err: %v", err)
+		}
+		fileProtos = append(fileProtos, file)
+	}
+	return fileProtos, nil
+}
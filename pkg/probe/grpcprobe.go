@@ -0,0 +1,376 @@
+package probe
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	grpcProbe "github.com/litmuschaos/litmus-go/pkg/probe/grpc"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+)
+
+// healthCheckMethod is the method of the grpc health checking protocol
+const healthCheckMethod = "grpc.health.v1.Health/Check"
+
+// prepareGRPCProbe contains the steps to prepare the grpc probe
+// which calls the health check or unary method of the grpc server and compares the response
+func prepareGRPCProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		if err := preChaosGRPCProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "postchaos":
+		if err := postChaosGRPCProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "duringchaos":
+		if err := onChaosGRPCProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("phase '%s' not supported in the grpc probe", phase)
+	}
+	return nil
+}
+
+//preChaosGRPCProbe trigger the grpc probe for prechaos phase
+func preChaosGRPCProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	var err error
+	switch strings.ToLower(probe.Mode) {
+	case "sot", "edge":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Address":        probe.GRPCProbeInputs.Address,
+			"Method":         getGRPCMethod(probe.GRPCProbeInputs),
+			"Comparator":     probe.GRPCProbeInputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		}
+
+		// triggering the grpc probe and storing the output into the out buffer
+		err = triggerGRPCProbe(probe, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PreChaos"); err != nil {
+			return err
+		}
+
+	case "continuous":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Address":        probe.GRPCProbeInputs.Address,
+			"Method":         getGRPCMethod(probe.GRPCProbeInputs),
+			"Comparator":     probe.GRPCProbeInputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// trigger the continuous grpc probe
+		go triggerContinuousGRPCProbe(probe, clients, resultDetails, chaosDetails)
+	}
+
+	return nil
+}
+
+//postChaosGRPCProbe trigger the grpc probe for postchaos phase
+func postChaosGRPCProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	var err error
+	switch strings.ToLower(probe.Mode) {
+	case "eot", "edge":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Address":        probe.GRPCProbeInputs.Address,
+			"Method":         getGRPCMethod(probe.GRPCProbeInputs),
+			"Comparator":     probe.GRPCProbeInputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		}
+
+		// triggering the grpc probe and storing the output into the out buffer
+		err = triggerGRPCProbe(probe, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+
+	case "continuous", "onchaos":
+
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err = checkForErrorInContinuousProbe(resultDetails, probe.Name)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+
+	}
+	return nil
+}
+
+//onChaosGRPCProbe trigger the grpc probe for DuringChaos phase
+func onChaosGRPCProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	switch strings.ToLower(probe.Mode) {
+	case "onchaos":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Address":        probe.GRPCProbeInputs.Address,
+			"Method":         getGRPCMethod(probe.GRPCProbeInputs),
+			"Comparator":     probe.GRPCProbeInputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+
+		// trigger the onchaos grpc probe
+		go triggerOnChaosGRPCProbe(probe, clients, resultDetails, chaosDetails)
+	}
+	return nil
+}
+
+// triggerGRPCProbe trigger the grpc probe
+// it calls the health check or unary method of the grpc server and compares the response with the expected criteria
+func triggerGRPCProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails) error {
+
+	inputs := probe.GRPCProbeInputs
+	if inputs.Address == "" {
+		return errors.Errorf("[Probe]: address is required for the grpc probe")
+	}
+
+	comparator := getGRPCComparator(inputs)
+	if comparator.Type == "" {
+		return errors.Errorf("[Probe]: comparator is required for the %v grpc method", inputs.Method)
+	}
+
+	request, err := parseCommand(inputs.Request, resultDetails)
+	if err != nil {
+		return err
+	}
+
+	// running the grpc probe and matching the response
+	// it will retry for some retry count, in each iterations of try it contains following things
+	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
+	// for a timeout, it will call the method, if it fails wait for the interval and again call the method until timeout expires
+	// each call is bounded by the probe timeout, so that an unreachable server doesn't block the probe
+	return probeRetry(probe).
+		AttemptTimeout(time.Duration(probe.RunProperties.ProbeTimeout) * time.Second).
+		TryWithContext(func(ctx context.Context, attempt uint) error {
+
+			value, err := grpcCall(ctx, inputs, request, resultDetails)
+			if err != nil {
+				return err
+			}
+
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			// comparing the response with the expected criteria
+			if err = validateResult(comparator, value, rc); err != nil {
+				log.Errorf("The %v grpc probe has been Failed, err: %v", probe.Name, err)
+				return err
+			}
+
+			resultDetails.SetProbeArtifact(probe.Name, value)
+			return nil
+		})
+}
+
+// grpcCall calls the health check or unary method of the grpc server
+// it returns the serving status for the health check, otherwise the value extracted from the response
+func grpcCall(ctx context.Context, inputs types.GRPCProbeInputs, request string, resultDetails *types.ResultDetails) (string, error) {
+
+	if inputs.ResponseTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(inputs.ResponseTimeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	ctx, err := getGRPCMetadata(ctx, inputs.Metadata, resultDetails)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := getGRPCClient(ctx, inputs)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if inputs.Method == "" {
+		return client.HealthCheck(ctx, inputs.Service)
+	}
+
+	response, err := client.Invoke(ctx, inputs.Method, request)
+	if err != nil {
+		return "", err
+	}
+	if inputs.JSONPath == "" {
+		return response, nil
+	}
+	return extractJSONPath(inputs.JSONPath, []byte(response))
+}
+
+// getGRPCClient returns the grpc client with the tls details of the probe
+func getGRPCClient(ctx context.Context, inputs types.GRPCProbeInputs) (*grpcProbe.Client, error) {
+	if !inputs.UseTLS {
+		return grpcProbe.NewClient(ctx, inputs.Address, nil)
+	}
+	tlsConfig, err := getTLSConfig(inputs.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return grpcProbe.NewClient(ctx, inputs.Address, tlsConfig)
+}
+
+// getGRPCMetadata attach the metadata headers to the outgoing context
+func getGRPCMetadata(ctx context.Context, headers []types.HTTPHeader, resultDetails *types.ResultDetails) (context.Context, error) {
+	for _, header := range headers {
+		value, err := getHeaderValue(header, resultDetails)
+		if err != nil {
+			return nil, err
+		}
+		// grpc metadata keys are case insensitive and stored in lowercase
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(header.Name), value)
+	}
+	return ctx, nil
+}
+
+// getGRPCMethod returns the method called by the grpc probe
+func getGRPCMethod(inputs types.GRPCProbeInputs) string {
+	if inputs.Method == "" {
+		return healthCheckMethod
+	}
+	return inputs.Method
+}
+
+// getGRPCComparator returns the comparator of the grpc probe
+// for health check, it defaults to the string comparison with SERVING status
+func getGRPCComparator(inputs types.GRPCProbeInputs) types.ComparatorInfo {
+	comparator := inputs.Comparator
+	if inputs.Method != "" || comparator.Type != "" {
+		return comparator
+	}
+	return types.ComparatorInfo{
+		Type:     "string",
+		Criteria: "equal",
+		Value:    "SERVING",
+	}
+}
+
+// triggerContinuousGRPCProbe trigger the continuous grpc probe
+func triggerContinuousGRPCProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+
+	var err error
+	var isExperimentFailed bool
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+	}
+
+	// it trigger the grpc probe for the entire duration of chaos and it fails, if any err encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		err = triggerGRPCProbe(probe, chaosresult)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			setProbeError(chaosresult, probe.Name, err)
+			log.Errorf("The %v grpc probe has been Failed, err: %v", probe.Name, err)
+			isExperimentFailed = true
+			break loop
+		}
+		// waiting for the probe polling interval
+		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// triggerOnChaosGRPCProbe trigger the onchaos grpc probe
+func triggerOnChaosGRPCProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+
+	var err error
+	var isExperimentFailed bool
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
+	}
+
+	var endTime <-chan time.Time
+	timeDelay := time.Duration(duration) * time.Second
+
+	// it trigger the grpc probe for the entire duration of chaos and it fails, if any err encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		endTime = time.After(timeDelay)
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			endTime = nil
+			break loop
+		default:
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err = triggerGRPCProbe(probe, chaosresult); err != nil {
+				setProbeError(chaosresult, probe.Name, err)
+				log.Errorf("The %v grpc probe has been Failed, err: %v", probe.Name, err)
+				isExperimentFailed = true
+				break loop
+			}
+			// waiting for the probe polling interval
+			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+		}
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
@@ -2,30 +2,34 @@ package probe
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"os/exec"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
-	"crypto/tls"
 	"net/http"
 
-	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
 	"github.com/litmuschaos/litmus-go/pkg/types"
-	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 // prepareHTTPProbe contains the steps to prepare the http probe
 // http probe can be used to add the probe which will send a request to given url and match the status code
-func prepareHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+func prepareHTTPProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
 
 	switch strings.ToLower(phase) {
 	case "prechaos":
@@ -44,9 +48,20 @@ func prepareHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets
 	return nil
 }
 
+// httpRequest contains the details of the http request, derived from the http method
+type httpRequest struct {
+	method       string
+	contentType  string
+	body         string
+	bodyPath     string
+	criteria     string
+	responseCode string
+}
+
 // triggerHTTPProbe run the http probe command
-func triggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+func triggerHTTPProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails) error {
 
+	var err error
 	// It parse the templated url and return normal string
 	// if command doesn't have template, it will return the same command
 	probe.HTTPProbeInputs.URL, err = parseCommand(probe.HTTPProbeInputs.URL, resultDetails)
@@ -54,71 +69,179 @@ func triggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		return err
 	}
 
-	// it fetch the http method type
-	method := getHTTPMethodType(probe.HTTPProbeInputs.Method)
+	// it derive the http request from the http method
+	request := getHTTPRequest(probe.HTTPProbeInputs.Method)
+
+	log.InfoWithValues("[Probe]: HTTP "+request.method+" method informations", logrus.Fields{
+		"Name":            probe.Name,
+		"URL":             probe.HTTPProbeInputs.URL,
+		"Criteria":        request.criteria,
+		"ResponseCode":    request.responseCode,
+		"ContentType":     request.contentType,
+		"ResponseTimeout": probe.HTTPProbeInputs.ResponseTimeout,
+	})
+
+	// initialize the http client with the tls configuration
+	// insecureSkipVerify at the inputs level is honoured along with the tls details
+	tlsDetails := probe.HTTPProbeInputs.TLS
+	tlsDetails.InsecureSkipVerify = tlsDetails.InsecureSkipVerify || probe.HTTPProbeInputs.InsecureSkipVerify
+	client, err := getHTTPClient(tlsDetails, time.Duration(probe.HTTPProbeInputs.ResponseTimeout)*time.Millisecond)
+	if err != nil {
+		return err
+	}
 
-	// initialize simple http client with default attributes
-	timeout := time.Duration(probe.HTTPProbeInputs.ResponseTimeout) * time.Millisecond
-	client := &http.Client{Timeout: timeout}
-	// impose properties to http client with cert check disabled
-	if probe.HTTPProbeInputs.InsecureSkipVerify {
-		transCfg := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		client = &http.Client{Transport: transCfg, Timeout: timeout}
+	headers, err := getHTTPHeaders(probe.HTTPProbeInputs, resultDetails)
+	if err != nil {
+		return err
 	}
 
-	switch method {
-	case "Get":
-		log.InfoWithValues("[Probe]: HTTP get method informations", logrus.Fields{
-			"Name":            probe.Name,
-			"URL":             probe.HTTPProbeInputs.URL,
-			"Criteria":        probe.HTTPProbeInputs.Method.Get.Criteria,
-			"ResponseCode":    probe.HTTPProbeInputs.Method.Get.ResponseCode,
-			"ResponseTimeout": probe.HTTPProbeInputs.ResponseTimeout,
-		})
-		if err := httpGet(probe, client, resultDetails); err != nil {
+	var body string
+	// body is mandatory for the post method and optional for the custom methods
+	if request.method == http.MethodPost || request.body != "" || request.bodyPath != "" {
+		if body, err = getHTTPBody(request.body, request.bodyPath); err != nil {
 			return err
 		}
-	case "Post":
-		log.InfoWithValues("[Probe]: HTTP Post method informations", logrus.Fields{
-			"Name":            probe.Name,
-			"URL":             probe.HTTPProbeInputs.URL,
-			"Body":            probe.HTTPProbeInputs.Method.Post.Body,
-			"BodyPath":        probe.HTTPProbeInputs.Method.Post.BodyPath,
-			"ContentType":     probe.HTTPProbeInputs.Method.Post.ContentType,
-			"ResponseTimeout": probe.HTTPProbeInputs.ResponseTimeout,
-		})
-		if err := httpPost(probe, client, resultDetails); err != nil {
+		if body, err = parseCommand(body, resultDetails); err != nil {
 			return err
 		}
 	}
-	return nil
+
+	return httpCall(probe, client, request, headers, body, resultDetails)
+}
+
+// getHTTPRequest derive the http request details from the http method
+// it supports Get, Post and custom methods
+func getHTTPRequest(httpMethod types.HTTPMethod) httpRequest {
+	switch getHTTPMethodType(httpMethod) {
+	case http.MethodGet:
+		return httpRequest{
+			method:       http.MethodGet,
+			criteria:     httpMethod.Get.Criteria,
+			responseCode: httpMethod.Get.ResponseCode,
+		}
+	case http.MethodPost:
+		return httpRequest{
+			method:       http.MethodPost,
+			contentType:  httpMethod.Post.ContentType,
+			body:         httpMethod.Post.Body,
+			bodyPath:     httpMethod.Post.BodyPath,
+			criteria:     httpMethod.Post.Criteria,
+			responseCode: httpMethod.Post.ResponseCode,
+		}
+	default:
+		return httpRequest{
+			method:       strings.ToUpper(httpMethod.Custom.Name),
+			contentType:  httpMethod.Custom.ContentType,
+			body:         httpMethod.Custom.Body,
+			bodyPath:     httpMethod.Custom.BodyPath,
+			criteria:     httpMethod.Custom.Criteria,
+			responseCode: httpMethod.Custom.ResponseCode,
+		}
+	}
 }
 
 // it fetch the http method type
-// it supports Get and Post methods
-func getHTTPMethodType(httpMethod v1alpha1.HTTPMethod) string {
-	if !reflect.DeepEqual(httpMethod.Get, v1alpha1.GetMethod{}) {
-		return "Get"
+// it returns the name of the custom method, if provided otherwise Get or Post
+func getHTTPMethodType(httpMethod types.HTTPMethod) string {
+	switch {
+	case httpMethod.Custom.Name != "":
+		return strings.ToUpper(httpMethod.Custom.Name)
+	case !reflect.DeepEqual(httpMethod.Get, types.GetMethod{}):
+		return http.MethodGet
+	default:
+		return http.MethodPost
+	}
+}
+
+// getHTTPHeaders derive the http request headers from the probe inputs
+// the header values can be templated or can be sourced from env or mounted file
+func getHTTPHeaders(inputs types.HTTPProbeInputs, resultDetails *types.ResultDetails) (http.Header, error) {
+	headers := http.Header{}
+
+	authorization, err := getAuthorizationHeader(inputs.Auth)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		headers.Set("Authorization", authorization)
+	}
+
+	for _, header := range inputs.Headers {
+		if header.Name == "" {
+			return nil, errors.Errorf("[Probe]: name is required for the http header")
+		}
+		value, err := getHeaderValue(header, resultDetails)
+		if err != nil {
+			return nil, err
+		}
+		headers.Add(header.Name, value)
+	}
+	return headers, nil
+}
+
+// getHeaderValue returns the value of the http header
+// It will use value or valueFrom attributes to get the header value
+// if both are provided, it will use value field
+func getHeaderValue(header types.HTTPHeader, resultDetails *types.ResultDetails) (string, error) {
+	switch {
+	case header.Value != "":
+		return parseCommand(header.Value, resultDetails)
+	case header.ValueFrom.Env != "":
+		value, ok := os.LookupEnv(header.ValueFrom.Env)
+		if !ok {
+			return "", errors.Errorf("[Probe]: %v env not found for the %v header", header.ValueFrom.Env, header.Name)
+		}
+		return value, nil
+	case header.ValueFrom.File != "":
+		content, err := ioutil.ReadFile(header.ValueFrom.File)
+		if err != nil {
+			return "", errors.Errorf("unable to read the value of %v header, err: %v", header.Name, err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	default:
+		return "", errors.Errorf("[Probe]: Any one of value or valueFrom is required for the %v header", header.Name)
 	}
-	return "Post"
 }
 
-// httpGet send the http Get request to the given URL and verify the response code to follow the specified criteria
-func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails *types.ResultDetails) error {
+// httpCall send the http request to the given URL and verify the response code to follow the specified criteria
+// it also validates the response body, if the response body assertion is provided
+func httpCall(probe types.ProbeAttributes, client *http.Client, request httpRequest, headers http.Header, body string, resultDetails *types.ResultDetails) error {
 	// it will retry for some retry count, in each iterations of try it contains following things
 	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
 	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
+	return probeRetry(probe).
+		TryWithContext(func(ctx context.Context, attempt uint) error {
+			var reqBody io.Reader
+			if body != "" {
+				reqBody = strings.NewReader(body)
+			}
+			// the in-flight request is cancelled, if the probes are aborted
+			req, err := http.NewRequest(request.method, probe.HTTPProbeInputs.URL, reqBody)
+			if err != nil {
+				return err
+			}
+			req = req.WithContext(ctx)
+			for name, values := range headers {
+				req.Header[name] = values
+			}
+			if request.contentType != "" {
+				req.Header.Set("Content-Type", request.contentType)
+			}
+
 			// getting the response from the given url
-			resp, err := client.Get(probe.HTTPProbeInputs.URL)
+			// the latency of each request is recorded to evaluate the latency SLO
+			start := time.Now()
+			resp, err := client.Do(req)
+			recordLatency(resultDetails, probe, time.Since(start))
 			if err != nil {
 				return err
 			}
+			defer resp.Body.Close()
+
+			respBody, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return errors.Errorf("unable to read the response body, err: %v", err)
+			}
 
 			code := strconv.Itoa(resp.StatusCode)
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
@@ -126,62 +249,112 @@ func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails
 			// comparing the response code with the expected criteria
 			if err = cmp.RunCount(rc).
 				FirstValue(code).
-				SecondValue(probe.HTTPProbeInputs.Method.Get.ResponseCode).
-				Criteria(probe.HTTPProbeInputs.Method.Get.Criteria).
+				SecondValue(request.responseCode).
+				Criteria(request.criteria).
 				CompareInt(); err != nil {
-				log.Errorf("The %v http probe get method has Failed, err: %v", probe.Name, err)
+				log.Errorf("The %v http probe %v method has Failed, err: %v", probe.Name, request.method, err)
+				return err
+			}
+
+			// validating the response body with the expected criteria
+			if err = validateResponseBody(probe, respBody, rc, resultDetails); err != nil {
+				log.Errorf("The %v http probe %v method has Failed, err: %v", probe.Name, request.method, err)
 				return err
 			}
 			return nil
 		})
 }
 
-// httpPost send the http post request to the given URL
-func httpPost(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails *types.ResultDetails) error {
-	body, err := getHTTPBody(probe.HTTPProbeInputs.Method.Post)
+// validateResponseBody extract the value from the response body and compare it with the specified criteria
+// the extracted value is stored inside the probe artifacts, which can be used by the other probes
+func validateResponseBody(probe types.ProbeAttributes, body []byte, rc int, resultDetails *types.ResultDetails) error {
+	assertion := probe.HTTPProbeInputs.ResponseBody
+	if assertion.JSONPath == "" && assertion.Regex == "" {
+		return nil
+	}
+
+	value, err := extractFromResponseBody(assertion, body)
 	if err != nil {
 		return err
 	}
-	// it will retry for some retry count, in each iterations of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
-			resp, err := client.Post(probe.HTTPProbeInputs.URL, probe.HTTPProbeInputs.Method.Post.ContentType, strings.NewReader(body))
-			if err != nil {
-				return err
-			}
-			code := strconv.Itoa(resp.StatusCode)
-			rc := getAndIncrementRunCount(resultDetails, probe.Name)
 
-			// comparing the response code with the expected criteria
-			if err = cmp.RunCount(rc).
-				FirstValue(code).
-				SecondValue(probe.HTTPProbeInputs.Method.Post.ResponseCode).
-				Criteria(probe.HTTPProbeInputs.Method.Post.Criteria).
-				CompareInt(); err != nil {
-				log.Errorf("The %v http probe post method has Failed, err: %v", probe.Name, err)
-				return err
-			}
-			return nil
-		})
+	if assertion.Comparator.Type != "" {
+		if err := validateResult(assertion.Comparator, value, rc); err != nil {
+			return err
+		}
+	}
+
+	resultDetails.SetProbeArtifact(probe.Name, value)
+	return nil
+}
+
+// extractFromResponseBody extract the value from the response body
+// It will use jsonPath or regex attributes to extract the value
+// if both are provided, it will use jsonPath field
+func extractFromResponseBody(assertion types.ResponseBodyDetails, body []byte) (string, error) {
+	if assertion.JSONPath != "" {
+		return extractJSONPath(assertion.JSONPath, body)
+	}
+
+	re, err := regexp.Compile(assertion.Regex)
+	if err != nil {
+		return "", errors.Errorf("unable to parse the %v regex, err: %v", assertion.Regex, err)
+	}
+	matches := re.FindSubmatch(body)
+	switch len(matches) {
+	case 0:
+		return "", errors.Errorf("no match found for the %v regex in the response body", assertion.Regex)
+	case 1:
+		return strings.TrimSpace(string(matches[0])), nil
+	default:
+		return strings.TrimSpace(string(matches[1])), nil
+	}
 }
 
-// getHTTPBody fetch the http body for the post request
+// extractJSONPath extract the value of the jsonpath expression from the JSON document
+func extractJSONPath(jsonPath string, body []byte) (string, error) {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", errors.Errorf("unable to parse the response body as json, err: %v", err)
+	}
+	value, err := evaluateJSONPath(jsonPath, data)
+	if err != nil {
+		return "", errors.Errorf("unable to find the %v jsonpath in the response body, err: %v", jsonPath, err)
+	}
+	return value, nil
+}
+
+// evaluateJSONPath evaluates the jsonpath expression against the decoded JSON document
+func evaluateJSONPath(jsonPath string, data interface{}) (string, error) {
+	// jsonpath expression can be provided with or without the enclosing braces
+	expression := jsonPath
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	parser := jsonpath.New("jsonPath")
+	if err := parser.Parse(expression); err != nil {
+		return "", errors.Errorf("unable to parse the %v jsonpath, err: %v", jsonPath, err)
+	}
+	var out bytes.Buffer
+	if err := parser.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// getHTTPBody fetch the http body for the request
 // It will use body or bodyPath attributes to get the http request body
 // if both are provided, it will use body field
-func getHTTPBody(httpBody v1alpha1.PostMethod) (string, error) {
+func getHTTPBody(body, bodyPath string) (string, error) {
 
-	if httpBody.Body != "" {
-		return httpBody.Body, nil
+	if body != "" {
+		return body, nil
 	}
 
 	var command string
 
-	if httpBody.BodyPath != "" {
-		command = "cat " + httpBody.BodyPath
+	if bodyPath != "" {
+		command = "cat " + bodyPath
 	} else {
 		return "", errors.Errorf("[Probe]: Any one of body or bodyPath is required")
 	}
@@ -198,7 +371,8 @@ func getHTTPBody(httpBody v1alpha1.PostMethod) (string, error) {
 }
 
 // triggerContinuousHTTPProbe trigger the continuous http probes
-func triggerContinuousHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerContinuousHTTPProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	var err error
 	var isExperimentFailed bool
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
@@ -213,14 +387,10 @@ loop:
 		err = triggerHTTPProbe(probe, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
-			for index := range chaosresult.ProbeDetails {
-				if chaosresult.ProbeDetails[index].Name == probe.Name {
-					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-					log.Errorf("The %v http probe has been Failed, err: %v", probe.Name, err)
-					isExperimentFailed = true
-					break loop
-				}
-			}
+			setProbeError(chaosresult, probe.Name, err)
+			log.Errorf("The %v http probe has been Failed, err: %v", probe.Name, err)
+			isExperimentFailed = true
+			break loop
 		}
 		// waiting for the probe polling interval
 		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -236,8 +406,9 @@ loop:
 }
 
 //preChaosHTTPProbe trigger the http probe for prechaos phase
-func preChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func preChaosHTTPProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch probe.Mode {
 	case "SOT", "Edge":
 
@@ -260,7 +431,7 @@ func preChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PreChaos"); err != nil {
 			return err
 		}
 	case "Continuous":
@@ -279,8 +450,9 @@ func preChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 }
 
 //postChaosHTTPProbe trigger the http probe for postchaos phase
-func postChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func postChaosHTTPProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch probe.Mode {
 	case "EOT", "Edge":
 
@@ -304,14 +476,18 @@ func postChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 			return err
 		}
 	case "Continuous", "OnChaos":
 		// it will check for the error, It will detect the error if any error encountered in probe during chaos
 		err = checkForErrorInContinuousProbe(resultDetails, probe.Name)
+		// it will evaluate the latency SLO over all the requests sent during chaos
+		if latencyErr := validateLatency(resultDetails, probe); err == nil {
+			err = latencyErr
+		}
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 			return err
 		}
 	}
@@ -319,8 +495,9 @@ func postChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 }
 
 // triggerOnChaosHTTPProbe trigger the onchaos http probes
-func triggerOnChaosHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerOnChaosHTTPProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 
+	var err error
 	var isExperimentFailed bool
 	duration := chaosDetails.ChaosDuration
 	// waiting for initial delay
@@ -347,13 +524,9 @@ loop:
 			err = triggerHTTPProbe(probe, chaosresult)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
-				for index := range chaosresult.ProbeDetails {
-					if chaosresult.ProbeDetails[index].Name == probe.Name {
-						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-						isExperimentFailed = true
-						break loop
-					}
-				}
+				setProbeError(chaosresult, probe.Name, err)
+				isExperimentFailed = true
+				break loop
 			}
 
 			// waiting for the probe polling interval
@@ -371,7 +544,7 @@ loop:
 }
 
 //onChaosHTTPProbe trigger the http probe for DuringChaos phase
-func onChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+func onChaosHTTPProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
 
 	switch probe.Mode {
 	case "OnChaos":
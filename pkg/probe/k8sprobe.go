@@ -1,15 +1,14 @@
 package probe
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	"github.com/litmuschaos/litmus-go/pkg/types"
-	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,7 +19,7 @@ import (
 
 // prepareK8sProbe contains the steps to prepare the k8s probe
 // k8s probe can be used to add the probe which needs client-go for command execution, no extra binaries/command
-func prepareK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, phase string, chaosDetails *types.ChaosDetails) error {
+func prepareK8sProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, phase string, chaosDetails *types.ChaosDetails) error {
 	switch strings.ToLower(phase) {
 	case "prechaos":
 		if err := preChaosK8sProbe(probe, resultDetails, clients, chaosDetails); err != nil {
@@ -39,8 +38,9 @@ func prepareK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 }
 
 // triggerK8sProbe run the k8s probe command
-func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+func triggerK8sProbe(probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
 
+	var err error
 	inputs := probe.K8sProbeInputs
 
 	// It parse the templated command and return normal string
@@ -58,9 +58,7 @@ func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 	// it will retry for some retry count, in each iterations of try it contains following things
 	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
 	// for a timeout, it will run the command, if it fails wait for the iterval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
+	return probeRetry(probe).
 		TryWithTimeout(func(attempt uint) error {
 			//defining the gvr for the requested resource
 			gvr := schema.GroupVersionResource{
@@ -88,8 +86,10 @@ func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 				if err != nil {
 					log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
 					return errors.Errorf("unable to list the resources with matching selector, err: %v", err)
-				} else if len(resourceList.Items) == 0 {
-					return errors.Errorf("no resource found with provided selectors")
+				}
+				if err = validateResources(probe, resourceList.Items, resultDetails); err != nil {
+					log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
+					return err
 				}
 			case "absent":
 				resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(inputs.Namespace).List(v1.ListOptions{
@@ -111,8 +111,74 @@ func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 		})
 }
 
+// validateResources validates the listed resources against the jsonPath and count assertions
+// the extracted value is stored inside the probe artifacts, which can be used by the templated inputs of other probes
+func validateResources(probe types.ProbeAttributes, resources []unstructured.Unstructured, resultDetails *types.ResultDetails) error {
+
+	inputs := probe.K8sProbeInputs
+	if inputs.Count.Type == "" && len(resources) == 0 {
+		return errors.Errorf("no resource found with provided selectors")
+	}
+	if inputs.JSONPath == "" && inputs.Count.Type == "" {
+		return nil
+	}
+
+	rc := getAndIncrementRunCount(resultDetails, probe.Name)
+
+	var value string
+	if inputs.Count.Type != "" {
+		// counting the resources, which satisfy the jsonPath and comparator
+		count := 0
+		for _, resource := range resources {
+			if matchResource(inputs, resource) {
+				count++
+			}
+		}
+		value = strconv.Itoa(count)
+		if err := validateResult(inputs.Count, value, rc); err != nil {
+			return errors.Errorf("count of the matched resources doesn't satisfy the criteria, err: %v", err)
+		}
+	} else {
+		// all the resources should satisfy the comparator
+		var values []string
+		for _, resource := range resources {
+			output, err := evaluateJSONPath(inputs.JSONPath, resource.Object)
+			if err != nil {
+				return errors.Errorf("unable to find the %v jsonpath in %v %v, err: %v", inputs.JSONPath, resource.GetKind(), resource.GetName(), err)
+			}
+			if inputs.Comparator.Type != "" {
+				if err := validateResult(inputs.Comparator, output, rc); err != nil {
+					return errors.Errorf("%v %v doesn't satisfy the criteria, err: %v", resource.GetKind(), resource.GetName(), err)
+				}
+			}
+			values = append(values, output)
+		}
+		value = strings.Join(values, ",")
+	}
+
+	resultDetails.SetProbeArtifact(probe.Name, value)
+	return nil
+}
+
+// matchResource checks whether the resource satisfies the jsonPath and comparator
+// the resource is matched if the jsonPath is not provided, or the extracted value is non-empty in absence of comparator
+func matchResource(inputs types.K8sProbeInputs, resource unstructured.Unstructured) bool {
+	if inputs.JSONPath == "" {
+		return true
+	}
+	output, err := evaluateJSONPath(inputs.JSONPath, resource.Object)
+	if err != nil {
+		return false
+	}
+	if inputs.Comparator.Type == "" {
+		return output != ""
+	}
+	return validateResult(inputs.Comparator, output, 0) == nil
+}
+
 // triggerContinuousK8sProbe trigger the continuous k8s probes
-func triggerContinuousK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerContinuousK8sProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	var err error
 	var isExperimentFailed bool
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
@@ -127,14 +193,10 @@ loop:
 		err = triggerK8sProbe(probe, clients, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
-			for index := range chaosresult.ProbeDetails {
-				if chaosresult.ProbeDetails[index].Name == probe.Name {
-					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-					log.Errorf("the %v k8s probe has been Failed, err: %v", probe.Name, err)
-					isExperimentFailed = true
-					break loop
-				}
-			}
+			setProbeError(chaosresult, probe.Name, err)
+			log.Errorf("the %v k8s probe has been Failed, err: %v", probe.Name, err)
+			isExperimentFailed = true
+			break loop
 		}
 		// waiting for the probe polling interval
 		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -150,21 +212,21 @@ loop:
 }
 
 // createResource creates the resource from the data provided inside data field
-func createResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
+func createResource(probe types.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
 	decUnstructured := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
 	// Decode YAML manifest into unstructured.Unstructured
 	data := &unstructured.Unstructured{}
-	_, _, err = decUnstructured.Decode([]byte(probe.Data), nil, data)
+	_, _, err := decUnstructured.Decode([]byte(probe.Data), nil, data)
 	if err != nil {
 		return err
 	}
-	_, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).Create(data, v1.CreateOptions{})
+	_, err = clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).Create(data, v1.CreateOptions{})
 
 	return err
 }
 
 // deleteResource deletes the resource with matching label & field selector
-func deleteResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
+func deleteResource(probe types.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
 	resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).List(v1.ListOptions{
 		FieldSelector: probe.K8sProbeInputs.FieldSelector,
 		LabelSelector: probe.K8sProbeInputs.LabelSelector,
@@ -184,8 +246,9 @@ func deleteResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResou
 }
 
 //preChaosK8sProbe trigger the k8s probe for prechaos phase
-func preChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func preChaosK8sProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch strings.ToLower(probe.Mode) {
 	case "sot", "edge":
 
@@ -207,7 +270,7 @@ func preChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PreChaos"); err != nil {
 			return err
 		}
 	case "continuous":
@@ -226,8 +289,9 @@ func preChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 }
 
 //postChaosK8sProbe trigger the k8s probe for postchaos phase
-func postChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func postChaosK8sProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch strings.ToLower(probe.Mode) {
 	case "eot", "edge":
 
@@ -249,14 +313,14 @@ func postChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 			return err
 		}
 	case "continuous", "onchaos":
 		// it will check for the error, It will detect the error if any error encountered in probe during chaos
 		err = checkForErrorInContinuousProbe(resultDetails, probe.Name)
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 			return err
 		}
 	}
@@ -264,7 +328,7 @@ func postChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 }
 
 //onChaosK8sProbe trigger the k8s probe for DuringChaos phase
-func onChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+func onChaosK8sProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
 
 	switch strings.ToLower(probe.Mode) {
 	case "onchaos":
@@ -283,8 +347,9 @@ func onChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 }
 
 // triggerOnChaosK8sProbe trigger the onchaos k8s probes
-func triggerOnChaosK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerOnChaosK8sProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 
+	var err error
 	var isExperimentFailed bool
 	duration := chaosDetails.ChaosDuration
 	// waiting for initial delay
@@ -311,14 +376,10 @@ loop:
 			err = triggerK8sProbe(probe, clients, chaosresult)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
-				for index := range chaosresult.ProbeDetails {
-					if chaosresult.ProbeDetails[index].Name == probe.Name {
-						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-						log.Errorf("The %v k8s probe has been Failed, err: %v", probe.Name, err)
-						isExperimentFailed = true
-						break loop
-					}
-				}
+				setProbeError(chaosresult, probe.Name, err)
+				log.Errorf("The %v k8s probe has been Failed, err: %v", probe.Name, err)
+				isExperimentFailed = true
+				break loop
 			}
 			// waiting for the probe polling interval
 			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -0,0 +1,100 @@
+package probe
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultPercentiles contains the percentiles which are always reported inside the probe status
+var defaultPercentiles = []string{"p50", "p90", "p99"}
+
+// recordLatency record the latency of the request for the continuous and onchaos probes
+func recordLatency(resultDetails *types.ResultDetails, probe types.ProbeAttributes, latency time.Duration) {
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+		resultDetails.UpdateProbeDetails(probe.Name, func(probeDetails *types.ProbeDetails) {
+			probeDetails.LatencySamples = append(probeDetails.LatencySamples, latency)
+		})
+	}
+}
+
+// validateLatency compute the percentiles of the recorded latency samples and compare them with the thresholds
+// the percentiles and the sample count are reported inside the probe status
+func validateLatency(resultDetails *types.ResultDetails, probe types.ProbeAttributes) error {
+	var samples []time.Duration
+	if found := resultDetails.UpdateProbeDetails(probe.Name, func(probeDetails *types.ProbeDetails) {
+		samples = append(samples, probeDetails.LatencySamples...)
+	}); !found {
+		return nil
+	}
+
+	thresholds := probe.HTTPProbeInputs.Latency.Thresholds
+	if len(samples) == 0 {
+		if len(thresholds) != 0 {
+			return errors.Errorf("no latency samples recorded for the %v probe", probe.Name)
+		}
+		return nil
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	status := map[string]string{
+		"LatencySamples": strconv.Itoa(len(samples)),
+	}
+	for _, percentile := range defaultPercentiles {
+		value, _ := getPercentile(samples, percentile)
+		status["Latency"+strings.ToUpper(percentile)] = value.String()
+	}
+
+	var failed []string
+	for _, threshold := range thresholds {
+		value, err := getPercentile(samples, threshold.Percentile)
+		if err != nil {
+			return err
+		}
+		status["Latency"+strings.ToUpper(threshold.Percentile)] = value.String()
+		limit := time.Duration(threshold.Threshold) * time.Millisecond
+		if value >= limit {
+			failed = append(failed, threshold.Percentile+": "+value.String()+" >= "+limit.String())
+		}
+	}
+
+	resultDetails.UpdateProbeDetails(probe.Name, func(probeDetails *types.ProbeDetails) {
+		for key, value := range status {
+			probeDetails.Status[key] = value
+		}
+	})
+
+	log.InfoWithValues("[Probe]: The latency of the "+probe.Name+" probe is as follows", logrus.Fields{
+		"Samples": len(samples),
+		"P50":     status["LatencyP50"],
+		"P90":     status["LatencyP90"],
+		"P99":     status["LatencyP99"],
+	})
+
+	if len(failed) != 0 {
+		return errors.Errorf("latency SLO of the %v probe is not met, %v", probe.Name, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// getPercentile returns the percentile of the sorted samples, using the nearest rank method
+// percentile can be provided as p99, p99.9 or 99
+func getPercentile(samples []time.Duration, percentile string) (time.Duration, error) {
+	rank, err := strconv.ParseFloat(strings.TrimPrefix(strings.ToLower(percentile), "p"), 64)
+	if err != nil || rank <= 0 || rank > 100 {
+		return 0, errors.Errorf("invalid latency percentile: '%v', it should be in the range of (0, 100]", percentile)
+	}
+	index := int(math.Ceil(rank/100*float64(len(samples)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return samples[index].Round(time.Microsecond), nil
+}
@@ -2,72 +2,118 @@ package probe
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyokomi/emoji"
 	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/abort"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-var err error
+// probeCtx is the context of the probes, it is cancelled when the experiment is aborted
+// or any probe stops the chaosengine on failure, so that the pending retries are interrupted
+var probeCtx, stopProbes = context.WithCancel(abort.Context())
+
+const (
+	// probeBackoffFactor is the factor by which the interval between the probe retries grows, upto the probe timeout
+	probeBackoffFactor = 2
+	// probeJitter is the fraction by which the interval between the probe retries is randomized
+	probeJitter = 0.1
+)
+
+// probeRetry returns the retry model of the probe, derived from its run properties
+// the interval grows exponentially with jitter, and the retries are stopped if the api rejects the probe permanently
+func probeRetry(probe types.ProbeAttributes) *retry.Model {
+	return retry.Context(probeCtx).
+		Times(uint(probe.RunProperties.Retry)).
+		Timeout(int64(probe.RunProperties.ProbeTimeout)).
+		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
+		Backoff(probeBackoffFactor, time.Duration(probe.RunProperties.ProbeTimeout)*time.Second).
+		Jitter(probeJitter).
+		StopIf(retry.IsPermanentAPIError)
+}
+
+// probeStartTime contains the start time of the probe executions, used to derive the probe duration
+var (
+	probeStartTimeMu sync.Mutex
+	probeStartTime   = map[string]time.Time{}
+)
+
+// chaosEngineGVR is the group version resource of the chaosengine
+var chaosEngineGVR = schema.GroupVersionResource{
+	Group:    "litmuschaos.io",
+	Version:  "v1alpha1",
+	Resource: "chaosengines",
+}
 
 // RunProbes contains the steps to trigger the probes
-// It contains steps to trigger all three probes: k8sprobe, httpprobe, cmdprobe
+// It contains steps to trigger all the probes: k8sprobe, httpprobe, cmdprobe, promprobe, grpcprobe, watchprobe
 func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
 
-	// get the probes details from the chaosengine
-	probes, err := getProbesFromEngine(chaosDetails, clients)
+	// update the phase inside the default log fields
+	log.SetPhase(phase)
+
+	// get the probes details from the chaosengine or the inline probes
+	probes, err := getProbes(chaosDetails, clients)
 	if err != nil {
 		return err
 	}
 
+	// validate the names and dependencies of the probes
+	if err := validateProbes(probes); err != nil {
+		return err
+	}
+
 	switch strings.ToLower(phase) {
 	//execute probes for the prechaos & duringchaos phase
 	case "prechaos", "duringchaos":
-		for _, probe := range probes {
-			if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
-				return err
-			}
-		}
+		return executeProbes(probes, chaosDetails, clients, resultDetails, phase)
 	default:
 		// execute the probes for the postchaos phase
 		// it first evaluate the onchaos and continuous modes then it evaluates the other modes
 		// as onchaos and continuous probes are already completed
-		var probeError []error
+		var continuousProbes, otherProbes []types.ProbeAttributes
 		for _, probe := range probes {
-			// evaluate continuous and onchaos probes
 			switch strings.ToLower(probe.Mode) {
 			case "onchaos", "continuous":
-				if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
-					probeError = append(probeError, err)
-				}
+				continuousProbes = append(continuousProbes, probe)
+			default:
+				otherProbes = append(otherProbes, probe)
 			}
 		}
-		if len(probeError) != 0 {
-			return errors.Errorf("probes failed, err: %v", probeError)
+		// evaluate continuous and onchaos probes
+		if err := executeProbes(continuousProbes, chaosDetails, clients, resultDetails, phase); err != nil {
+			return err
 		}
 		// executes the eot and edge modes
-		for _, probe := range probes {
-			if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
-				return err
-			}
-		}
+		return executeProbes(otherProbes, chaosDetails, clients, resultDetails, phase)
 	}
-	return nil
 }
 
+// failedProbeStatus is the status of the failed probe in the chaosresult
+const failedProbeStatus = "Better Luck Next Time"
+
 //setProbeVerdict mark the verdict of the probe in the chaosresult as passed
 // on the basis of phase(pre/post chaos)
-func setProbeVerdict(resultDetails *types.ResultDetails, probe v1alpha1.ProbeAttributes, verdict, phase string) {
+func setProbeVerdict(resultDetails *types.ResultDetails, probe types.ProbeAttributes, verdict, phase string) {
+
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
 
 	for index, probes := range resultDetails.ProbeDetails {
 		if probes.Name == probe.Name && probes.Type == probe.Type {
@@ -76,22 +122,27 @@ func setProbeVerdict(resultDetails *types.ResultDetails, probe v1alpha1.ProbeAtt
 				if verdict == "Passed" {
 					resultDetails.ProbeDetails[index].Status[phase] = verdict + emoji.Sprint(" :thumbsup:")
 				} else {
-					resultDetails.ProbeDetails[index].Status[phase] = "Better Luck Next Time" + emoji.Sprint(" :thumbsdown:")
+					resultDetails.ProbeDetails[index].Status[phase] = failedProbeStatus + emoji.Sprint(" :thumbsdown:")
 				}
 			case "continuous", "onchaos":
 				if verdict == "Passed" {
 					resultDetails.ProbeDetails[index].Status[probe.Mode] = verdict + emoji.Sprint(" :thumbsup:")
 				} else {
-					resultDetails.ProbeDetails[index].Status[probe.Mode] = "Better Luck Next Time" + emoji.Sprint(" :thumbsdown:")
+					resultDetails.ProbeDetails[index].Status[probe.Mode] = failedProbeStatus + emoji.Sprint(" :thumbsdown:")
 				}
 			}
-			resultDetails.ProbeDetails[index].Phase = verdict
+			// the failed probe remains failed, even if it passes in the later phases
+			if resultDetails.ProbeDetails[index].Phase != "Failed" {
+				resultDetails.ProbeDetails[index].Phase = verdict
+			}
 		}
 	}
 }
 
 //SetProbeVerdictAfterFailure mark the verdict of all the failed/unrun probes as failed
 func SetProbeVerdictAfterFailure(resultDetails *types.ResultDetails) {
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
 	for index := range resultDetails.ProbeDetails {
 		for _, phase := range []string{"PreChaos", "PostChaos", "Continuous", "OnChaos"} {
 			if resultDetails.ProbeDetails[index].Status[phase] == "Awaited" {
@@ -101,23 +152,47 @@ func SetProbeVerdictAfterFailure(resultDetails *types.ResultDetails) {
 	}
 }
 
+// getProbes returns the inline probes, if provided, otherwise it fetch the probes from the chaosengine
+func getProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets) ([]types.ProbeAttributes, error) {
+	if len(chaosDetails.Probes) != 0 {
+		return chaosDetails.Probes, nil
+	}
+	return getProbesFromEngine(chaosDetails, clients)
+}
+
 // getProbesFromEngine fetch the details of the probes from the chaosengines
-func getProbesFromEngine(chaosDetails *types.ChaosDetails, clients clients.ClientSets) ([]v1alpha1.ProbeAttributes, error) {
+// the chaosengine is fetched as unstructured object so that the probe inputs,
+// which are not present inside the chaos-operator api types, are preserved
+func getProbesFromEngine(chaosDetails *types.ChaosDetails, clients clients.ClientSets) ([]types.ProbeAttributes, error) {
 
-	var Probes []v1alpha1.ProbeAttributes
+	var Probes []types.ProbeAttributes
 
 	if err := retry.
+		Context(abort.Context()).
 		Times(uint(chaosDetails.Timeout / chaosDetails.Delay)).
 		Wait(time.Duration(chaosDetails.Delay) * time.Second).
+		StopIf(retry.IsPermanentAPIError).
 		Try(func(attempt uint) error {
-			engine, err := clients.LitmusClient.ChaosEngines(chaosDetails.ChaosNamespace).Get(chaosDetails.EngineName, v1.GetOptions{})
+			engine, err := clients.DynamicClient.Resource(chaosEngineGVR).Namespace(chaosDetails.ChaosNamespace).Get(chaosDetails.EngineName, v1.GetOptions{})
 			if err != nil {
-				return fmt.Errorf("unable to Get the chaosengine, err: %v", err)
+				return errors.Wrapf(err, "unable to Get the chaosengine")
+			}
+			experiments, _, err := unstructured.NestedSlice(engine.Object, "spec", "experiments")
+			if err != nil {
+				return fmt.Errorf("unable to parse the experiments from chaosengine, err: %v", err)
 			}
 			// get all the probes defined inside chaosengine for the corresponding experiment
-			for _, experiment := range engine.Spec.Experiments {
-				if experiment.Name == chaosDetails.ExperimentName {
-					Probes = experiment.Spec.Probe
+			for _, experiment := range experiments {
+				experimentSpec, ok := experiment.(map[string]interface{})
+				if !ok || experimentSpec["name"] != chaosDetails.ExperimentName {
+					continue
+				}
+				probes, _, err := unstructured.NestedSlice(experimentSpec, "spec", "probe")
+				if err != nil {
+					return fmt.Errorf("unable to parse the probes from chaosengine, err: %v", err)
+				}
+				if Probes, err = decodeProbes(probes); err != nil {
+					return err
 				}
 			}
 			return nil
@@ -128,13 +203,26 @@ func getProbesFromEngine(chaosDetails *types.ChaosDetails, clients clients.Clien
 	return Probes, nil
 }
 
+// decodeProbes convert the unstructured probe definitions into the probe attributes
+func decodeProbes(probes []interface{}) ([]types.ProbeAttributes, error) {
+	data, err := json.Marshal(probes)
+	if err != nil {
+		return nil, errors.Errorf("unable to marshal the probes, err: %v", err)
+	}
+	var probeAttributes []types.ProbeAttributes
+	if err := json.Unmarshal(data, &probeAttributes); err != nil {
+		return nil, errors.Errorf("unable to decode the probes, err: %v", err)
+	}
+	return probeAttributes, nil
+}
+
 // InitializeProbesInChaosResultDetails set the probe inside chaos result
 // it fetch the probe details from the chaosengine and set into the chaosresult
 func InitializeProbesInChaosResultDetails(chaosDetails *types.ChaosDetails, clients clients.ClientSets, chaosresult *types.ResultDetails) error {
 
 	probeDetails := []types.ProbeDetails{}
-	// get the probes from the chaosengine
-	probes, err := getProbesFromEngine(chaosDetails, clients)
+	// get the probes from the chaosengine or the inline probes
+	probes, err := getProbes(chaosDetails, clients)
 	if err != nil {
 		return err
 	}
@@ -146,17 +234,36 @@ func InitializeProbesInChaosResultDetails(chaosDetails *types.ChaosDetails, clie
 		tempProbe.Type = probe.Type
 		tempProbe.Phase = "N/A"
 		tempProbe.RunCount = 0
+		tempProbe.Weight = getProbeWeight(probe)
+		tempProbe.Critical = isCriticalProbe(probe)
 		setProbeInitialStatus(&tempProbe, probe.Mode)
 		probeDetails = append(probeDetails, tempProbe)
 	}
 
+	chaosresult.Lock()
+	defer chaosresult.Unlock()
 	chaosresult.ProbeDetails = probeDetails
 	chaosresult.ProbeArtifacts = map[string]types.ProbeArtifact{}
 	return nil
 }
 
+// getProbeWeight returns the weight of the probe in the resilience score, it defaults to 1
+func getProbeWeight(probe types.ProbeAttributes) int {
+	if probe.Weight <= 0 {
+		return 1
+	}
+	return probe.Weight
+}
+
+// isCriticalProbe checks whether the failure of the probe should fail the experiment, it defaults to true
+func isCriticalProbe(probe types.ProbeAttributes) bool {
+	return probe.Critical == nil || *probe.Critical
+}
+
 //getAndIncrementRunCount return the run count for the specified probe
 func getAndIncrementRunCount(resultDetails *types.ResultDetails, probeName string) int {
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
 	for index, probe := range resultDetails.ProbeDetails {
 		if probeName == probe.Name {
 			resultDetails.ProbeDetails[index].RunCount++
@@ -197,6 +304,9 @@ func setProbeInitialStatus(probeDetails *types.ProbeDetails, mode string) {
 // which will used in the continuous cmd probe, run_id is used as suffix in the external pod name
 func getRunIDFromProbe(resultDetails *types.ResultDetails, probeName, probeType string) string {
 
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+
 	for _, probe := range resultDetails.ProbeDetails {
 		if probe.Name == probeName && probe.Type == probeType {
 			return probe.RunID
@@ -209,6 +319,9 @@ func getRunIDFromProbe(resultDetails *types.ResultDetails, probeName, probeType
 // which will used in the continuous cmd probe, run_id is used as suffix in the external pod name
 func setRunIDForProbe(resultDetails *types.ResultDetails, probeName, probeType, runid string) {
 
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+
 	for index, probe := range resultDetails.ProbeDetails {
 		if probe.Name == probeName && probe.Type == probeType {
 			resultDetails.ProbeDetails[index].RunID = runid
@@ -218,7 +331,7 @@ func setRunIDForProbe(resultDetails *types.ResultDetails, probeName, probeType,
 }
 
 // markedVerdictInEnd add the probe status in the chaosresult
-func markedVerdictInEnd(err error, resultDetails *types.ResultDetails, probe v1alpha1.ProbeAttributes, phase string) error {
+func markedVerdictInEnd(err error, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, probe types.ProbeAttributes, phase string) error {
 	probeVerdict := "Passed"
 	if err != nil {
 		probeVerdict = "Failed"
@@ -232,19 +345,19 @@ func markedVerdictInEnd(err error, resultDetails *types.ResultDetails, probe v1a
 			"ProbeInstance": phase,
 			"ProbeStatus":   probeVerdict,
 		})
-		// counting the passed probes count to generate the score and mark the verdict as passed
+		// marking the final verdict of the probe as passed, which is used to derive the resilience score
 		// for edge, probe is marked as Passed if passed in both pre/post chaos checks
 		switch strings.ToLower(probe.Mode) {
 		case "edge", "continuous":
 			if phase != "PreChaos" {
-				resultDetails.PassedProbeCount++
+				resultDetails.MarkProbePassed(probe.Name)
 			}
 		case "onchaos":
 			if phase != "DuringChaos" {
-				resultDetails.PassedProbeCount++
+				resultDetails.MarkProbePassed(probe.Name)
 			}
 		default:
-			resultDetails.PassedProbeCount++
+			resultDetails.MarkProbePassed(probe.Name)
 		}
 	default:
 		log.ErrorWithValues("[Probe]: "+probe.Name+" probe has been Failed "+emoji.Sprint(":cry:"), logrus.Fields{
@@ -256,6 +369,7 @@ func markedVerdictInEnd(err error, resultDetails *types.ResultDetails, probe v1a
 	}
 
 	setProbeVerdict(resultDetails, probe, probeVerdict, phase)
+	metrics.RecordProbe(chaosDetails, probe.Name, probe.Type, phase, probeVerdict, getProbeDuration(probe.Name))
 	if !probe.RunProperties.StopOnFailure {
 		return nil
 	}
@@ -265,6 +379,9 @@ func markedVerdictInEnd(err error, resultDetails *types.ResultDetails, probe v1a
 //CheckForErrorInContinuousProbe check for the error in the continuous probes
 func checkForErrorInContinuousProbe(resultDetails *types.ResultDetails, probeName string) error {
 
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+
 	for index, probe := range resultDetails.ProbeDetails {
 		if probe.Name == probeName {
 			return resultDetails.ProbeDetails[index].IsProbeFailedWithError
@@ -273,11 +390,19 @@ func checkForErrorInContinuousProbe(resultDetails *types.ResultDetails, probeNam
 	return nil
 }
 
+// setProbeError record the error inside the probeDetails, it is used by the continuous and onchaos probes
+// to report the failures to the postchaos phase
+func setProbeError(resultDetails *types.ResultDetails, probeName string, err error) {
+	resultDetails.UpdateProbeDetails(probeName, func(probeDetails *types.ProbeDetails) {
+		probeDetails.IsProbeFailedWithError = err
+	})
+}
+
 // ParseCommand parse the templated command and replace the templated value by actual value
 // if command doesn't have template, it will return the same command
 func parseCommand(templatedCommand string, resultDetails *types.ResultDetails) (string, error) {
 
-	register := resultDetails.ProbeArtifacts
+	register := resultDetails.GetProbeArtifacts()
 
 	t := template.Must(template.New("t1").Parse(templatedCommand))
 
@@ -291,11 +416,17 @@ func parseCommand(templatedCommand string, resultDetails *types.ResultDetails) (
 }
 
 // stopChaosEngine update the probe status and patch the chaosengine to stop state
-func stopChaosEngine(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+func stopChaosEngine(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
 	// it will check for the error, It will detect the error if any error encountered in probe during chaos
-	err = checkForErrorInContinuousProbe(chaosresult, probe.Name)
+	err := checkForErrorInContinuousProbe(chaosresult, probe.Name)
 	// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-	markedVerdictInEnd(err, chaosresult, probe, "PostChaos")
+	markedVerdictInEnd(err, chaosresult, chaosDetails, probe, "PostChaos")
+	// interrupt the pending retries of the other probes
+	stopProbes()
+	// there is no chaosengine to stop, if the experiment is running in the standalone mode
+	if chaosDetails.EngineName == "" {
+		return nil
+	}
 	//patch chaosengine's state to stop
 	engine, err := clients.LitmusClient.ChaosEngines(chaosDetails.ChaosNamespace).Get(chaosDetails.EngineName, v1.GetOptions{})
 	if err != nil {
@@ -306,27 +437,64 @@ func stopChaosEngine(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 	return err
 }
 
+// setProbeStartTime records the start time of the probe execution
+// for continuous and onchaos probes, the start time of the first execution is retained
+// so that the duration covers the entire window monitored by the probe
+func setProbeStartTime(probe types.ProbeAttributes) {
+	probeStartTimeMu.Lock()
+	defer probeStartTimeMu.Unlock()
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+		if _, ok := probeStartTime[probe.Name]; ok {
+			return
+		}
+	}
+	probeStartTime[probe.Name] = time.Now()
+}
+
+// getProbeDuration returns the time elapsed since the start of the probe execution
+func getProbeDuration(probeName string) time.Duration {
+	probeStartTimeMu.Lock()
+	defer probeStartTimeMu.Unlock()
+	startTime, ok := probeStartTime[probeName]
+	if !ok {
+		return 0
+	}
+	return time.Since(startTime)
+}
+
 // execute contains steps to execute & evaluate probes in different modes at different phases
-func execute(probe v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string) error {
+func execute(probe types.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string) error {
+	setProbeStartTime(probe)
 	switch strings.ToLower(probe.Type) {
 	case "k8sprobe":
 		// it contains steps to prepare the k8s probe
-		if err = prepareK8sProbe(probe, resultDetails, clients, phase, chaosDetails); err != nil {
+		if err := prepareK8sProbe(probe, resultDetails, clients, phase, chaosDetails); err != nil {
 			return errors.Errorf("probes failed, err: %v", err)
 		}
 	case "cmdprobe":
 		// it contains steps to prepare cmd probe
-		if err = prepareCmdProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+		if err := prepareCmdProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
 			return errors.Errorf("probes failed, err: %v", err)
 		}
 	case "httpprobe":
 		// it contains steps to prepare http probe
-		if err = prepareHTTPProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+		if err := prepareHTTPProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
 			return errors.Errorf("probes failed, err: %v", err)
 		}
 	case "promprobe":
 		// it contains steps to prepare prom probe
-		if err = preparePromProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+		if err := preparePromProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return errors.Errorf("probes failed, err: %v", err)
+		}
+	case "grpcprobe":
+		// it contains steps to prepare grpc probe
+		if err := prepareGRPCProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return errors.Errorf("probes failed, err: %v", err)
+		}
+	case "watchprobe":
+		// it contains steps to prepare watch probe
+		if err := prepareWatchProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
 			return errors.Errorf("probes failed, err: %v", err)
 		}
 	default:
@@ -1,26 +1,25 @@
 package probe
 
 import (
-	"bytes"
-	"fmt"
-	"os/exec"
+	"io/ioutil"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
+	"github.com/litmuschaos/litmus-go/pkg/probe/prometheus"
 	"github.com/litmuschaos/litmus-go/pkg/types"
-	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 // preparePromProbe contains the steps to prepare the prometheus probe
 // which compares the metrics output exposed at the given endpoint
-func preparePromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+func preparePromProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
 
 	switch strings.ToLower(phase) {
 	case "prechaos":
@@ -42,8 +41,9 @@ func preparePromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets
 }
 
 //preChaosPromProbe trigger the prometheus probe for prechaos phase
-func preChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func preChaosPromProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch strings.ToLower(probe.Mode) {
 	case "sot", "edge":
 
@@ -53,6 +53,7 @@ func preChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			"Query":          probe.PromProbeInputs.Query,
 			"Endpoint":       probe.PromProbeInputs.Endpoint,
 			"Comparator":     probe.PromProbeInputs.Comparator,
+			"Range":          probe.PromProbeInputs.Range,
 			"Run Properties": probe.RunProperties,
 			"Mode":           probe.Mode,
 			"Phase":          "PreChaos",
@@ -65,11 +66,11 @@ func preChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 		}
 
 		// triggering the prom probe and storing the output into the out buffer
-		err = triggerPromProbe(probe, resultDetails)
+		err = triggerPromProbe(probe, resultDetails, chaosDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PreChaos"); err != nil {
 			return err
 		}
 
@@ -81,6 +82,7 @@ func preChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			"Query":          probe.PromProbeInputs.Query,
 			"Endpoint":       probe.PromProbeInputs.Endpoint,
 			"Comparator":     probe.PromProbeInputs.Comparator,
+			"Range":          probe.PromProbeInputs.Range,
 			"Run Properties": probe.RunProperties,
 			"Mode":           probe.Mode,
 			"Phase":          "PreChaos",
@@ -94,8 +96,9 @@ func preChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 }
 
 //postChaosPromProbe trigger the prometheus probe for postchaos phase
-func postChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func postChaosPromProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	var err error
 	switch strings.ToLower(probe.Mode) {
 	case "eot", "edge":
 
@@ -105,6 +108,7 @@ func postChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 			"Query":          probe.PromProbeInputs.Query,
 			"Endpoint":       probe.PromProbeInputs.Endpoint,
 			"Comparator":     probe.PromProbeInputs.Comparator,
+			"Range":          probe.PromProbeInputs.Range,
 			"Run Properties": probe.RunProperties,
 			"Mode":           probe.Mode,
 			"Phase":          "PostChaos",
@@ -117,11 +121,11 @@ func postChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 		}
 
 		// triggering the prom probe and storing the output into the out buffer
-		err = triggerPromProbe(probe, resultDetails)
+		err = triggerPromProbe(probe, resultDetails, chaosDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 			return err
 		}
 
@@ -131,7 +135,7 @@ func postChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 		err = checkForErrorInContinuousProbe(resultDetails, probe.Name)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
 			return err
 		}
 
@@ -140,7 +144,7 @@ func postChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 }
 
 //onChaosPromProbe trigger the prom probe for DuringChaos phase
-func onChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func onChaosPromProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	switch strings.ToLower(probe.Mode) {
 	case "onchaos":
@@ -151,6 +155,7 @@ func onChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			"Query":          probe.PromProbeInputs.Query,
 			"Endpoint":       probe.PromProbeInputs.Endpoint,
 			"Comparator":     probe.PromProbeInputs.Comparator,
+			"Range":          probe.PromProbeInputs.Range,
 			"Run Properties": probe.RunProperties,
 			"Mode":           probe.Mode,
 			"Phase":          "DuringChaos",
@@ -162,39 +167,31 @@ func onChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 	return nil
 }
 
-// triggerPromProbe trigger the prometheus probe inside the external pod
-func triggerPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+// triggerPromProbe trigger the prometheus probe
+// it queries the prometheus http api and compares the derived value with the expected criteria
+func triggerPromProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
 
-	// running the prom probe command and matching the output
+	// It will use query or queryPath to get the prometheus metrics
+	// if both are provided, it will use query
+	query, err := getPromQuery(probe.PromProbeInputs)
+	if err != nil {
+		return err
+	}
+
+	client, err := getPromClient(probe)
+	if err != nil {
+		return err
+	}
+
+	// running the prom probe query and matching the output
 	// it will retry for some retry count, in each iterations of try it contains following things
 	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
+	// for a timeout, it will run the query, if it fails wait for the interval and again execute the query until timeout expires
+	return probeRetry(probe).
 		TryWithTimeout(func(attempt uint) error {
-			var command string
-			// It will use query or queryPath to get the prometheus metrics
-			// if both are provided, it will use query
-			if probe.PromProbeInputs.Query != "" {
-				command = "promql --host " + probe.PromProbeInputs.Endpoint + " \"" + probe.PromProbeInputs.Query + "\"" + " --output csv"
-			} else if probe.PromProbeInputs.QueryPath != "" {
-				command = "promql --host " + probe.PromProbeInputs.Endpoint + " \"$(cat " + probe.PromProbeInputs.QueryPath + ")\"" + " --output csv"
-			} else {
-				return errors.Errorf("[Probe]: Any one of query or queryPath is required")
-			}
-
-			var out, errOut bytes.Buffer
-			// run the inline command probe
-			cmd := exec.Command("/bin/sh", "-c", command)
-			cmd.Stdout = &out
-			cmd.Stderr = &errOut
-			if err := cmd.Run(); err != nil {
-				return fmt.Errorf("unable to run command, err: %v; error output: %v", err, errOut.String())
-			}
 
-			// extract the values from the metrics
-			value, err := extractValueFromMetrics(strings.TrimSpace(out.String()))
+			// extract the value from the metrics
+			value, err := getPromValue(client, query, probe.PromProbeInputs.Range, chaosDetails.ChaosDuration)
 			if err != nil {
 				return err
 			}
@@ -213,9 +210,94 @@ func triggerPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		})
 }
 
+// getPromQuery fetch the prometheus query from the query or queryPath inputs
+func getPromQuery(inputs types.PromProbeInputs) (string, error) {
+	switch {
+	case inputs.Query != "":
+		return inputs.Query, nil
+	case inputs.QueryPath != "":
+		query, err := ioutil.ReadFile(inputs.QueryPath)
+		if err != nil {
+			return "", errors.Errorf("unable to read the query from %v, err: %v", inputs.QueryPath, err)
+		}
+		return strings.TrimSpace(string(query)), nil
+	default:
+		return "", errors.Errorf("[Probe]: Any one of query or queryPath is required")
+	}
+}
+
+// getPromClient returns the prometheus client with the auth and tls details of the probe
+func getPromClient(probe types.ProbeAttributes) (*prometheus.Client, error) {
+	httpClient, err := getHTTPClient(probe.PromProbeInputs.TLS, time.Duration(probe.RunProperties.ProbeTimeout)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	authHeader, err := getAuthorizationHeader(probe.PromProbeInputs.Auth)
+	if err != nil {
+		return nil, err
+	}
+	if authHeader != "" {
+		headers.Set("Authorization", authHeader)
+	}
+	return prometheus.NewClient(probe.PromProbeInputs.Endpoint, httpClient, headers), nil
+}
+
+// getPromValue evaluates the query and returns the derived value of the metrics
+// it runs the range query over the chaos window, if aggregation is provided
+// otherwise it runs the instant query
+func getPromValue(client *prometheus.Client, query string, rangeDetails types.PromRangeDetails, chaosDuration int) (string, error) {
+
+	if rangeDetails.Aggregation == "" {
+		samples, err := client.Query(query, time.Now())
+		if err != nil {
+			return "", err
+		}
+		// output should contains exact one metrics entry
+		// erroring out the cases where it contains more or less entries
+		if len(samples) != 1 {
+			return "", errors.Errorf("metrics should contain exactly one entry, found %v entries", len(samples))
+		}
+		return strconv.FormatFloat(samples[0].Value, 'f', -1, 64), nil
+	}
+
+	// the window defaults to the chaos duration
+	window := rangeDetails.Window
+	if window == 0 {
+		window = chaosDuration
+	}
+	if window <= 0 {
+		return "", errors.Errorf("[Probe]: range window should be greater than zero")
+	}
+	// the step defaults to the hundredth part of the window, with a minimum of a second
+	step := time.Duration(rangeDetails.Step) * time.Second
+	if step == 0 {
+		step = time.Duration(math.Maximum(1, window/100)) * time.Second
+	}
+
+	end := time.Now()
+	series, err := client.QueryRange(query, end.Add(-time.Duration(window)*time.Second), end, step)
+	if err != nil {
+		return "", err
+	}
+	// output should contains exact one time series
+	// erroring out the cases where it contains more or less series
+	if len(series) != 1 {
+		return "", errors.Errorf("metrics should contain exactly one series, found %v series", len(series))
+	}
+
+	value, err := prometheus.Aggregate(series[0].Samples, rangeDetails.Aggregation)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64), nil
+}
+
 // triggerContinuousPromProbe trigger the continuous prometheus probe
-func triggerContinuousPromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerContinuousPromProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 
+	var err error
 	var isExperimentFailed bool
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
@@ -227,17 +309,13 @@ func triggerContinuousPromProbe(probe v1alpha1.ProbeAttributes, clients clients.
 	// it marked the error for the probes, if any
 loop:
 	for {
-		err = triggerPromProbe(probe, chaosresult)
+		err = triggerPromProbe(probe, chaosresult, chaosDetails)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
-			for index := range chaosresult.ProbeDetails {
-				if chaosresult.ProbeDetails[index].Name == probe.Name {
-					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-					log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
-					isExperimentFailed = true
-					break loop
-				}
-			}
+			setProbeError(chaosresult, probe.Name, err)
+			log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
+			isExperimentFailed = true
+			break loop
 		}
 		// waiting for the probe polling interval
 		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -253,8 +331,9 @@ loop:
 }
 
 // triggerOnChaosPromProbe trigger the onchaos prom probe
-func triggerOnChaosPromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+func triggerOnChaosPromProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 
+	var err error
 	var isExperimentFailed bool
 	duration := chaosDetails.ChaosDuration
 	// waiting for initial delay
@@ -279,15 +358,11 @@ loop:
 			break loop
 		default:
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
-			if err = triggerPromProbe(probe, chaosresult); err != nil {
-				for index := range chaosresult.ProbeDetails {
-					if chaosresult.ProbeDetails[index].Name == probe.Name {
-						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
-						log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
-						isExperimentFailed = true
-						break loop
-					}
-				}
+			if err = triggerPromProbe(probe, chaosresult, chaosDetails); err != nil {
+				setProbeError(chaosresult, probe.Name, err)
+				log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
+				isExperimentFailed = true
+				break loop
 			}
 			// waiting for the probe polling interval
 			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
@@ -302,38 +377,3 @@ loop:
 		}
 	}
 }
-
-// extractValueFromMetrics extract the value field from the prometheus metrix
-func extractValueFromMetrics(metrics string) (string, error) {
-
-	// spliting the metrics based on newline as metrics may have multiple entries
-	rows := strings.Split(metrics, "\n")
-
-	// output should contains exact one metrics entry along with header
-	// erroring out the cases where it contains more or less entries
-	if len(rows) > 2 {
-		return "", errors.Errorf("metrics entries can't be more than two")
-	} else if len(rows) < 2 {
-		return "", errors.Errorf("metrics doesn't contains required values")
-	}
-
-	// deriving the index for the value column from the headers
-	headerColumn := strings.Split(rows[0], ",")
-	indexForValueColumn := -1
-	for index := range headerColumn {
-		if strings.ToLower(headerColumn[index]) == "value" {
-			indexForValueColumn = index
-			break
-		}
-	}
-	if indexForValueColumn == -1 {
-		return "", errors.Errorf("metrics entries doesn't contains value column")
-	}
-
-	// splitting the metrics entries which are available as comma separated
-	values := strings.Split(rows[1], ",")
-	if values[indexForValueColumn] == "" {
-		return "", errors.Errorf("error while parsing value from derived matrics")
-	}
-	return values[indexForValueColumn], nil
-}
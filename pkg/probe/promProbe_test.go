@@ -0,0 +1,106 @@
+package probe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// newPromServer returns the prometheus stand-in which expects the given authorization header
+// and replies with the given body
+func newPromServer(t *testing.T, authorization, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != authorization {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":"error","errorType":"unauthorized","error":"invalid credentials"}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPromProbeAuth(t *testing.T) {
+	const body = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"1"]}]}}`
+	tests := map[string]struct {
+		auth          types.AuthDetails
+		authorization string
+	}{
+		"bearer": {
+			auth:          types.AuthDetails{Type: "Bearer", Credentials: "token"},
+			authorization: "Bearer token",
+		},
+		"basic": {
+			auth:          types.AuthDetails{Type: "basic", Credentials: "admin:secret"},
+			authorization: "Basic YWRtaW46c2VjcmV0",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newPromServer(t, test.authorization, body)
+			probe := types.ProbeAttributes{}
+			probe.RunProperties.ProbeTimeout = 5
+			probe.PromProbeInputs.Endpoint = server.URL
+			probe.PromProbeInputs.Auth = test.auth
+
+			client, err := getPromClient(probe)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			value, err := getPromValue(client, "up", types.PromRangeDetails{}, 60)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != "1" {
+				t.Fatalf("expected 1, got %v", value)
+			}
+
+			// the request without credentials should be rejected by the stand-in
+			probe.PromProbeInputs.Auth = types.AuthDetails{}
+			client, err = getPromClient(probe)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := getPromValue(client, "up", types.PromRangeDetails{}, 60); err == nil {
+				t.Fatalf("expected an error without the credentials")
+			}
+		})
+	}
+}
+
+func TestPromProbeRangeValue(t *testing.T) {
+	server := newPromServer(t, "", `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1700000000,"2"],[1700000030,"4"]]}]}}`)
+	probe := types.ProbeAttributes{}
+	probe.RunProperties.ProbeTimeout = 5
+	probe.PromProbeInputs.Endpoint = server.URL
+
+	client, err := getPromClient(probe)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	value, err := getPromValue(client, "up", types.PromRangeDetails{Aggregation: "avg"}, 60)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != "3" {
+		t.Fatalf("expected 3, got %v", value)
+	}
+}
+
+func TestPromProbeEmptyResult(t *testing.T) {
+	server := newPromServer(t, "", `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	probe := types.ProbeAttributes{}
+	probe.RunProperties.ProbeTimeout = 5
+	probe.PromProbeInputs.Endpoint = server.URL
+
+	client, err := getPromClient(probe)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := getPromValue(client, "up", types.PromRangeDetails{}, 60); err == nil {
+		t.Fatalf("expected an error for the empty result")
+	}
+}
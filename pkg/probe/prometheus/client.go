@@ -0,0 +1,246 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Client contains the attributes needed to query the prometheus http api
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+	headers    http.Header
+}
+
+// Sample contains a single value of the query result
+type Sample struct {
+	Metric    map[string]string
+	Timestamp time.Time
+	Value     float64
+}
+
+// Series contains all the values of a single time series of the range query result
+type Series struct {
+	Metric  map[string]string
+	Samples []Sample
+}
+
+// apiResponse is the envelope of every prometheus http api response
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+}
+
+// queryData contains the result of a query, its format depends upon the result type
+type queryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// NewClient returns the client for the given prometheus endpoint
+// the headers are added to every request, it can be used to pass the authorization header
+func NewClient(endpoint string, httpClient *http.Client, headers http.Header) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: httpClient,
+		headers:    headers,
+	}
+}
+
+// Query evaluates the instant query at the given time
+// it supports the vector and scalar result types
+func (c *Client) Query(query string, ts time.Time) ([]Sample, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("time", formatTime(ts))
+
+	data, err := c.do("/api/v1/query", params)
+	if err != nil {
+		return nil, err
+	}
+
+	switch data.ResultType {
+	case "vector":
+		var result []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		}
+		if err := json.Unmarshal(data.Result, &result); err != nil {
+			return nil, errors.Errorf("unable to decode the vector result, err: %v", err)
+		}
+		samples := []Sample{}
+		for _, r := range result {
+			sample, err := parseSample(r.Value)
+			if err != nil {
+				return nil, err
+			}
+			sample.Metric = r.Metric
+			samples = append(samples, sample)
+		}
+		return samples, nil
+	case "scalar":
+		var result []interface{}
+		if err := json.Unmarshal(data.Result, &result); err != nil {
+			return nil, errors.Errorf("unable to decode the scalar result, err: %v", err)
+		}
+		sample, err := parseSample(result)
+		if err != nil {
+			return nil, err
+		}
+		return []Sample{sample}, nil
+	default:
+		return nil, errors.Errorf("result type '%s' not supported for the instant query", data.ResultType)
+	}
+}
+
+// QueryRange evaluates the query over the given range with the given resolution step
+func (c *Client) QueryRange(query string, start, end time.Time, step time.Duration) ([]Series, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", formatTime(start))
+	params.Set("end", formatTime(end))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	data, err := c.do("/api/v1/query_range", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ResultType != "matrix" {
+		return nil, errors.Errorf("result type '%s' not supported for the range query", data.ResultType)
+	}
+
+	var result []struct {
+		Metric map[string]string `json:"metric"`
+		Values [][]interface{}   `json:"values"`
+	}
+	if err := json.Unmarshal(data.Result, &result); err != nil {
+		return nil, errors.Errorf("unable to decode the matrix result, err: %v", err)
+	}
+
+	seriesList := []Series{}
+	for _, r := range result {
+		series := Series{Metric: r.Metric}
+		for _, value := range r.Values {
+			sample, err := parseSample(value)
+			if err != nil {
+				return nil, err
+			}
+			series.Samples = append(series.Samples, sample)
+		}
+		seriesList = append(seriesList, series)
+	}
+	return seriesList, nil
+}
+
+// do send the form encoded request to the given api path and decode the response envelope
+func (c *Client) do(path string, params url.Values) (*queryData, error) {
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+path, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response apiResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.Errorf("unable to decode the response, status code: %v, err: %v", resp.StatusCode, err)
+	}
+	if response.Status != "success" {
+		return nil, errors.Errorf("query failed, status code: %v, errorType: %v, err: %v", resp.StatusCode, response.ErrorType, response.Error)
+	}
+
+	var data queryData
+	if err := json.Unmarshal(response.Data, &data); err != nil {
+		return nil, errors.Errorf("unable to decode the response data, err: %v", err)
+	}
+	return &data, nil
+}
+
+// parseSample parse the [<unix_time>, "<value>"] pair returned by prometheus
+func parseSample(pair []interface{}) (Sample, error) {
+	if len(pair) != 2 {
+		return Sample{}, errors.Errorf("invalid sample: %v", pair)
+	}
+	ts, ok := pair[0].(float64)
+	if !ok {
+		return Sample{}, errors.Errorf("invalid sample timestamp: %v", pair[0])
+	}
+	raw, ok := pair[1].(string)
+	if !ok {
+		return Sample{}, errors.Errorf("invalid sample value: %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Sample{}, errors.Errorf("unable to parse the sample value, err: %v", err)
+	}
+	sec := int64(ts)
+	return Sample{
+		Timestamp: time.Unix(sec, int64((ts-float64(sec))*1e9)),
+		Value:     value,
+	}, nil
+}
+
+// formatTime convert the time into the unix timestamp accepted by prometheus
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 3, 64)
+}
+
+// Aggregate reduce the samples into a single value
+// it supports min, max and avg aggregations
+func Aggregate(samples []Sample, aggregation string) (float64, error) {
+	if len(samples) == 0 {
+		return 0, errors.Errorf("no samples found to aggregate")
+	}
+
+	result := samples[0].Value
+	switch strings.ToLower(aggregation) {
+	case "min":
+		for _, sample := range samples[1:] {
+			if sample.Value < result {
+				result = sample.Value
+			}
+		}
+	case "max":
+		for _, sample := range samples[1:] {
+			if sample.Value > result {
+				result = sample.Value
+			}
+		}
+	case "avg":
+		for _, sample := range samples[1:] {
+			result += sample.Value
+		}
+		result = result / float64(len(samples))
+	default:
+		return 0, errors.Errorf("aggregation '%s' not supported", aggregation)
+	}
+	return result, nil
+}
@@ -0,0 +1,166 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newServer returns the prometheus stand-in which replies with the given status code and body
+// the received requests are passed to the inspect func, if provided
+func newServer(t *testing.T, statusCode int, body string, inspect func(r *http.Request)) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse the form, err: %v", err)
+		}
+		if inspect != nil {
+			inspect(r)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestQueryVector(t *testing.T) {
+	server := newServer(t, http.StatusOK,
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"api"},"value":[1700000000.5,"42.5"]}]}}`,
+		func(r *http.Request) {
+			if r.URL.Path != "/api/v1/query" {
+				t.Errorf("expected /api/v1/query path, got %v", r.URL.Path)
+			}
+			if r.Form.Get("query") != `up{job="api"}` {
+				t.Errorf("unexpected query: %v", r.Form.Get("query"))
+			}
+			if r.Form.Get("time") == "" {
+				t.Errorf("expected the evaluation time")
+			}
+		})
+
+	samples, err := NewClient(server.URL+"/", nil, nil).Query(`up{job="api"}`, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(samples) != 1 || samples[0].Value != 42.5 || samples[0].Metric["job"] != "api" {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+	if samples[0].Timestamp.Unix() != 1700000000 {
+		t.Fatalf("unexpected timestamp: %v", samples[0].Timestamp)
+	}
+}
+
+func TestQueryScalar(t *testing.T) {
+	server := newServer(t, http.StatusOK, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"7"]}}`, nil)
+
+	samples, err := NewClient(server.URL, nil, nil).Query("vector(7)", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(samples) != 1 || samples[0].Value != 7 {
+		t.Fatalf("unexpected samples: %+v", samples)
+	}
+}
+
+func TestQueryRange(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	end := start.Add(time.Minute)
+	server := newServer(t, http.StatusOK,
+		`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"api"},"values":[[1700000000,"3"],[1700000030,"9"],[1700000060,"6"]]}]}}`,
+		func(r *http.Request) {
+			if r.URL.Path != "/api/v1/query_range" {
+				t.Errorf("expected /api/v1/query_range path, got %v", r.URL.Path)
+			}
+			if r.Form.Get("start") != "1700000000.000" || r.Form.Get("end") != "1700000060.000" || r.Form.Get("step") != "30" {
+				t.Errorf("unexpected range: start=%v end=%v step=%v", r.Form.Get("start"), r.Form.Get("end"), r.Form.Get("step"))
+			}
+		})
+
+	series, err := NewClient(server.URL, nil, nil).QueryRange("rate(errors[1m])", start, end, 30*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series) != 1 || len(series[0].Samples) != 3 {
+		t.Fatalf("unexpected series: %+v", series)
+	}
+
+	tests := map[string]float64{"min": 3, "max": 9, "avg": 6, "AVG": 6}
+	for aggregation, expected := range tests {
+		value, err := Aggregate(series[0].Samples, aggregation)
+		if err != nil {
+			t.Fatalf("unexpected error for %v aggregation: %v", aggregation, err)
+		}
+		if value != expected {
+			t.Fatalf("expected %v for %v aggregation, got %v", expected, aggregation, value)
+		}
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	if _, err := Aggregate(nil, "avg"); err == nil {
+		t.Fatalf("expected an error for the empty samples")
+	}
+	if _, err := Aggregate([]Sample{{Value: 1}}, "sum"); err == nil {
+		t.Fatalf("expected an error for the unsupported aggregation")
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	server := newServer(t, http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		func(r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				t.Errorf("unexpected authorization header: %v", r.Header.Get("Authorization"))
+			}
+		})
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer token")
+	samples, err := NewClient(server.URL, nil, headers).Query("up", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(samples) != 0 {
+		t.Fatalf("expected empty result, got %+v", samples)
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := map[string]struct {
+		statusCode int
+		body       string
+	}{
+		"error status": {
+			statusCode: http.StatusBadRequest,
+			body:       `{"status":"error","errorType":"bad_data","error":"parse error at char 4"}`,
+		},
+		"non-2xx status without body": {
+			statusCode: http.StatusServiceUnavailable,
+			body:       `service unavailable`,
+		},
+		"unsupported result type": {
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"string","result":[1700000000,"up"]}}`,
+		},
+		"invalid sample value": {
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"NaN?"]}]}}`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newServer(t, test.statusCode, test.body, nil)
+			if _, err := NewClient(server.URL, nil, nil).Query("up", time.Now()); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestQueryRangeErrors(t *testing.T) {
+	server := newServer(t, http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[]}}`, nil)
+	if _, err := NewClient(server.URL, nil, nil).QueryRange("up", time.Now().Add(-time.Minute), time.Now(), time.Second); err == nil {
+		t.Fatalf("expected an error for the non matrix result")
+	}
+}
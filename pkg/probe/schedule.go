@@ -0,0 +1,185 @@
+package probe
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// executeProbes executes the probes of the given phase
+// the probes are executed concurrently, unless PARALLEL_PROBES env is set to false
+// each probe waits for the probes listed inside its dependsOn, which are executed in the same batch
+func executeProbes(probes []types.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string) error {
+
+	if len(probes) == 0 {
+		return nil
+	}
+
+	ordered, err := sortProbes(probes)
+	if err != nil {
+		return err
+	}
+
+	if parallel, _ := strconv.ParseBool(types.Getenv("PARALLEL_PROBES", "true")); !parallel {
+		for _, probe := range ordered {
+			if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	done := map[string]chan struct{}{}
+	for _, probe := range probes {
+		done[probe.Name] = make(chan struct{})
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := map[string]bool{}
+	probeErrors := make([]error, len(probes))
+
+	for index := range probes {
+		wg.Add(1)
+		go func(index int, probe types.ProbeAttributes) {
+			defer wg.Done()
+			defer close(done[probe.Name])
+
+			// waiting for the dependencies, the probe is skipped if any dependency fails
+			for _, dependency := range probe.DependsOn {
+				// the dependencies outside of the batch are already executed
+				if _, ok := done[dependency]; !ok {
+					continue
+				}
+				<-done[dependency]
+				mu.Lock()
+				isFailed := failed[dependency]
+				mu.Unlock()
+				if isFailed {
+					log.Errorf("[Probe]: Skipping the %v probe, as %v probe has been failed", probe.Name, dependency)
+					// the skipped probe is marked as failed, it fails the experiment only if it stops on failure
+					setProbeVerdict(resultDetails, probe, "Failed", phase)
+					mu.Lock()
+					failed[probe.Name] = true
+					if probe.RunProperties.StopOnFailure {
+						probeErrors[index] = errors.Errorf("%v probe skipped, as its dependency %v probe has been failed", probe.Name, dependency)
+					}
+					mu.Unlock()
+					return
+				}
+			}
+
+			err := execute(probe, chaosDetails, clients, resultDetails, phase)
+			// the probes, which doesn't stop on failure, record the failure inside the verdict without any error
+			if err != nil || isProbeFailed(resultDetails, probe, phase) {
+				mu.Lock()
+				failed[probe.Name] = true
+				probeErrors[index] = err
+				mu.Unlock()
+			}
+		}(index, probes[index])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, err := range probeErrors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Errorf("probes failed, err: %v", errs)
+	}
+}
+
+// isProbeFailed checks whether the recorded verdict of the probe is failed in the given phase
+func isProbeFailed(resultDetails *types.ResultDetails, probe types.ProbeAttributes, phase string) bool {
+	key := phase
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+		key = probe.Mode
+	}
+	for _, probeDetails := range resultDetails.GetProbeDetails() {
+		if probeDetails.Name == probe.Name && probeDetails.Type == probe.Type {
+			return strings.HasPrefix(probeDetails.Status[key], failedProbeStatus)
+		}
+	}
+	return false
+}
+
+// validateProbes validates the names and the dependencies of all the probes
+func validateProbes(probes []types.ProbeAttributes) error {
+
+	names := map[string]bool{}
+	for _, probe := range probes {
+		if names[probe.Name] {
+			return errors.Errorf("[Probe]: Multiple probes found with %v name", probe.Name)
+		}
+		names[probe.Name] = true
+	}
+	for _, probe := range probes {
+		for _, dependency := range probe.DependsOn {
+			if !names[dependency] {
+				return errors.Errorf("[Probe]: %v dependency of the %v probe not found", dependency, probe.Name)
+			}
+		}
+	}
+	_, err := sortProbes(probes)
+	return err
+}
+
+// sortProbes returns the probes in the execution order, the dependencies outside of the given probes are ignored
+// it preserves the order of the chaosengine for the independent probes
+func sortProbes(probes []types.ProbeAttributes) ([]types.ProbeAttributes, error) {
+
+	index := map[string]int{}
+	for i, probe := range probes {
+		index[probe.Name] = i
+	}
+
+	// depth first traversal, marks the probes as visiting to detect the cycles
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make([]int, len(probes))
+	var ordered []types.ProbeAttributes
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return errors.Errorf("[Probe]: Cyclic dependency found between the probes: %v", strings.Join(append(path, probes[i].Name), " -> "))
+		}
+		state[i] = visiting
+		for _, dependency := range probes[i].DependsOn {
+			dependencyIndex, ok := index[dependency]
+			if !ok {
+				continue
+			}
+			if err := visit(dependencyIndex, append(path, probes[i].Name)); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		ordered = append(ordered, probes[i])
+		return nil
+	}
+	for i := range probes {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,84 @@
+package probe
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// getHTTPClient returns the http client with the given tls configuration and timeout
+func getHTTPClient(tlsDetails types.TLSDetails, timeout time.Duration) (*http.Client, error) {
+	tlsConfig, err := getTLSConfig(tlsDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		Timeout:   timeout,
+	}, nil
+}
+
+// getTLSConfig derive the tls config from the ca certificate and client key pair
+func getTLSConfig(tlsDetails types.TLSDetails) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: tlsDetails.InsecureSkipVerify}
+
+	if tlsDetails.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(tlsDetails.CACertFile)
+		if err != nil {
+			return nil, errors.Errorf("unable to read the ca certificate, err: %v", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("unable to parse the ca certificate from %v", tlsDetails.CACertFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if tlsDetails.CertFile != "" || tlsDetails.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsDetails.CertFile, tlsDetails.KeyFile)
+		if err != nil {
+			return nil, errors.Errorf("unable to load the client key pair, err: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// getAuthorizationHeader derive the value of authorization header from the auth details
+// it returns empty string, if auth details are not provided
+func getAuthorizationHeader(auth types.AuthDetails) (string, error) {
+	if auth.Type == "" {
+		return "", nil
+	}
+
+	credentials := auth.Credentials
+	if credentials == "" && auth.CredentialsFile != "" {
+		content, err := ioutil.ReadFile(auth.CredentialsFile)
+		if err != nil {
+			return "", errors.Errorf("unable to read the credentials file, err: %v", err)
+		}
+		credentials = strings.TrimSpace(string(content))
+	}
+	if credentials == "" {
+		return "", errors.Errorf("[Probe]: Any one of credentials or credentialsFile is required for %v auth", auth.Type)
+	}
+
+	switch strings.ToLower(auth.Type) {
+	case "bearer":
+		return "Bearer " + credentials, nil
+	case "basic":
+		if !strings.Contains(credentials, ":") {
+			return "", errors.Errorf("basic auth credentials should be in username:password format")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials)), nil
+	default:
+		return "", errors.Errorf("auth type '%s' not supported", auth.Type)
+	}
+}
@@ -0,0 +1,113 @@
+package watch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// listContainers list the pods and record the terminations of their containers, it returns the resource version of the list
+// the new terminations are matched only if count is true, otherwise the terminations are just seeded
+func (w *Watcher) listContainers(count bool) (string, error) {
+	pods, err := w.client.CoreV1().Pods(w.options.Namespace).List(v1.ListOptions{LabelSelector: w.options.TerminationSelector})
+	if err != nil {
+		return "", errors.Errorf("unable to list the pods with %v label in %v namespace, err: %v", w.options.TerminationSelector, w.options.Namespace, err)
+	}
+	for index := range pods.Items {
+		w.recordTerminations(&pods.Items[index], count)
+	}
+	return pods.ResourceVersion, nil
+}
+
+// watchContainers watch the container statuses of the pods, until the context is cancelled
+// the broken watches are re-established from the last seen resource version
+func (w *Watcher) watchContainers(ctx context.Context, resourceVersion string) {
+	defer w.wg.Done()
+
+	for ctx.Err() == nil {
+		// relist the pods, if the resource version is expired
+		if resourceVersion == "" {
+			var err error
+			if resourceVersion, err = w.listContainers(true); err != nil {
+				log.Warnf("[Watch]: %v", err)
+				if !sleep(ctx, resyncInterval) {
+					return
+				}
+				continue
+			}
+		}
+
+		watcher, err := w.client.CoreV1().Pods(w.options.Namespace).Watch(v1.ListOptions{
+			LabelSelector:   w.options.TerminationSelector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			log.Warnf("[Watch]: unable to watch the pods in %v namespace, err: %v", w.options.Namespace, err)
+			resourceVersion = ""
+			if !sleep(ctx, resyncInterval) {
+				return
+			}
+			continue
+		}
+		resourceVersion = w.consumePods(ctx, watcher, resourceVersion)
+	}
+}
+
+// consumePods record the terminations of the pods received from the watch and returns the last seen resource version
+// it returns empty resource version, if the watch is expired
+func (w *Watcher) consumePods(ctx context.Context, watcher watch.Interface, resourceVersion string) string {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if pod, ok := event.Object.(*corev1.Pod); ok {
+					w.recordTerminations(pod, true)
+					resourceVersion = pod.ResourceVersion
+				}
+			case watch.Error:
+				return ""
+			}
+		}
+	}
+}
+
+// recordTerminations record the new terminations of the containers, if the terminated reason is matched
+// the termination is present in the state till the container restarts and in the last state afterwards
+func (w *Watcher) recordTerminations(pod *corev1.Pod, count bool) {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, status := range statuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated == nil || !w.terminatedReasons[strings.ToLower(terminated.Reason)] {
+				continue
+			}
+			key := string(pod.UID) + "/" + status.Name + "/" + terminated.ContainerID + "/" + terminated.FinishedAt.String()
+			if w.terminated[key] {
+				continue
+			}
+			w.terminated[key] = true
+			// skipping the terminations before the start, which are found during the relist
+			if !count || terminated.FinishedAt.Time.Before(w.startTime) {
+				continue
+			}
+			w.terminations[terminated.Reason]++
+			log.Infof("[Watch]: %v container of %v pod terminated with %v reason, exit code: %v", status.Name, pod.Name, terminated.Reason, terminated.ExitCode)
+		}
+	}
+}
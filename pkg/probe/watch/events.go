@@ -0,0 +1,127 @@
+package watch
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// warningEventSelector selects only the warning events
+const warningEventSelector = "type=" + corev1.EventTypeWarning
+
+// listEvents list the warning events of the namespace and returns the resource version of the list
+// the new occurrences are matched only if count is true, otherwise the events are just seeded
+func (w *Watcher) listEvents(count bool) (string, error) {
+	events, err := w.client.CoreV1().Events(w.options.Namespace).List(v1.ListOptions{FieldSelector: warningEventSelector})
+	if err != nil {
+		return "", errors.Errorf("unable to list the events in %v namespace, err: %v", w.options.Namespace, err)
+	}
+	for index := range events.Items {
+		w.recordEvent(&events.Items[index], count)
+	}
+	return events.ResourceVersion, nil
+}
+
+// watchEvents watch the warning events of the namespace, until the context is cancelled
+// the broken watches are re-established from the last seen resource version
+func (w *Watcher) watchEvents(ctx context.Context, resourceVersion string) {
+	defer w.wg.Done()
+
+	for ctx.Err() == nil {
+		// relist the events, if the resource version is expired
+		if resourceVersion == "" {
+			var err error
+			if resourceVersion, err = w.listEvents(true); err != nil {
+				log.Warnf("[Watch]: %v", err)
+				if !sleep(ctx, resyncInterval) {
+					return
+				}
+				continue
+			}
+		}
+
+		watcher, err := w.client.CoreV1().Events(w.options.Namespace).Watch(v1.ListOptions{
+			FieldSelector:   warningEventSelector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			log.Warnf("[Watch]: unable to watch the events in %v namespace, err: %v", w.options.Namespace, err)
+			resourceVersion = ""
+			if !sleep(ctx, resyncInterval) {
+				return
+			}
+			continue
+		}
+		resourceVersion = w.consumeEvents(ctx, watcher, resourceVersion)
+	}
+}
+
+// consumeEvents record the events received from the watch and returns the last seen resource version
+// it returns empty resource version, if the watch is expired
+func (w *Watcher) consumeEvents(ctx context.Context, watcher watch.Interface, resourceVersion string) string {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if e, ok := event.Object.(*corev1.Event); ok {
+					w.recordEvent(e, true)
+					resourceVersion = e.ResourceVersion
+				}
+			case watch.Error:
+				return ""
+			}
+		}
+	}
+}
+
+// recordEvent record the new occurrences of the event, if the reason of the event is matched
+func (w *Watcher) recordEvent(event *corev1.Event, count bool) {
+	if !w.reasons[strings.ToLower(event.Reason)] {
+		return
+	}
+	occurrences := int(event.Count)
+	if occurrences == 0 {
+		occurrences = 1
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seen, ok := w.seen[event.UID]
+	w.seen[event.UID] = occurrences
+	if !count || occurrences <= seen {
+		return
+	}
+	// skipping the events emitted before the start, which are found during the relist
+	if !ok && getEventTime(event).Before(w.startTime) {
+		return
+	}
+	w.events[event.Reason] += occurrences - seen
+	log.Infof("[Watch]: %v event found for %v %v: %v", event.Reason, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message)
+}
+
+// getEventTime returns the time of the last occurrence of the event
+func getEventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,156 @@
+package watch
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxLogLineSize is the maximum size of the log line, the longer lines are skipped
+const maxLogLineSize = 1024 * 1024
+
+// maxSampleSize is the maximum size of the log line kept as sample
+const maxSampleSize = 256
+
+// listPods list the pods, whose logs are watched
+func (w *Watcher) listPods() ([]corev1.Pod, error) {
+	pods, err := w.client.CoreV1().Pods(w.options.Namespace).List(v1.ListOptions{LabelSelector: w.options.LabelSelector})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the pods with %v label in %v namespace, err: %v", w.options.LabelSelector, w.options.Namespace, err)
+	}
+	return pods.Items, nil
+}
+
+// watchLogs stream the logs of the running pods, until the context is cancelled
+// the pods are listed periodically to discover the newly created pods and restarted containers
+func (w *Watcher) watchLogs(ctx context.Context) {
+	defer w.wg.Done()
+
+	for {
+		pods, err := w.listPods()
+		if err != nil {
+			log.Warnf("[Watch]: %v", err)
+		}
+		for _, pod := range pods {
+			if pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
+			for _, container := range pod.Spec.Containers {
+				if w.options.Container != "" && container.Name != w.options.Container {
+					continue
+				}
+				key := pod.Name + "/" + container.Name
+				if w.startStream(key) {
+					w.wg.Add(1)
+					go w.streamLogs(ctx, key, pod.Name, container.Name)
+				}
+			}
+		}
+		if !sleep(ctx, resyncInterval) {
+			return
+		}
+	}
+}
+
+// startStream marks the stream as started, it returns false if the stream is already running
+func (w *Watcher) startStream(key string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.streams[key] {
+		return false
+	}
+	w.streams[key] = true
+	return true
+}
+
+// streamLogs follow the logs of the container and record the matched log lines
+// the stream is resumed from the last seen log line, if it was broken earlier
+func (w *Watcher) streamLogs(ctx context.Context, key, podName, containerName string) {
+	defer w.wg.Done()
+	defer func() {
+		w.mu.Lock()
+		w.streams[key] = false
+		w.mu.Unlock()
+	}()
+
+	w.mu.Lock()
+	lastLine, resumed := w.lastLines[key]
+	w.mu.Unlock()
+	since := w.startTime
+	if resumed {
+		since = lastLine
+	}
+
+	stream, err := w.client.CoreV1().Pods(w.options.Namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container:  containerName,
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  &v1.Time{Time: since},
+	}).Stream()
+	if err != nil {
+		log.Warnf("[Watch]: unable to stream the logs of %v container of %v pod, err: %v", containerName, podName, err)
+		return
+	}
+	defer stream.Close()
+
+	// closing the stream once the context is cancelled, to interrupt the blocking reads
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-done:
+		}
+	}()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		timestamp, line := splitTimestamp(scanner.Text())
+		// skipping the lines, which are already seen before the stream was broken
+		if resumed && !timestamp.After(lastLine) {
+			continue
+		}
+		w.recordLine(key, timestamp, line)
+	}
+}
+
+// recordLine record the log line, if it matches the regex
+func (w *Watcher) recordLine(key string, timestamp time.Time, line string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !timestamp.IsZero() {
+		w.lastLines[key] = timestamp
+	}
+	if !w.regex.MatchString(line) {
+		return
+	}
+	w.lines++
+	if len(w.samples) < maxLogSamples {
+		if len(line) > maxSampleSize {
+			line = line[:maxSampleSize]
+		}
+		w.samples = append(w.samples, key+": "+line)
+	}
+}
+
+// splitTimestamp split the log line into the timestamp and the message
+func splitTimestamp(line string) (time.Time, string) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) != 2 {
+		return time.Time{}, line
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, line
+	}
+	return timestamp, parts[1]
+}
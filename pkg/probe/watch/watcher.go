@@ -0,0 +1,215 @@
+package watch
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxLogSamples is the maximum number of the matched log lines kept as samples
+const maxLogSamples = 5
+
+// resyncInterval is the interval to re-establish the broken watches and discover the new pods
+const resyncInterval = 5 * time.Second
+
+// Options contains the attributes of the watcher
+type Options struct {
+	Namespace string
+	// Reasons of the warning events to be matched
+	Reasons []string
+	// Regex to be matched against the log lines
+	Regex string
+	// LabelSelector of the pods, whose logs are watched
+	LabelSelector string
+	// Container of the pods, whose logs are watched
+	Container string
+	// TerminatedReasons of the container terminations to be matched, e.g. OOMKilled
+	TerminatedReasons []string
+	// TerminationSelector is the label selector of the pods, whose container terminations are watched
+	TerminationSelector string
+}
+
+// Result contains the matched events and log lines
+type Result struct {
+	// Events contains the number of the matched events per reason
+	Events map[string]int
+	// LogLines contains the number of the matched log lines
+	LogLines int
+	// LogSamples contains the first few matched log lines
+	LogSamples []string
+	// Terminations contains the number of the matched container terminations per reason
+	Terminations map[string]int
+}
+
+// EventCount returns the total number of the matched events
+func (r Result) EventCount() int {
+	return total(r.Events)
+}
+
+// EventSummary returns the matched events in reason: count format, sorted by reason
+func (r Result) EventSummary() string {
+	return summarize(r.Events)
+}
+
+// TerminationCount returns the total number of the matched container terminations
+func (r Result) TerminationCount() int {
+	return total(r.Terminations)
+}
+
+// TerminationSummary returns the matched container terminations in reason: count format, sorted by reason
+func (r Result) TerminationSummary() string {
+	return summarize(r.Terminations)
+}
+
+// total returns the sum of the counts of all the reasons
+func total(reasons map[string]int) int {
+	count := 0
+	for _, value := range reasons {
+		count += value
+	}
+	return count
+}
+
+// summarize returns the counts in reason: count format, sorted by reason
+func summarize(reasons map[string]int) string {
+	var summary []string
+	for reason, count := range reasons {
+		summary = append(summary, reason+": "+strconv.Itoa(count))
+	}
+	sort.Strings(summary)
+	return strings.Join(summary, ", ")
+}
+
+// Watcher watches the warning events, the container terminations and the pod logs of the namespace
+type Watcher struct {
+	client            kubernetes.Interface
+	options           Options
+	reasons           map[string]bool
+	terminatedReasons map[string]bool
+	regex             *regexp.Regexp
+	startTime         time.Time
+	wg                sync.WaitGroup
+
+	mu           sync.Mutex
+	seen         map[k8stypes.UID]int
+	events       map[string]int
+	terminated   map[string]bool
+	terminations map[string]int
+	streams      map[string]bool
+	lastLines    map[string]time.Time
+	lines        int
+	samples      []string
+}
+
+// New creates the watcher with the given options
+func New(client kubernetes.Interface, options Options) (*Watcher, error) {
+	w := &Watcher{
+		client:            client,
+		options:           options,
+		reasons:           map[string]bool{},
+		terminatedReasons: map[string]bool{},
+		seen:              map[k8stypes.UID]int{},
+		events:            map[string]int{},
+		terminated:        map[string]bool{},
+		terminations:      map[string]int{},
+		streams:           map[string]bool{},
+		lastLines:         map[string]time.Time{},
+	}
+	for _, reason := range options.Reasons {
+		w.reasons[strings.ToLower(reason)] = true
+	}
+	for _, reason := range options.TerminatedReasons {
+		w.terminatedReasons[strings.ToLower(reason)] = true
+	}
+	if options.Regex != "" {
+		regex, err := regexp.Compile(options.Regex)
+		if err != nil {
+			return nil, errors.Errorf("unable to parse the %v regex, err: %v", options.Regex, err)
+		}
+		w.regex = regex
+	}
+	if len(w.reasons) == 0 && len(w.terminatedReasons) == 0 && w.regex == nil {
+		return nil, errors.Errorf("any one of event reasons, terminated reasons or log regex is required")
+	}
+	return w, nil
+}
+
+// Start starts watching the events, container terminations and logs, until the context is cancelled
+// only the events, terminations and log lines, which are emitted after the start are matched
+func (w *Watcher) Start(ctx context.Context) error {
+	w.startTime = time.Now()
+
+	if len(w.reasons) != 0 {
+		// the existing events are only seeded, the new occurrences of them are matched later
+		resourceVersion, err := w.listEvents(false)
+		if err != nil {
+			return err
+		}
+		w.wg.Add(1)
+		go w.watchEvents(ctx, resourceVersion)
+	}
+
+	if len(w.terminatedReasons) != 0 {
+		// the existing terminations are only seeded, the new terminations are matched later
+		resourceVersion, err := w.listContainers(false)
+		if err != nil {
+			return err
+		}
+		w.wg.Add(1)
+		go w.watchContainers(ctx, resourceVersion)
+	}
+
+	if w.regex != nil {
+		if _, err := w.listPods(); err != nil {
+			return err
+		}
+		w.wg.Add(1)
+		go w.watchLogs(ctx)
+	}
+	return nil
+}
+
+// Wait waits for the watches to be stopped, once the context is cancelled
+func (w *Watcher) Wait() {
+	w.wg.Wait()
+}
+
+// Result returns the matched events, container terminations and log lines
+func (w *Watcher) Result() Result {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	result := Result{
+		Events:       map[string]int{},
+		LogLines:     w.lines,
+		LogSamples:   append([]string{}, w.samples...),
+		Terminations: map[string]int{},
+	}
+	for reason, count := range w.events {
+		result.Events[reason] = count
+	}
+	for reason, count := range w.terminations {
+		result.Terminations[reason] = count
+	}
+	return result
+}
+
+// sleep waits for the given duration, it returns false if the context is cancelled
+func sleep(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
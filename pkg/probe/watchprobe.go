@@ -0,0 +1,328 @@
+package probe
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	watchProbe "github.com/litmuschaos/litmus-go/pkg/probe/watch"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// watchProbeRun contains the details of the running watch probe
+type watchProbeRun struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	result watchProbe.Result
+}
+
+// watchProbeRuns contains the running watch probes, which are stopped in the postchaos phase
+var (
+	watchProbeRunsMu sync.Mutex
+	watchProbeRuns   = map[string]*watchProbeRun{}
+)
+
+// prepareWatchProbe contains the steps to prepare the watch probe
+// which watches the kubernetes events and the application logs during the chaos window
+func prepareWatchProbe(probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+	default:
+		return errors.Errorf("mode '%s' not supported in the watch probe, it supports continuous and onchaos modes", probe.Mode)
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		if err := preChaosWatchProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "postchaos":
+		if err := postChaosWatchProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "duringchaos":
+		if err := onChaosWatchProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("phase '%s' not supported in the watch probe", phase)
+	}
+	return nil
+}
+
+//preChaosWatchProbe trigger the watch probe for prechaos phase
+func preChaosWatchProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	switch strings.ToLower(probe.Mode) {
+	case "continuous":
+
+		//DISPLAY THE WATCH PROBE INFO
+		log.InfoWithValues("[Probe]: The watch probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespace":      getWatchNamespace(probe.WatchProbeInputs, chaosDetails),
+			"Events":         probe.WatchProbeInputs.Events,
+			"Containers":     probe.WatchProbeInputs.Containers,
+			"Logs":           probe.WatchProbeInputs.Logs,
+			"Record Only":    probe.WatchProbeInputs.RecordOnly,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// trigger the continuous watch probe
+		ctx, cancel := context.WithCancel(probeCtx)
+		go triggerContinuousWatchProbe(ctx, probe, clients, resultDetails, chaosDetails, registerWatchProbe(probe.Name, cancel))
+	}
+	return nil
+}
+
+//postChaosWatchProbe stops the watch probe and evaluates the matched events and log lines
+func postChaosWatchProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	var err error
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+
+		// stopping the watches and reporting the matched events and log lines inside the probe status
+		result := stopWatchProbe(probe.Name)
+		reportWatchProbe(resultDetails, probe, result)
+
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err = checkForErrorInContinuousProbe(resultDetails, probe.Name)
+		if err == nil {
+			err = validateWatchProbe(probe, result)
+		}
+
+		// failing the probe, if the success condition doesn't met
+		if err = markedVerdictInEnd(err, resultDetails, chaosDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//onChaosWatchProbe trigger the watch probe for DuringChaos phase
+func onChaosWatchProbe(probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	switch strings.ToLower(probe.Mode) {
+	case "onchaos":
+
+		//DISPLAY THE WATCH PROBE INFO
+		log.InfoWithValues("[Probe]: The watch probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespace":      getWatchNamespace(probe.WatchProbeInputs, chaosDetails),
+			"Events":         probe.WatchProbeInputs.Events,
+			"Containers":     probe.WatchProbeInputs.Containers,
+			"Logs":           probe.WatchProbeInputs.Logs,
+			"Record Only":    probe.WatchProbeInputs.RecordOnly,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+
+		// trigger the onchaos watch probe
+		ctx, cancel := context.WithCancel(probeCtx)
+		go triggerOnChaosWatchProbe(ctx, probe, clients, resultDetails, chaosDetails, registerWatchProbe(probe.Name, cancel))
+	}
+	return nil
+}
+
+// triggerContinuousWatchProbe trigger the continuous watch probe
+// it watches the events and logs, until the probe is stopped in the postchaos phase
+func triggerContinuousWatchProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails, run *watchProbeRun) {
+	defer close(run.done)
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second):
+		}
+	}
+
+	run.result = triggerWatchProbe(ctx, probe, clients, chaosresult, chaosDetails)
+}
+
+// triggerOnChaosWatchProbe trigger the onchaos watch probe
+// it watches the events and logs for the entire duration of chaos
+func triggerOnChaosWatchProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails, run *watchProbeRun) {
+	defer close(run.done)
+
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second):
+		}
+		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(duration)*time.Second)
+	defer cancel()
+	run.result = triggerWatchProbe(ctx, probe, clients, chaosresult, chaosDetails)
+	log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+}
+
+// triggerWatchProbe watches the events and logs until the context is cancelled and returns the matched events and log lines
+// it compares the matched events and log lines with the thresholds after each polling interval and it fails, if any threshold exceeds
+func triggerWatchProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) watchProbe.Result {
+
+	inputs := probe.WatchProbeInputs
+	watcher, err := watchProbe.New(clients.KubeClient, watchProbe.Options{
+		Namespace:           getWatchNamespace(inputs, chaosDetails),
+		Reasons:             inputs.Events.Reasons,
+		Regex:               inputs.Logs.Regex,
+		LabelSelector:       getWatchLabelSelector(inputs.Logs.LabelSelector, chaosDetails),
+		Container:           inputs.Logs.Container,
+		TerminatedReasons:   inputs.Containers.TerminatedReasons,
+		TerminationSelector: getWatchLabelSelector(inputs.Containers.LabelSelector, chaosDetails),
+	})
+	if err == nil {
+		err = watcher.Start(ctx)
+	}
+	if err == nil {
+		err = monitorWatchProbe(ctx, probe, watcher)
+	}
+
+	// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+	if err != nil {
+		setProbeError(chaosresult, probe.Name, err)
+		log.Errorf("The %v watch probe has been Failed, err: %v", probe.Name, err)
+		// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+		// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+		// and failed the experiment in the end
+		if probe.RunProperties.StopOnFailure {
+			if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+				log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+			}
+		}
+	}
+
+	if watcher == nil {
+		return watchProbe.Result{}
+	}
+	// keep recording the events and log lines until the end of the probe
+	<-ctx.Done()
+	watcher.Wait()
+	return watcher.Result()
+}
+
+// monitorWatchProbe compares the matched events and log lines with the thresholds after each polling interval
+// it returns once the context is cancelled or any threshold exceeds
+func monitorWatchProbe(ctx context.Context, probe types.ProbeAttributes, watcher *watchProbe.Watcher) error {
+	interval := time.Duration(math.Maximum(1, probe.RunProperties.ProbePollingInterval)) * time.Second
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+		if err := validateWatchProbe(probe, watcher.Result()); err != nil {
+			return err
+		}
+	}
+}
+
+// validateWatchProbe compares the matched events and log lines with the thresholds
+func validateWatchProbe(probe types.ProbeAttributes, result watchProbe.Result) error {
+	inputs := probe.WatchProbeInputs
+	if inputs.RecordOnly {
+		return nil
+	}
+	if len(inputs.Events.Reasons) != 0 && result.EventCount() > inputs.Events.Threshold {
+		return errors.Errorf("%v matched events found, threshold: %v, events: %v", result.EventCount(), inputs.Events.Threshold, result.EventSummary())
+	}
+	if len(inputs.Containers.TerminatedReasons) != 0 && result.TerminationCount() > inputs.Containers.Threshold {
+		return errors.Errorf("%v matched container terminations found, threshold: %v, terminations: %v", result.TerminationCount(), inputs.Containers.Threshold, result.TerminationSummary())
+	}
+	if inputs.Logs.Regex != "" && result.LogLines > inputs.Logs.Threshold {
+		return errors.Errorf("%v matched log lines found, threshold: %v", result.LogLines, inputs.Logs.Threshold)
+	}
+	return nil
+}
+
+// reportWatchProbe report the matched events and log lines inside the probe status
+func reportWatchProbe(resultDetails *types.ResultDetails, probe types.ProbeAttributes, result watchProbe.Result) {
+	resultDetails.UpdateProbeDetails(probe.Name, func(probeDetails *types.ProbeDetails) {
+		status := probeDetails.Status
+		if len(probe.WatchProbeInputs.Events.Reasons) != 0 {
+			status["MatchedEventCount"] = strconv.Itoa(result.EventCount())
+			if summary := result.EventSummary(); summary != "" {
+				status["MatchedEvents"] = summary
+			}
+		}
+		if len(probe.WatchProbeInputs.Containers.TerminatedReasons) != 0 {
+			status["MatchedTerminationCount"] = strconv.Itoa(result.TerminationCount())
+			if summary := result.TerminationSummary(); summary != "" {
+				status["MatchedTerminations"] = summary
+			}
+		}
+		if probe.WatchProbeInputs.Logs.Regex != "" {
+			status["MatchedLogLines"] = strconv.Itoa(result.LogLines)
+			if len(result.LogSamples) != 0 {
+				status["MatchedLogSamples"] = strings.Join(result.LogSamples, "\n")
+			}
+		}
+	})
+	log.InfoWithValues("[Probe]: The watch result of the "+probe.Name+" probe is as follows", logrus.Fields{
+		"Events":       result.EventSummary(),
+		"Terminations": result.TerminationSummary(),
+		"Log Lines":    result.LogLines,
+	})
+}
+
+// registerWatchProbe register the running watch probe, which is stopped in the postchaos phase
+func registerWatchProbe(probeName string, cancel context.CancelFunc) *watchProbeRun {
+	watchProbeRunsMu.Lock()
+	defer watchProbeRunsMu.Unlock()
+	run := &watchProbeRun{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	watchProbeRuns[probeName] = run
+	return run
+}
+
+// stopWatchProbe stops the running watch probe and returns the matched events and log lines
+func stopWatchProbe(probeName string) watchProbe.Result {
+	watchProbeRunsMu.Lock()
+	run, ok := watchProbeRuns[probeName]
+	delete(watchProbeRuns, probeName)
+	watchProbeRunsMu.Unlock()
+	if !ok {
+		return watchProbe.Result{}
+	}
+	run.cancel()
+	<-run.done
+	return run.result
+}
+
+// getWatchNamespace returns the namespace to be watched, it defaults to the application namespace
+func getWatchNamespace(inputs types.WatchProbeInputs, chaosDetails *types.ChaosDetails) string {
+	if inputs.Namespace != "" {
+		return inputs.Namespace
+	}
+	return chaosDetails.AppDetail.Namespace
+}
+
+// getWatchLabelSelector returns the label selector of the pods, whose logs or container terminations are watched
+// it defaults to the application label
+func getWatchLabelSelector(labelSelector string, chaosDetails *types.ChaosDetails) string {
+	if labelSelector != "" {
+		return labelSelector
+	}
+	return chaosDetails.AppDetail.Label
+}
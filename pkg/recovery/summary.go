@@ -0,0 +1,106 @@
+package recovery
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Summary contains the recovery of all the disrupted target workloads
+type Summary struct {
+	InjectedAt time.Time `json:"injectedAt"`
+	Targets    []Target  `json:"targets"`
+	// MTTRSeconds is the mean time to recovery of all the recoveries
+	MTTRSeconds float64 `json:"mttrSeconds"`
+	// MaxRecoverySeconds is the longest recovery of all the targets
+	MaxRecoverySeconds float64 `json:"maxRecoverySeconds"`
+	// Unrecovered contains the targets, which are not recovered till the end of the experiment
+	Unrecovered []string `json:"unrecovered,omitempty"`
+}
+
+// Target contains the recoveries of the target workload
+type Target struct {
+	Kind        string     `json:"kind"`
+	Name        string     `json:"name"`
+	Namespace   string     `json:"namespace"`
+	Recoveries  []Recovery `json:"recoveries"`
+	MTTRSeconds float64    `json:"mttrSeconds"`
+}
+
+// Recovery contains the timestamps of the single disruption of the target workload
+type Recovery struct {
+	UnavailableAt   time.Time  `json:"unavailableAt"`
+	ScheduledAt     *time.Time `json:"replacementScheduledAt,omitempty"`
+	ReadyAt         *time.Time `json:"readyAt,omitempty"`
+	DurationSeconds float64    `json:"durationSeconds"`
+}
+
+// Summary returns the recoveries of all the disrupted workloads
+// the open recoveries are reported as unrecovered, their duration is measured till now
+func (tracker *Tracker) Summary() Summary {
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	summary := Summary{
+		InjectedAt: tracker.injectedAt,
+		Targets:    []Target{},
+	}
+
+	var total float64
+	var count int
+	for _, w := range tracker.workloads {
+		target := w.target
+		target.Recoveries = append([]Recovery{}, w.target.Recoveries...)
+		if w.open != nil {
+			open := *w.open
+			open.DurationSeconds = time.Since(open.UnavailableAt).Seconds()
+			target.Recoveries = append(target.Recoveries, open)
+			summary.Unrecovered = append(summary.Unrecovered, target.Kind+"/"+target.Name)
+		}
+		if len(target.Recoveries) == 0 {
+			continue
+		}
+
+		var targetTotal float64
+		for _, recovery := range target.Recoveries {
+			targetTotal += recovery.DurationSeconds
+			if recovery.DurationSeconds > summary.MaxRecoverySeconds {
+				summary.MaxRecoverySeconds = recovery.DurationSeconds
+			}
+		}
+		target.MTTRSeconds = targetTotal / float64(len(target.Recoveries))
+		total += targetTotal
+		count += len(target.Recoveries)
+		summary.Targets = append(summary.Targets, target)
+	}
+	if count != 0 {
+		summary.MTTRSeconds = total / float64(count)
+	}
+
+	sort.Slice(summary.Targets, func(i, j int) bool {
+		return summary.Targets[i].Kind+"/"+summary.Targets[i].Name < summary.Targets[j].Kind+"/"+summary.Targets[j].Name
+	})
+	sort.Strings(summary.Unrecovered)
+	return summary
+}
+
+// Violations returns the targets, which are not recovered or their recovery exceeds the slo (in sec)
+func (summary Summary) Violations(slo int) []string {
+	violations := []string{}
+	for _, target := range summary.Unrecovered {
+		violations = append(violations, fmt.Sprintf("%v is not recovered", target))
+	}
+	if slo <= 0 {
+		return violations
+	}
+	for _, target := range summary.Targets {
+		for _, recovery := range target.Recoveries {
+			if recovery.ReadyAt != nil && recovery.DurationSeconds > float64(slo) {
+				violations = append(violations, fmt.Sprintf("%v/%v recovered in %.2fs, exceeds the MTTR SLO of %vs", target.Kind, target.Name, recovery.DurationSeconds, slo))
+				break
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,277 @@
+package recovery
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Options contains the tunables of the recovery measurement
+type Options struct {
+	// Enabled enables the recovery measurement of the target workloads
+	Enabled bool `env:"MEASURE_MTTR" default:"true"`
+	// SLO is the maximum recovery time (in sec) of a target, the verdict is failed if the recovery exceeds it
+	SLO int `env:"MTTR_SLO" default:"0" validate:"duration=s"`
+}
+
+// Tracker measures the recovery of the target workloads, using the pod informer
+// each disruption of a workload (a pod deleted, not ready or restarted) opens a recovery,
+// which is closed once all the pods of the workload are ready again
+type Tracker struct {
+	namespace  string
+	injectedAt time.Time
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+
+	mu        sync.Mutex
+	workloads map[string]*workload
+}
+
+// workload contains the recovery state of the target workload
+type workload struct {
+	target   Target
+	baseline int
+	pods     map[clientTypes.UID]podState
+	open     *Recovery
+}
+
+// podState contains the state of the pod, used to detect the disruptions
+type podState struct {
+	ready     bool
+	restarts  int32
+	scheduled bool
+	firstSeen time.Time
+}
+
+// Start starts watching the pods of the given namespace and label, it waits for the informer cache to sync
+// the recoveries are recorded once the chaos injection is marked
+func Start(clients clients.ClientSets, namespace, label string) (*Tracker, error) {
+
+	tracker := &Tracker{
+		namespace: namespace,
+		stopCh:    make(chan struct{}),
+		workloads: map[string]*workload{},
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(clients.KubeClient, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *v1.ListOptions) {
+			options.LabelSelector = label
+		}))
+	podInformer := factory.Core().V1().Pods().Informer()
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				tracker.update(pod, false)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				tracker.update(pod, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				tracker.update(pod, true)
+			}
+		},
+	})
+
+	factory.Start(tracker.stopCh)
+	if !cache.WaitForCacheSync(tracker.stopCh, podInformer.HasSynced) {
+		tracker.Stop()
+		return nil, errors.Errorf("unable to sync the pods of %v namespace", namespace)
+	}
+	return tracker, nil
+}
+
+// MarkInjected records the chaos injection, the disruptions are recorded only after it
+// the ready pods at the injection are used as the baseline of the workloads, only the first call is recorded
+func (tracker *Tracker) MarkInjected() {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if !tracker.injectedAt.IsZero() {
+		return
+	}
+	for _, w := range tracker.workloads {
+		w.baseline = w.readyCount()
+	}
+	tracker.injectedAt = time.Now()
+}
+
+// Stop stops the informer, the open recoveries remain unrecovered
+func (tracker *Tracker) Stop() {
+	tracker.stopOnce.Do(func() { close(tracker.stopCh) })
+}
+
+// Wait waits till all the open recoveries are closed or the timeout is elapsed
+func (tracker *Tracker) Wait(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if tracker.openRecoveries() == 0 {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	log.Warnf("[Recovery]: %v workloads are not recovered within %v", tracker.openRecoveries(), timeout)
+}
+
+// openRecoveries returns the number of the workloads, which are not recovered yet
+func (tracker *Tracker) openRecoveries() int {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	count := 0
+	for _, workload := range tracker.workloads {
+		if workload.open != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// update records the state of the pod and opens or closes the recovery of its workload
+func (tracker *Tracker) update(pod *corev1.Pod, deleted bool) {
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	now := time.Now()
+	key := ownerKey(pod)
+	w, ok := tracker.workloads[key]
+	if !ok {
+		kind, name := owner(pod)
+		w = &workload{
+			target: Target{Kind: kind, Name: name, Namespace: pod.Namespace},
+			pods:   map[clientTypes.UID]podState{},
+		}
+		tracker.workloads[key] = w
+	}
+
+	previous, seen := w.pods[pod.UID]
+	current := podState{
+		ready:     !deleted && isReady(pod),
+		restarts:  restartCount(pod),
+		scheduled: pod.Spec.NodeName != "",
+		firstSeen: now,
+	}
+	if seen {
+		current.firstSeen = previous.firstSeen
+	}
+	if deleted {
+		delete(w.pods, pod.UID)
+	} else {
+		w.pods[pod.UID] = current
+	}
+
+	// the recoveries are recorded only after the chaos injection
+	if tracker.injectedAt.IsZero() {
+		return
+	}
+
+	// a ready pod becomes unavailable, if it is deleted, not ready or its containers are restarted
+	disrupted := seen && previous.ready && (!current.ready || current.restarts > previous.restarts)
+	if deleted && !seen {
+		disrupted = true
+	}
+	if disrupted && w.open == nil {
+		w.open = &Recovery{UnavailableAt: now}
+		log.Infof("[Recovery]: %v %v is unavailable", w.target.Kind, w.target.Name)
+	}
+
+	if w.open == nil {
+		return
+	}
+
+	// the replacement pod is the pod created after the disruption, which is scheduled on a node
+	if w.open.ScheduledAt == nil && !deleted && current.scheduled && !current.firstSeen.Before(w.open.UnavailableAt) {
+		scheduledAt := now
+		w.open.ScheduledAt = &scheduledAt
+	}
+
+	if w.isRecovered() {
+		readyAt := now
+		w.open.ReadyAt = &readyAt
+		w.open.DurationSeconds = readyAt.Sub(w.open.UnavailableAt).Seconds()
+		w.target.Recoveries = append(w.target.Recoveries, *w.open)
+		log.Infof("[Recovery]: %v %v is recovered in %.2fs", w.target.Kind, w.target.Name, w.open.DurationSeconds)
+		w.open = nil
+	}
+}
+
+// isRecovered checks whether all the pods of the workload are ready and the ready pods reached the baseline
+func (w *workload) isRecovered() bool {
+	for _, pod := range w.pods {
+		if !pod.ready {
+			return false
+		}
+	}
+	return w.readyCount() >= w.baseline
+}
+
+// readyCount returns the number of the ready pods of the workload
+func (w *workload) readyCount() int {
+	count := 0
+	for _, pod := range w.pods {
+		if pod.ready {
+			count++
+		}
+	}
+	return count
+}
+
+// isReady checks whether the pod is ready and not terminating
+func isReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// restartCount returns the total restarts of the containers of the pod
+func restartCount(pod *corev1.Pod) int32 {
+	var restarts int32
+	for _, container := range pod.Status.ContainerStatuses {
+		restarts += container.RestartCount
+	}
+	return restarts
+}
+
+// owner returns the kind and name of the workload owning the pod
+// the replicaset is resolved to its deployment using the pod-template-hash label
+func owner(pod *corev1.Pod) (string, string) {
+	controller := v1.GetControllerOf(pod)
+	if controller == nil {
+		return "pod", pod.Name
+	}
+	kind := strings.ToLower(controller.Kind)
+	if kind == "replicaset" {
+		if hash, ok := pod.Labels["pod-template-hash"]; ok && strings.HasSuffix(controller.Name, "-"+hash) {
+			return "deployment", strings.TrimSuffix(controller.Name, "-"+hash)
+		}
+	}
+	return kind, controller.Name
+}
+
+// ownerKey returns the unique key of the workload owning the pod
+func ownerKey(pod *corev1.Pod) string {
+	kind, name := owner(pod)
+	return pod.Namespace + "/" + kind + "/" + name
+}
@@ -0,0 +1,106 @@
+package result
+
+import (
+	"encoding/json"
+	"sync"
+
+	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+	retries "k8s.io/client-go/util/retry"
+)
+
+// annotators contains the annotator of each chaosresult, keyed by the namespace and name of the chaosresult
+var annotators = struct {
+	sync.Mutex
+	items map[string]*annotator
+}{items: map[string]*annotator{}}
+
+// annotator batches the annotations of the chaosresult
+// the annotations received while a patch is in flight are merged and patched together by the next patch
+type annotator struct {
+	mu       sync.Mutex
+	inflight bool
+	pending  *annotationBatch
+}
+
+// annotationBatch contains the annotations, which are patched together
+type annotationBatch struct {
+	annotations map[string]string
+	done        chan struct{}
+	err         error
+}
+
+// getAnnotator returns the annotator of the given chaosresult
+func getAnnotator(resultName, namespace string) *annotator {
+	annotators.Lock()
+	defer annotators.Unlock()
+	key := namespace + "/" + resultName
+	if _, ok := annotators.items[key]; !ok {
+		annotators.items[key] = &annotator{}
+	}
+	return annotators.items[key]
+}
+
+// annotate adds the annotations to the pending batch and waits till the batch is patched
+// the caller patches the batches itself, if there is no patch in flight
+func (a *annotator) annotate(resultName, namespace string, annotations map[string]string, clients clients.ClientSets) error {
+
+	a.mu.Lock()
+	if a.pending == nil {
+		a.pending = &annotationBatch{annotations: map[string]string{}, done: make(chan struct{})}
+	}
+	for key, value := range annotations {
+		a.pending.annotations[key] = value
+	}
+	batch := a.pending
+	if a.inflight {
+		a.mu.Unlock()
+		<-batch.done
+		return batch.err
+	}
+	a.inflight = true
+
+	// patch the pending batches, till there is no pending batch
+	for a.pending != nil {
+		current := a.pending
+		a.pending = nil
+		a.mu.Unlock()
+
+		current.err = patchAnnotations(resultName, namespace, current.annotations, clients)
+		close(current.done)
+
+		a.mu.Lock()
+	}
+	a.inflight = false
+	a.mu.Unlock()
+	return batch.err
+}
+
+// patchAnnotations patches the annotations of the chaosresult with the json merge patch
+// it retries on the conflict and the transient errors of the api server
+func patchAnnotations(resultName, namespace string, annotations map[string]string, clients clients.ClientSets) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = retries.OnError(retries.DefaultBackoff, isTransient, func() error {
+		_, err := clients.LitmusClient.ChaosResults(namespace).Patch(resultName, clientTypes.MergePatchType, patch)
+		return err
+	})
+	if err != nil {
+		return errors.Errorf("unable to annotate the %v chaosresult, err: %v", resultName, err)
+	}
+	return nil
+}
+
+// isTransient checks whether the patch can be retried for the given error
+func isTransient(err error) bool {
+	return k8serrors.IsConflict(err) || k8serrors.IsServerTimeout(err) || k8serrors.IsTimeout(err) ||
+		k8serrors.IsTooManyRequests(err) || k8serrors.IsInternalError(err) || k8serrors.IsServiceUnavailable(err)
+}
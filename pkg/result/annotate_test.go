@@ -0,0 +1,129 @@
+package result
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	chaosClient "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/typed/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"k8s.io/client-go/rest"
+)
+
+// resultServer is the api server stand-in, which records the merge patches of the chaosresult
+type resultServer struct {
+	mu        sync.Mutex
+	patches   []map[string]string
+	conflicts int
+	// hold blocks the first patch, till it is closed
+	hold     chan struct{}
+	received chan struct{}
+}
+
+func (server *resultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	patch := struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	server.mu.Lock()
+	first := len(server.patches) == 0 && server.conflicts == 0
+	if server.conflicts > 0 {
+		server.conflicts--
+		server.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Conflict","code":409}`))
+		return
+	}
+	server.patches = append(server.patches, patch.Metadata.Annotations)
+	server.mu.Unlock()
+
+	if first && server.hold != nil {
+		close(server.received)
+		<-server.hold
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"kind":"ChaosResult","apiVersion":"litmuschaos.io/v1alpha1","metadata":{"name":"engine-pod-delete","namespace":"litmus"}}`))
+}
+
+// newResultClients returns the clientsets, which point to the given api server stand-in
+func newResultClients(t *testing.T, server *resultServer) clients.ClientSets {
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	litmusClient, err := chaosClient.NewForConfig(&rest.Config{Host: httpServer.URL})
+	if err != nil {
+		t.Fatalf("unable to create the litmus client, err: %v", err)
+	}
+	return clients.ClientSets{LitmusClient: litmusClient}
+}
+
+func TestSetResultAnnotationsRetriesOnConflict(t *testing.T) {
+	server := &resultServer{conflicts: 2}
+	clients := newResultClients(t, server)
+
+	if err := SetResultAnnotations("engine-pod-delete", "litmus", map[string]string{"pod/nginx-1": "injected"}, clients); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(server.patches) != 1 || server.patches[0]["pod/nginx-1"] != "injected" {
+		t.Fatalf("expected the patch to succeed after the conflicts, got %v", server.patches)
+	}
+}
+
+func TestSetResultAnnotationsBatchesConcurrentCalls(t *testing.T) {
+	server := &resultServer{hold: make(chan struct{}), received: make(chan struct{})}
+	clients := newResultClients(t, server)
+
+	errs := make(chan error, 3)
+	go func() {
+		errs <- SetResultAnnotations("engine-pod-delete", "litmus", map[string]string{"pod/nginx-1": "injected"}, clients)
+	}()
+	<-server.received
+
+	// the annotations received while the first patch is in flight are patched together
+	for _, name := range []string{"pod/nginx-2", "pod/nginx-3"} {
+		name := name
+		go func() {
+			errs <- SetResultAnnotations("engine-pod-delete", "litmus", map[string]string{name: "injected"}, clients)
+		}()
+	}
+	a := getAnnotator("engine-pod-delete", "litmus")
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		a.mu.Lock()
+		queued := a.pending != nil && len(a.pending.annotations) == 2
+		a.mu.Unlock()
+		if queued {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the annotations to be queued behind the inflight patch")
+		}
+	}
+	close(server.hold)
+
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(server.patches) != 2 {
+		t.Fatalf("expected 2 patches, got %v", server.patches)
+	}
+	if len(server.patches[1]) != 2 || server.patches[1]["pod/nginx-2"] != "injected" || server.patches[1]["pod/nginx-3"] != "injected" {
+		t.Fatalf("expected the queued annotations to be merged into one patch, got %v", server.patches[1])
+	}
+}
@@ -1,8 +1,7 @@
 package result
 
 import (
-	"bytes"
-	"os/exec"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -13,8 +12,11 @@ import (
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/abort"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -25,6 +27,17 @@ import (
 func ChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, state string) error {
 	experimentLabel := map[string]string{}
 
+	// update the phase inside the default log fields
+	switch state {
+	case "SOT":
+		log.SetPhase("PreChaos")
+	case "EOT":
+		log.SetPhase(types.Summary)
+		metrics.SetInjectionEnd(chaosDetails, false)
+	}
+	// the verdict is recorded after the chaosresult update, as it is updated based on the probe status
+	defer func() { metrics.SetVerdict(chaosDetails, string(resultDetails.Verdict)) }()
+
 	// It try to get the chaosresult, if available
 	// it will retries until it got chaos result or met the timeout(3 mins)
 	var result *v1alpha1.ChaosResult
@@ -128,17 +141,18 @@ func InitializeChaosResult(chaosDetails *types.ChaosDetails, clients clients.Cli
 }
 
 //GetProbeStatus fetch status of all probes
+// it returns false, if any critical probe is failed
 func GetProbeStatus(resultDetails *types.ResultDetails) (bool, []v1alpha1.ProbeStatus) {
 	isAllProbePassed := true
 
 	probeStatus := []v1alpha1.ProbeStatus{}
-	for _, probe := range resultDetails.ProbeDetails {
+	for _, probe := range resultDetails.GetProbeDetails() {
 		probes := v1alpha1.ProbeStatus{}
 		probes.Name = probe.Name
 		probes.Type = probe.Type
 		probes.Status = probe.Status
 		probeStatus = append(probeStatus, probes)
-		if probe.Phase == "Failed" {
+		if probe.Phase == "Failed" && probe.Critical {
 			isAllProbePassed = false
 		}
 	}
@@ -170,27 +184,24 @@ func PatchChaosResult(result *v1alpha1.ChaosResult, clients clients.ClientSets,
 			resultDetails.Verdict = "Fail"
 			result.Status.ExperimentStatus.Verdict = "Fail"
 		}
+		// the failures of the non-critical probes only lower the weighted resilience score
+		score, contributions := GetResilienceScore(resultDetails)
 		switch strings.ToLower(string(resultDetails.Verdict)) {
 		case "pass":
-			result.Status.ExperimentStatus.ProbeSuccessPercentage = "100"
+			result.Status.ExperimentStatus.ProbeSuccessPercentage = getProbeSuccessPercentage(resultDetails, score, "100")
 			result.Status.History.PassedRuns++
 		case "fail":
 			result.Status.History.FailedRuns++
 			probe.SetProbeVerdictAfterFailure(resultDetails)
-			if len(resultDetails.ProbeDetails) != 0 {
-				result.Status.ExperimentStatus.ProbeSuccessPercentage = strconv.Itoa((resultDetails.PassedProbeCount * 100) / len(resultDetails.ProbeDetails))
-			} else {
-				result.Status.ExperimentStatus.ProbeSuccessPercentage = "0"
-			}
+			result.Status.ExperimentStatus.ProbeSuccessPercentage = getProbeSuccessPercentage(resultDetails, score, "0")
 		case "stopped":
 			result.Status.History.StoppedRuns++
 			probe.SetProbeVerdictAfterFailure(resultDetails)
-			if len(resultDetails.ProbeDetails) != 0 {
-				result.Status.ExperimentStatus.ProbeSuccessPercentage = strconv.Itoa((resultDetails.PassedProbeCount * 100) / len(resultDetails.ProbeDetails))
-			} else {
-				result.Status.ExperimentStatus.ProbeSuccessPercentage = "0"
-			}
+			result.Status.ExperimentStatus.ProbeSuccessPercentage = getProbeSuccessPercentage(resultDetails, score, "0")
 		}
+		// the probe status is derived again, as the unrun probes are updated after the failure
+		_, result.Status.ProbeStatus = GetProbeStatus(resultDetails)
+		result.ObjectMeta.Annotations = setProbeContributions(result.ObjectMeta.Annotations, contributions)
 	default:
 		result.Status.ExperimentStatus.ProbeSuccessPercentage = "Awaited"
 	}
@@ -209,6 +220,36 @@ func PatchChaosResult(result *v1alpha1.ChaosResult, clients clients.ClientSets,
 		})
 }
 
+// CompleteResult derives the final verdict, probe status and probe success percentage of the experiment
+// the probe contributions are added to the given annotations
+// it is used in the standalone mode, where the chaosresult is not available
+func CompleteResult(resultDetails *types.ResultDetails, annotations map[string]string) ([]v1alpha1.ProbeStatus, string, map[string]string) {
+
+	resultDetails.Phase = v1alpha1.ResultPhaseCompleted
+	if isAllProbePassed, _ := GetProbeStatus(resultDetails); !isAllProbePassed {
+		resultDetails.Verdict = "Fail"
+	}
+	score, contributions := GetResilienceScore(resultDetails)
+
+	probeSuccessPercentage := getProbeSuccessPercentage(resultDetails, score, "100")
+	if strings.ToLower(string(resultDetails.Verdict)) != "pass" {
+		probe.SetProbeVerdictAfterFailure(resultDetails)
+		probeSuccessPercentage = getProbeSuccessPercentage(resultDetails, score, "0")
+	}
+	// the probe status is derived again, as the unrun probes are updated after the failure
+	_, probeStatus := GetProbeStatus(resultDetails)
+	return probeStatus, probeSuccessPercentage, setProbeContributions(annotations, contributions)
+}
+
+// getProbeSuccessPercentage returns the weighted resilience score, rounded to the nearest integer, as the probe success percentage
+// it returns the default value, if no probes are provided
+func getProbeSuccessPercentage(resultDetails *types.ResultDetails, score float64, defaultValue string) string {
+	if len(resultDetails.GetProbeDetails()) == 0 {
+		return defaultValue
+	}
+	return strconv.Itoa(int(math.Round(score)))
+}
+
 // SetResultUID sets the ResultUID into the ResultDetails structure
 func SetResultUID(resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
@@ -224,6 +265,12 @@ func SetResultUID(resultDetails *types.ResultDetails, clients clients.ClientSets
 //RecordAfterFailure update the chaosresult and create the summary events
 func RecordAfterFailure(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, failStep string, clients clients.ClientSets, eventsDetails *types.EventDetails) {
 
+	// the chaosresult is already updated by the abort watcher, if the experiment is aborted
+	if abort.IsAborted() {
+		log.Infof("[Abort]: Skipping the chaosresult update, the experiment has been aborted")
+		return
+	}
+
 	// update the chaos result
 	types.SetResultAfterCompletion(resultDetails, "Fail", "Completed", failStep)
 	ChaosResult(chaosDetails, clients, resultDetails, "EOT")
@@ -241,6 +288,23 @@ func RecordAfterFailure(chaosDetails *types.ChaosDetails, resultDetails *types.R
 
 }
 
+// RecordConfigFailure records the invalid configuration of the experiment as the pre-chaos failure
+// the chaosresult is created first, as the configuration is loaded before the start of the experiment
+// all the given validation errors are recorded together inside the fail step
+func RecordConfigFailure(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, errs ...error) {
+
+	err := config.Merge(errs...)
+	log.Errorf("Unable to load the configuration, err: %v", err)
+
+	types.SetResultAttributes(resultDetails, *chaosDetails)
+	if err := ChaosResult(chaosDetails, clients, resultDetails, "SOT"); err != nil {
+		log.Errorf("Unable to Create the Chaos Result, err: %v", err)
+		return
+	}
+	failStep := "[pre-chaos]: Invalid configuration of " + chaosDetails.ExperimentName + " experiment, err: " + err.Error()
+	RecordAfterFailure(chaosDetails, resultDetails, failStep, clients, eventsDetails)
+}
+
 // updateHistory initialise the history for the older results
 func updateHistory(result *v1alpha1.ChaosResult) {
 	if reflect.DeepEqual(result.Status.History, v1alpha1.HistoryDetails{}) {
@@ -255,17 +319,14 @@ func updateHistory(result *v1alpha1.ChaosResult) {
 }
 
 // AnnotateChaosResult annotate the chaosResult for the chaos status
-// using kubectl cli to annotate the chaosresult as it will automatically handle the race condition in case of multiple helpers
-func AnnotateChaosResult(resultName, namespace, status, kind, name string) error {
-	command := exec.Command("kubectl", "annotate", "chaosresult", resultName, "-n", namespace, kind+"/"+name+"="+status, "--overwrite")
-	var out, stderr bytes.Buffer
-	command.Stdout = &out
-	command.Stderr = &stderr
-	if err := command.Run(); err != nil {
-		log.Infof("Error String: %v", stderr.String())
-		return errors.Errorf("unable to annotate the %v chaosresult, err: %v", resultName, err)
-	}
-	return nil
+func AnnotateChaosResult(resultName, namespace, status, kind, name string, clients clients.ClientSets) error {
+	return SetResultAnnotations(resultName, namespace, map[string]string{kind + "/" + name: status}, clients)
+}
+
+// SetResultAnnotations sets the given annotations on the chaosresult, using the json merge patch
+// the annotations of the concurrent calls are batched into a single patch, which is retried on the conflict and transient errors
+func SetResultAnnotations(resultName, namespace string, annotations map[string]string, clients clients.ClientSets) error {
+	return getAnnotator(resultName, namespace).annotate(resultName, namespace, annotations, clients)
 }
 
 // GetChaosStatus get the chaos status based on annotations in chaosresult
@@ -0,0 +1,74 @@
+package result
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// ProbeContributionsAnnotation is the chaosresult annotation, which contains the weight, criticality and contribution of each probe
+const ProbeContributionsAnnotation = "litmuschaos.io/probe-contributions"
+
+// ProbeContribution contains the share of the probe in the weighted resilience score
+type ProbeContribution struct {
+	Weight   int  `json:"weight"`
+	Critical bool `json:"critical"`
+	// Contribution is the percentage added by the probe to the resilience score, rounded to two decimals
+	Contribution float64 `json:"contribution"`
+}
+
+// GetResilienceScore returns the weighted resilience score of the experiment along with the contribution of each probe
+// each probe, which passed in the final phase of its mode, contributes its share of the total weight
+// the failed and unrun probes contribute nothing
+func GetResilienceScore(resultDetails *types.ResultDetails) (float64, map[string]ProbeContribution) {
+	probeDetails := resultDetails.GetProbeDetails()
+	contributions := map[string]ProbeContribution{}
+
+	totalWeight := 0
+	for _, probe := range probeDetails {
+		totalWeight += getWeight(probe)
+	}
+	if totalWeight == 0 {
+		return 0, contributions
+	}
+
+	passedWeight := 0
+	for _, probe := range probeDetails {
+		contribution := ProbeContribution{Weight: getWeight(probe), Critical: probe.Critical}
+		// the edge probes are passed only after passing the postchaos check, failure in any phase voids the pass
+		if probe.Passed && probe.Phase != "Failed" {
+			passedWeight += getWeight(probe)
+			contribution.Contribution = math.Round(float64(getWeight(probe)*10000)/float64(totalWeight)) / 100
+		}
+		contributions[probe.Name] = contribution
+	}
+	return float64(passedWeight*100) / float64(totalWeight), contributions
+}
+
+// setProbeContributions records the contributions of the probes inside the annotations
+// the annotations are returned, as they are initialised if not present
+func setProbeContributions(annotations map[string]string, contributions map[string]ProbeContribution) map[string]string {
+	if len(contributions) == 0 {
+		return annotations
+	}
+	data, err := json.Marshal(contributions)
+	if err != nil {
+		log.Errorf("Unable to encode the probe contributions, err: %v", err)
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ProbeContributionsAnnotation] = string(data)
+	return annotations
+}
+
+// getWeight returns the weight of the probe, it defaults to 1
+func getWeight(probe types.ProbeDetails) int {
+	if probe.Weight <= 0 {
+		return 1
+	}
+	return probe.Weight
+}
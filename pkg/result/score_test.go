@@ -0,0 +1,63 @@
+package result
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+func TestGetResilienceScore(t *testing.T) {
+	resultDetails := &types.ResultDetails{
+		ProbeDetails: []types.ProbeDetails{
+			// passed in the final phase of its mode
+			{Name: "api-health", Phase: "Passed", Passed: true, Weight: 1},
+			// passed the prechaos check of the edge probe, but never reached the postchaos check
+			{Name: "db-health", Phase: "Passed", Weight: 1},
+			// passed the postchaos check after failing in the prechaos check
+			{Name: "cache-health", Phase: "Failed", Passed: true, Weight: 1, Critical: true},
+		},
+	}
+
+	score, contributions := GetResilienceScore(resultDetails)
+	if got := getProbeSuccessPercentage(resultDetails, score, "0"); got != "33" {
+		t.Fatalf("expected 33 probe success percentage, got %v", got)
+	}
+	if contributions["api-health"].Contribution != 33.33 {
+		t.Fatalf("expected 33.33 contribution of the passed probe, got %v", contributions["api-health"].Contribution)
+	}
+	if contributions["db-health"].Contribution != 0 || contributions["cache-health"].Contribution != 0 {
+		t.Fatalf("expected no contribution of the unfinished and failed probes, got %+v", contributions)
+	}
+	if !contributions["cache-health"].Critical {
+		t.Fatalf("expected the criticality of the probe to be recorded")
+	}
+}
+
+func TestGetProbeSuccessPercentageRounds(t *testing.T) {
+	resultDetails := &types.ResultDetails{
+		ProbeDetails: []types.ProbeDetails{
+			{Name: "a", Phase: "Passed", Passed: true, Weight: 2},
+			{Name: "b", Phase: "Failed", Weight: 1},
+		},
+	}
+	score, _ := GetResilienceScore(resultDetails)
+	if got := getProbeSuccessPercentage(resultDetails, score, "0"); got != "67" {
+		t.Fatalf("expected the 66.67 score to be rounded to 67, got %v", got)
+	}
+}
+
+func TestSetProbeContributions(t *testing.T) {
+	annotations := setProbeContributions(nil, map[string]ProbeContribution{"a": {Weight: 2, Contribution: 100}})
+
+	contributions := map[string]ProbeContribution{}
+	if err := json.Unmarshal([]byte(annotations[ProbeContributionsAnnotation]), &contributions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contributions["a"].Weight != 2 || contributions["a"].Contribution != 100 {
+		t.Fatalf("unexpected contributions: %+v", contributions)
+	}
+	if annotations := setProbeContributions(nil, nil); annotations != nil {
+		t.Fatalf("expected no annotations without the probes, got %v", annotations)
+	}
+}
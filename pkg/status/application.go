@@ -8,6 +8,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/annotation"
+	"github.com/litmuschaos/litmus-go/pkg/utils/apptargets"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	logrus "github.com/sirupsen/logrus"
@@ -18,8 +19,28 @@ import (
 // AUTStatusCheck checks the status of application under test
 // if annotationCheck is true, it will check the status of the annotated pod only
 // else it will check status of all pods with matching label
+// the applications of all the targets are checked, if the applications are spread across multiple targets
 func AUTStatusCheck(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	if chaosDetails.AppDetail.IsMultiTarget() {
+		targets, err := apptargets.Resolve(clients, chaosDetails.AppDetail)
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			log.Infof("[Status]: Checking the status of the applications in %v namespace with %v label", target.Namespace, target.Label)
+			if err := autStatusCheck(target.Namespace, target.Label, containerName, timeout, delay, clients, apptargets.WithTarget(chaosDetails, target)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return autStatusCheck(appNs, appLabel, containerName, timeout, delay, clients, chaosDetails)
+}
+
+// autStatusCheck checks the status of the application under test with the given namespace and label
+func autStatusCheck(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
 	switch chaosDetails.AppDetail.AnnotationCheck {
 	case true:
 		return AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName, timeout, delay, clients, chaosDetails)
@@ -47,13 +68,11 @@ func AUTStatusCheck(appNs, appLabel, containerName string, timeout, delay int, c
 // AnnotatedApplicationsStatusCheck checks the status of all the annotated applications with matching label
 func AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
+	return statusRetry(timeout, delay).
 		Try(func(attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil {
-				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
+				return errors.Wrapf(err, "Unable to find the pods with matching labels")
 			} else if len(podList.Items) == 0 {
 				errors.Errorf("Unable to find the pods with matching labels")
 			}
@@ -71,7 +90,7 @@ func AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName string, tim
 					case "":
 						for _, container := range pod.Status.ContainerStatuses {
 							if container.State.Terminated != nil {
-								return errors.Errorf("container is in terminated state")
+								return retry.Permanent(errors.Errorf("container is in terminated state"))
 							}
 							if !container.Ready {
 								return errors.Errorf("containers are not yet in running state")
@@ -83,7 +102,7 @@ func AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName string, tim
 						for _, container := range pod.Status.ContainerStatuses {
 							if containerName == container.Name {
 								if container.State.Terminated != nil {
-									return errors.Errorf("container is in terminated state")
+									return retry.Permanent(errors.Errorf("container is in terminated state"))
 								}
 								if !container.Ready {
 									return errors.Errorf("containers are not yet in running state")
@@ -142,13 +161,11 @@ func CheckAuxiliaryApplicationStatus(AuxiliaryAppDetails string, timeout, delay
 
 // CheckPodStatusPhase checks the status of the application pod
 func CheckPodStatusPhase(appNs, appLabel string, timeout, delay int, clients clients.ClientSets, states ...string) error {
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
+	return statusRetry(timeout, delay).
 		Try(func(attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil {
-				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
+				return errors.Wrapf(err, "Unable to find the pods with matching labels")
 			} else if len(podList.Items) == 0 {
 				errors.Errorf("Unable to find the pods with matching labels")
 			}
@@ -183,13 +200,11 @@ func CheckPodStatus(appNs, appLabel string, timeout, delay int, clients clients.
 // CheckContainerStatus checks the status of the application container
 func CheckContainerStatus(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets) error {
 
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
+	return statusRetry(timeout, delay).
 		Try(func(attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil {
-				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
+				return errors.Wrapf(err, "Unable to find the pods with matching labels")
 			} else if len(podList.Items) == 0 {
 				errors.Errorf("Unable to find the pods with matching labels")
 			}
@@ -214,7 +229,7 @@ func validateContainerStatus(containerName, podName string, ContainerStatuses []
 	for _, container := range ContainerStatuses {
 		if container.Name == containerName {
 			if container.State.Terminated != nil {
-				return errors.Errorf("container is in terminated state")
+				return retry.Permanent(errors.Errorf("container is in terminated state"))
 			}
 			if !container.Ready {
 				return errors.Errorf("containers are not yet in running state")
@@ -248,7 +263,7 @@ func WaitForCompletion(appNs, appLabel string, clients clients.ClientSets, durat
 		Try(func(attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil {
-				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
+				return errors.Wrapf(err, "Unable to find the pods with matching labels")
 			} else if len(podList.Items) == 0 {
 				errors.Errorf("Unable to find the pods with matching labels")
 			}
@@ -288,13 +303,11 @@ func WaitForCompletion(appNs, appLabel string, clients clients.ClientSets, durat
 // and wait until the helper pod comes to one of the {running,completed,failed} states
 func CheckHelperStatus(appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
 
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
+	return statusRetry(timeout, delay).
 		Try(func(attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil {
-				return errors.Errorf("unable to find the pods with matching labels, err: %v", err)
+				return errors.Wrapf(err, "unable to find the pods with matching labels")
 			} else if len(podList.Items) == 0 {
 				errors.Errorf("Unable to find the pods with matching labels")
 			}
@@ -6,20 +6,34 @@ import (
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/openebs/maya/pkg/util/retry"
+	"github.com/litmuschaos/litmus-go/pkg/utils/abort"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	logrus "github.com/sirupsen/logrus"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// CheckNodeStatus checks the status of the node
-func CheckNodeStatus(nodes string, timeout, delay int, clients clients.ClientSets) error {
+// statusJitter is the fraction by which the delay between the status checks is randomized
+// it spreads the api calls of the concurrent checks
+const statusJitter = 0.1
 
-	nodeList := apiv1.NodeList{}
+// statusRetry returns the retry model of the status checks
+// the checks are retried until the timeout, unless the api rejects them permanently or the experiment is aborted
+func statusRetry(timeout, delay int) *retry.Model {
 	return retry.
+		Context(abort.Context()).
 		Times(uint(timeout / delay)).
 		Wait(time.Duration(delay) * time.Second).
+		Jitter(statusJitter).
+		StopIf(retry.IsPermanentAPIError)
+}
+
+// CheckNodeStatus checks the status of the node
+func CheckNodeStatus(nodes string, timeout, delay int, clients clients.ClientSets) error {
+
+	nodeList := apiv1.NodeList{}
+	return statusRetry(timeout, delay).
 		Try(func(attempt uint) error {
 			if nodes != "" {
 				targetNodes := strings.Split(nodes, ",")
@@ -58,9 +72,7 @@ func CheckNodeStatus(nodes string, timeout, delay int, clients clients.ClientSet
 
 // CheckNodeNotReadyState check for node to be in not ready state
 func CheckNodeNotReadyState(nodeName string, timeout, delay int, clients clients.ClientSets) error {
-	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
+	return statusRetry(timeout, delay).
 		Try(func(attempt uint) error {
 			node, err := clients.KubeClient.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
 			if err != nil {
@@ -0,0 +1,369 @@
+package types
+
+// ProbeAttributes contains details of probe, which can be applied on the experiments
+// It mirrors the probe schema of the chaosengine and extends it with the inputs
+// which are not available inside the chaos-operator api types yet
+type ProbeAttributes struct {
+	// Name of probe
+	Name string `json:"name,omitempty"`
+	// Type of probe
+	Type string `json:"type,omitempty"`
+	// inputs needed for the k8s probe
+	K8sProbeInputs K8sProbeInputs `json:"k8sProbe/inputs,omitempty"`
+	// inputs needed for the http probe
+	HTTPProbeInputs HTTPProbeInputs `json:"httpProbe/inputs,omitempty"`
+	// inputs needed for the cmd probe
+	CmdProbeInputs CmdProbeInputs `json:"cmdProbe/inputs,omitempty"`
+	// inputs needed for the prometheus probe
+	PromProbeInputs PromProbeInputs `json:"promProbe/inputs,omitempty"`
+	// inputs needed for the grpc probe
+	GRPCProbeInputs GRPCProbeInputs `json:"grpcProbe/inputs,omitempty"`
+	// inputs needed for the watch probe
+	WatchProbeInputs WatchProbeInputs `json:"watchProbe/inputs,omitempty"`
+	// RunProperty contains timeout, retry and interval for the probe
+	RunProperties RunProperty `json:"runProperties,omitempty"`
+	// mode for k8s probe
+	// it can be SOT, EOT, Edge
+	Mode string `json:"mode,omitempty"`
+	// Data contains the manifest/data for the resource, which need to be created
+	// it supported for create operation only
+	Data string `json:"data,omitempty"`
+	// DependsOn contains the names of the probes, which should be completed before the probe
+	// it is used to consume the registered output of the other probes in the same phase
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Weight of the probe in the resilience score, it defaults to 1
+	Weight int `json:"weight,omitempty"`
+	// Critical flag to fail the experiment, if the probe fails
+	// the failures of the non-critical probes only lower the resilience score
+	// it defaults to true
+	Critical *bool `json:"critical,omitempty"`
+}
+
+// K8sProbeInputs contains all the inputs required for k8s probe
+type K8sProbeInputs struct {
+	// group of the resource
+	Group string `json:"group,omitempty"`
+	// apiversion of the resource
+	Version string `json:"version,omitempty"`
+	// kind of resource
+	Resource string `json:"resource,omitempty"`
+	// namespace of the resource
+	Namespace string `json:"namespace,omitempty"`
+	// fieldselector to get the resource using fields selector
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	// labelselector to get the resource using labels selector
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Operation performed by the k8s probe
+	// it can be create, delete, present, absent
+	Operation string `json:"operation,omitempty"`
+	// JSONPath expression evaluated against each listed resource, used with the present operation
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Comparator check for the value extracted by the jsonPath
+	// all the listed resources should satisfy it, if count is not provided
+	Comparator ComparatorInfo `json:"comparator,omitempty"`
+	// Count check for the number of listed resources, which satisfy the jsonPath and comparator
+	Count ComparatorInfo `json:"count,omitempty"`
+}
+
+// CmdProbeInputs contains all the inputs required for cmd probe
+type CmdProbeInputs struct {
+	// Command need to be executed for the probe
+	Command string `json:"command,omitempty"`
+	// Comparator check for the correctness of the probe output
+	Comparator ComparatorInfo `json:"comparator,omitempty"`
+	// The source where we have to run the command
+	// It will run in inline(inside experiment itself) mode if source is nil
+	Source SourceDetails `json:"source,omitempty"`
+}
+
+// SourceDetails contains source details of the cmdProbe
+type SourceDetails struct {
+	// Image of the source pod
+	Image string `json:"image,omitempty"`
+	// HostNetwork define the hostNetwork of the external pod
+	// it supports boolean values and default value is false
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+}
+
+// PromProbeInputs contains all the inputs required for prometheus probe
+type PromProbeInputs struct {
+	// Endpoint for the prometheus probe
+	Endpoint string `json:"endpoint,omitempty"`
+	// Query to get promethus metrices
+	Query string `json:"query,omitempty"`
+	// QueryPath contains filePath, which contains prometheus query
+	QueryPath string `json:"queryPath,omitempty"`
+	// Comparator check for the correctness of the probe output
+	Comparator ComparatorInfo `json:"comparator,omitempty"`
+	// Auth contains the credentials used to authenticate with the prometheus endpoint
+	Auth AuthDetails `json:"auth,omitempty"`
+	// TLS contains the tls configuration for the prometheus endpoint
+	TLS TLSDetails `json:"tls,omitempty"`
+	// Range converts the query into a range query over the chaos window
+	// the samples are aggregated into a single value before comparison
+	Range PromRangeDetails `json:"range,omitempty"`
+}
+
+// PromRangeDetails contains the details of the prometheus range query
+type PromRangeDetails struct {
+	// Window contains the duration (in sec) of the range, ending at the time of evaluation
+	// it defaults to the total chaos duration
+	Window int `json:"window,omitempty"`
+	// Step contains the query resolution step (in sec)
+	Step int `json:"step,omitempty"`
+	// Aggregation used to reduce the samples into a single value
+	// it supports min, max, avg
+	Aggregation string `json:"aggregation,omitempty"`
+}
+
+// GRPCProbeInputs contains all the inputs required for grpc probe
+type GRPCProbeInputs struct {
+	// Address of the grpc server, in host:port format
+	Address string `json:"address,omitempty"`
+	// Service contains the name of the service for the health check
+	// the overall health of the server is checked, if service is not provided
+	Service string `json:"service,omitempty"`
+	// Method contains the full name of the unary method, in package.Service/Method format
+	// it calls the grpc.health.v1.Health/Check method, if method is not provided
+	// the method is resolved through the server reflection
+	Method string `json:"method,omitempty"`
+	// Request contains the JSON representation of the request message of the method
+	Request string `json:"request,omitempty"`
+	// Metadata contains the headers sent with the grpc request
+	Metadata []HTTPHeader `json:"metadata,omitempty"`
+	// UseTLS flag to connect with the grpc server over tls
+	UseTLS bool `json:"useTLS,omitempty"`
+	// TLS contains the tls configuration for the grpc server, it is used only if useTLS is true
+	TLS TLSDetails `json:"tls,omitempty"`
+	// ResponseTimeout contains the grpc response timeout (in ms)
+	ResponseTimeout int `json:"responseTimeout,omitempty"`
+	// JSONPath contains the jsonpath expression used to extract the value from the JSON representation of the response
+	// the entire response is compared, if jsonPath is not provided
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Comparator check for the correctness of the response
+	// for health check, it defaults to the string comparison with SERVING status
+	Comparator ComparatorInfo `json:"comparator,omitempty"`
+}
+
+// WatchProbeInputs contains all the inputs required for watch probe
+// it watches the kubernetes events and the application logs during the chaos window
+type WatchProbeInputs struct {
+	// Namespace to be watched, it defaults to the application namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Events contains the details of the kubernetes events to be watched
+	Events EventWatchDetails `json:"events,omitempty"`
+	// Containers contains the details of the container terminations to be watched
+	Containers ContainerWatchDetails `json:"containers,omitempty"`
+	// Logs contains the details of the application logs to be watched
+	Logs LogWatchDetails `json:"logs,omitempty"`
+	// RecordOnly flag to only record the matched events and log lines inside the probe status
+	// the thresholds are not evaluated, if it is true
+	RecordOnly bool `json:"recordOnly,omitempty"`
+}
+
+// EventWatchDetails contains the details of the kubernetes events to be watched
+type EventWatchDetails struct {
+	// Reasons of the warning events to be matched, e.g. BackOff, FailedScheduling, Unhealthy
+	// the events are not watched, if reasons are not provided
+	Reasons []string `json:"reasons,omitempty"`
+	// Threshold contains the maximum number of the matched events
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// ContainerWatchDetails contains the details of the container terminations to be watched
+// the terminated reasons are reported inside the container statuses of the pods, instead of the events
+type ContainerWatchDetails struct {
+	// TerminatedReasons of the container terminations to be matched, e.g. OOMKilled, Error
+	// the container statuses are not watched, if terminated reasons are not provided
+	TerminatedReasons []string `json:"terminatedReasons,omitempty"`
+	// LabelSelector of the application pods, it defaults to the application label
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Threshold contains the maximum number of the matched container terminations
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// LogWatchDetails contains the details of the application logs to be watched
+type LogWatchDetails struct {
+	// Regex to be matched against the log lines
+	// the logs are not watched, if regex is not provided
+	Regex string `json:"regex,omitempty"`
+	// LabelSelector of the application pods, it defaults to the application label
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Container name of the application pods, all the containers are watched if not provided
+	Container string `json:"container,omitempty"`
+	// Threshold contains the maximum number of the matched log lines
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// AuthDetails contains the authentication details for the probe endpoints
+type AuthDetails struct {
+	// Type of the authentication
+	// it supports Bearer and Basic
+	Type string `json:"type,omitempty"`
+	// Credentials contains the bearer token or the username:password for basic auth
+	Credentials string `json:"credentials,omitempty"`
+	// CredentialsFile contains the path of the file which contains the credentials
+	// it will be used, if credentials are not provided
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+}
+
+// TLSDetails contains the tls configuration for the probe endpoints
+type TLSDetails struct {
+	// CACertFile contains the path of the ca certificate used to verify the server
+	CACertFile string `json:"caCertFile,omitempty"`
+	// CertFile contains the path of the client certificate
+	CertFile string `json:"certFile,omitempty"`
+	// KeyFile contains the path of the client key
+	KeyFile string `json:"keyFile,omitempty"`
+	// InsecureSkipVerify flag to skip certificate checks
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ComparatorInfo contains the comparator details
+type ComparatorInfo struct {
+	// Type of data
+	// it can be int, float, string
+	Type string `json:"type,omitempty"`
+	// Criteria for matching data
+	// it supports >=, <=, ==, >, <, != for int and float
+	// it supports equal, notEqual, contains for string
+	Criteria string `json:"criteria,omitempty"`
+	// Value contains relative value for criteria
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPProbeInputs contains all the inputs required for http probe
+type HTTPProbeInputs struct {
+	// URL which needs to curl, to check the status
+	URL string `json:"url,omitempty"`
+	// InsecureSkipVerify flag to skip certificate checks
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// Method define the http method, it can be get, post or any other custom method
+	Method HTTPMethod `json:"method,omitempty"`
+	// ResponseTimeout contains the http response timeout
+	ResponseTimeout int `json:"responseTimeout,omitempty"`
+	// Headers contains the request headers sent with the http request
+	Headers []HTTPHeader `json:"headers,omitempty"`
+	// Auth contains the credentials used to authenticate with the http endpoint
+	Auth AuthDetails `json:"auth,omitempty"`
+	// TLS contains the tls configuration for the http endpoint
+	TLS TLSDetails `json:"tls,omitempty"`
+	// ResponseBody contains the assertion on the http response body
+	ResponseBody ResponseBodyDetails `json:"responseBody,omitempty"`
+	// Latency contains the latency SLO of the http requests
+	// it is evaluated over all the requests sent in continuous and onchaos modes
+	Latency LatencySLO `json:"latency,omitempty"`
+}
+
+// LatencySLO contains the percentile thresholds for the latency of the http requests
+type LatencySLO struct {
+	// Thresholds contains the list of percentile thresholds
+	// probe fails, if any of the percentile crosses the threshold
+	Thresholds []LatencyThreshold `json:"thresholds,omitempty"`
+}
+
+// LatencyThreshold contains the latency threshold for the percentile
+type LatencyThreshold struct {
+	// Percentile of the latency samples, like p50, p90, p99, p99.9
+	Percentile string `json:"percentile,omitempty"`
+	// Threshold contains the upper bound (in ms) for the latency of the percentile
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// HTTPMethod define the http method details
+type HTTPMethod struct {
+	Get  GetMethod  `json:"get,omitempty"`
+	Post PostMethod `json:"post,omitempty"`
+	// Custom define the methods other than get and post, like PUT, PATCH, DELETE
+	Custom CustomMethod `json:"custom,omitempty"`
+}
+
+// CustomMethod define the arbitrary http method
+type CustomMethod struct {
+	// Name of the http method, like PUT, PATCH, DELETE, HEAD
+	Name string `json:"name,omitempty"`
+	// ContentType contains content type for http body data
+	ContentType string `json:"contentType,omitempty"`
+	// Body contains http body for the request
+	Body string `json:"body,omitempty"`
+	// BodyPath contains filePath, which contains http body
+	BodyPath string `json:"bodyPath,omitempty"`
+	// Criteria for matching data
+	// it supports  == != operations
+	Criteria string `json:"criteria,omitempty"`
+	// Value contains relative value for criteria
+	ResponseCode string `json:"responseCode,omitempty"`
+}
+
+// HTTPHeader contains the details of the http request header
+type HTTPHeader struct {
+	// Name of the header
+	Name string `json:"name,omitempty"`
+	// Value of the header, it supports the templated values
+	Value string `json:"value,omitempty"`
+	// ValueFrom contains the source of the header value
+	// it will be used, if value is not provided
+	ValueFrom HeaderValueSource `json:"valueFrom,omitempty"`
+}
+
+// HeaderValueSource contains the source of the secret header values
+type HeaderValueSource struct {
+	// Env contains the name of the env, which contains the header value
+	Env string `json:"env,omitempty"`
+	// File contains the path of the mounted file, which contains the header value
+	File string `json:"file,omitempty"`
+}
+
+// ResponseBodyDetails contains the details of the http response body assertion
+type ResponseBodyDetails struct {
+	// JSONPath contains the jsonpath expression used to extract the value from the json response body
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Regex contains the regular expression used to extract the value from the response body
+	// it extracts the first capture group, if present otherwise the entire match
+	Regex string `json:"regex,omitempty"`
+	// Comparator check for the correctness of the extracted value
+	Comparator ComparatorInfo `json:"comparator,omitempty"`
+}
+
+// GetMethod define the http Get method
+type GetMethod struct {
+	// Criteria for matching data
+	// it supports  == != operations
+	Criteria string `json:"criteria,omitempty"`
+	// Value contains relative value for criteria
+	ResponseCode string `json:"responseCode,omitempty"`
+}
+
+// PostMethod define the http Post method
+type PostMethod struct {
+	// ContentType contains content type for http body data
+	ContentType string `json:"contentType,omitempty"`
+	// Body contains http body for post request
+	Body string `json:"body,omitempty"`
+	// BodyPath contains filePath, which contains http body
+	BodyPath string `json:"bodyPath,omitempty"`
+	// Criteria for matching data
+	// it supports  == != operations
+	Criteria string `json:"criteria,omitempty"`
+	// Value contains relative value for criteria
+	ResponseCode string `json:"responseCode,omitempty"`
+}
+
+// RunProperty contains timeout, retry and interval for the probe
+type RunProperty struct {
+	//ProbeTimeout contains timeout for the probe
+	ProbeTimeout int `json:"probeTimeout,omitempty"`
+	// Interval contains the inverval for the probe
+	Interval int `json:"interval,omitempty"`
+	// Retry contains the retry count for the probe
+	Retry int `json:"retry,omitempty"`
+	//ProbePollingInterval contains time interval, for which continuous probe should be sleep
+	// after each iteration
+	ProbePollingInterval int `json:"probePollingInterval,omitempty"`
+	//InitialDelaySeconds time interval for which probe will wait before run
+	InitialDelaySeconds int `json:"initialDelaySeconds,omitempty"`
+	// StopOnFailure contains flag to stop/continue experiment execution, if probe fails
+	// it will stop the experiment execution, if provided true
+	// it will continue the experiment execution, if provided false or not provided(default case)
+	StopOnFailure bool `json:"stopOnFailure,omitempty"`
+}
@@ -0,0 +1,86 @@
+package types
+
+import "time"
+
+// Lock acquires the lock over the probe details and artifacts
+// it should be held while accessing them from the concurrent probes
+func (resultDetails *ResultDetails) Lock() {
+	resultDetails.mu.Lock()
+}
+
+// Unlock releases the lock over the probe details and artifacts
+func (resultDetails *ResultDetails) Unlock() {
+	resultDetails.mu.Unlock()
+}
+
+// UpdateProbeDetails updates the details of the given probe under the lock
+// it returns false, if the probe is not found
+func (resultDetails *ResultDetails) UpdateProbeDetails(probeName string, update func(probeDetails *ProbeDetails)) bool {
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+
+	for index := range resultDetails.ProbeDetails {
+		if resultDetails.ProbeDetails[index].Name == probeName {
+			update(&resultDetails.ProbeDetails[index])
+			return true
+		}
+	}
+	return false
+}
+
+// GetProbeDetails returns the snapshot of the probe details
+func (resultDetails *ResultDetails) GetProbeDetails() []ProbeDetails {
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+
+	probeDetails := make([]ProbeDetails, 0, len(resultDetails.ProbeDetails))
+	for _, probe := range resultDetails.ProbeDetails {
+		status := make(map[string]string, len(probe.Status))
+		for key, value := range probe.Status {
+			status[key] = value
+		}
+		probe.Status = status
+		probe.LatencySamples = append([]time.Duration{}, probe.LatencySamples...)
+		probeDetails = append(probeDetails, probe)
+	}
+	return probeDetails
+}
+
+// HasProbes checks whether any probe is initialised for the experiment
+func (resultDetails *ResultDetails) HasProbes() bool {
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+	return len(resultDetails.ProbeDetails) != 0
+}
+
+// SetProbeArtifact register the output of the given probe, which can be used by the templated inputs of other probes
+func (resultDetails *ResultDetails) SetProbeArtifact(probeName, register string) {
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+
+	if resultDetails.ProbeArtifacts == nil {
+		resultDetails.ProbeArtifacts = map[string]ProbeArtifact{}
+	}
+	probes := ProbeArtifact{}
+	probes.ProbeArtifacts.Register = register
+	resultDetails.ProbeArtifacts[probeName] = probes
+}
+
+// GetProbeArtifacts returns the snapshot of the probe artifacts
+func (resultDetails *ResultDetails) GetProbeArtifacts() map[string]ProbeArtifact {
+	resultDetails.Lock()
+	defer resultDetails.Unlock()
+
+	artifacts := make(map[string]ProbeArtifact, len(resultDetails.ProbeArtifacts))
+	for name, artifact := range resultDetails.ProbeArtifacts {
+		artifacts[name] = artifact
+	}
+	return artifacts
+}
+
+// MarkProbePassed marks the final verdict of the probe as passed
+func (resultDetails *ResultDetails) MarkProbePassed(probeName string) {
+	resultDetails.UpdateProbeDetails(probeName, func(probeDetails *ProbeDetails) {
+		probeDetails.Passed = true
+	})
+}
@@ -3,8 +3,13 @@ package types
 import (
 	"os"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	clientTypes "k8s.io/apimachinery/pkg/types"
 )
@@ -30,14 +35,15 @@ const (
 
 // ResultDetails is for collecting all the chaos-result-related details
 type ResultDetails struct {
-	Name             string
-	Verdict          v1alpha1.ResultVerdict
-	FailStep         string
-	Phase            v1alpha1.ResultPhase
-	ResultUID        clientTypes.UID
-	ProbeDetails     []ProbeDetails
-	PassedProbeCount int
-	ProbeArtifacts   map[string]ProbeArtifact
+	Name           string
+	Verdict        v1alpha1.ResultVerdict
+	FailStep       string
+	Phase          v1alpha1.ResultPhase
+	ResultUID      clientTypes.UID
+	ProbeDetails   []ProbeDetails
+	ProbeArtifacts map[string]ProbeArtifact
+	// mu guards the probe details and artifacts, which are updated by the concurrent probes
+	mu sync.Mutex
 }
 
 // ProbeArtifact contains the probe artifacts
@@ -59,6 +65,16 @@ type ProbeDetails struct {
 	IsProbeFailedWithError error
 	RunID                  string
 	RunCount               int
+	// LatencySamples contains the latency of the requests sent by the probe
+	// it is recorded for the continuous and onchaos http probes
+	LatencySamples []time.Duration
+	// Weight of the probe in the resilience score
+	Weight int
+	// Critical flag to fail the experiment, if the probe fails
+	Critical bool
+	// Passed is set once the probe passes in the final phase of its mode
+	// like the postchaos phase of the edge probes
+	Passed bool
 }
 
 // EventDetails is for collecting all the events-related details
@@ -72,64 +88,170 @@ type EventDetails struct {
 
 // ChaosDetails is for collecting all the global variables
 type ChaosDetails struct {
-	ChaosUID              clientTypes.UID
-	ChaosNamespace        string
-	ChaosPodName          string
-	EngineName            string
-	InstanceID            string
-	ExperimentName        string
-	Timeout               int
-	Delay                 int
+	ChaosUID              clientTypes.UID `env:"CHAOS_UID"`
+	ChaosNamespace        string          `env:"CHAOS_NAMESPACE"`
+	ChaosPodName          string          `env:"POD_NAME"`
+	EngineName            string          `env:"CHAOSENGINE"`
+	InstanceID            string          `env:"INSTANCE_ID"`
+	ExperimentName        string          `env:"EXPERIMENT_NAME"`
+	Timeout               int             `env:"STATUS_CHECK_TIMEOUT" default:"180" validate:"duration=s"`
+	Delay                 int             `env:"STATUS_CHECK_DELAY" default:"2" validate:"duration=s,min=1"`
 	AppDetail             AppDetails
-	ChaosDuration         int
-	JobCleanupPolicy      string
-	ProbeImagePullPolicy  string
-	Randomness            bool
+	ChaosDuration         int    `env:"TOTAL_CHAOS_DURATION" validate:"duration=s"`
+	JobCleanupPolicy      string `env:"JOB_CLEANUP_POLICY" default:"retain"`
+	ProbeImagePullPolicy  string `env:"LIB_IMAGE_PULL_POLICY" default:"Always"`
+	Randomness            bool   `env:"RANDOMNESS" default:"false"`
 	Targets               []v1alpha1.TargetDetails
 	ParentsResources      []string
-	DefaultAppHealthCheck bool
+	DefaultAppHealthCheck bool `env:"DEFAULT_APP_HEALTH_CHECK" default:"true"`
 	Annotations           map[string]string
 	Resources             corev1.ResourceRequirements
 	ImagePullSecrets      []corev1.LocalObjectReference
 	Labels                map[string]string
+	DryRun                bool `env:"DRY_RUN" default:"false"`
+	Standalone            bool `env:"STANDALONE" default:"false"`
+	// Probes contains the inline probes, which are used instead of the chaosengine probes
+	Probes []ProbeAttributes
+	// Guardrails limits the blast radius of the chaos, based on the availability of the target workloads
+	Guardrails Guardrails
+	// OnInject is called by the chaoslib, once the chaos is injected
+	OnInject func() `json:"-"`
+}
+
+// Guardrails contains the blast radius limits of the target workloads
+type Guardrails struct {
+	// MinAvailable is the minimum available pods of each target workload, in number or percentage like 50%
+	MinAvailable string `env:"MIN_AVAILABLE"`
+	// RespectPDB limits the targets of the workload to the disruptions allowed by its pod disruption budgets
+	RespectPDB bool `env:"RESPECT_PDB" default:"false"`
+	// Policy defines whether the targets exceeding the limits are trimmed or the chaos is refused
+	Policy string `env:"BLAST_RADIUS_POLICY" default:"trim" validate:"enum=trim|refuse"`
+	// Trimmed contains the targets, which are trimmed by the guardrails
+	Trimmed []TrimmedTarget
+}
+
+// TrimmedTarget contains the details of the target pod, which is trimmed by the guardrails
+type TrimmedTarget struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	Owner     string `json:"owner"`
+	Reason    string `json:"reason"`
+}
+
+// IsEnabled checks whether any of the guardrails is enabled
+func (guardrails Guardrails) IsEnabled() bool {
+	return guardrails.MinAvailable != "" || guardrails.RespectPDB
 }
 
 // AppDetails contains all the application related envs
 type AppDetails struct {
-	Namespace       string
-	Label           string
-	Kind            string
-	AnnotationCheck bool
-	AnnotationKey   string
+	Namespace       string `env:"APP_NAMESPACE"`
+	Label           string `env:"APP_LABEL"`
+	Kind            string `env:"APP_KIND"`
+	AnnotationCheck bool   `env:"ANNOTATION_CHECK" default:"false"`
+	AnnotationKey   string `env:"ANNOTATION_KEY" default:"litmuschaos.io/chaos"`
 	AnnotationValue string
+	// Targets contains the additional namespace, label and kind tuples of the applications
+	Targets []AppTarget
+	// NamespaceSelector is the label selector of the target namespaces, the label and kind are applied in each namespace
+	NamespaceSelector string `env:"APP_NAMESPACE_SELECTOR"`
+	// PodsAffectedPercScope defines whether the pods affected percentage is applied on all the targets together (global) or on each target
+	PodsAffectedPercScope string `env:"PODS_AFFECTED_PERC_SCOPE" default:"global"`
+}
+
+// AppTarget contains the namespace, label and kind of the application
+type AppTarget struct {
+	Namespace string
+	Label     string
+	Kind      string
+	// PodsAffectedPerc is the pods affected percentage of the target, it overrides the PODS_AFFECTED_PERC if set
+	PodsAffectedPerc int
+}
+
+// IsMultiTarget checks whether the applications are spread across the multiple tuples or namespaces
+func (appDetails AppDetails) IsMultiTarget() bool {
+	return len(appDetails.Targets) != 0 || appDetails.NamespaceSelector != ""
+}
+
+// ParseAppTargets parse the tuples of the applications, separated by semicolon
+// each tuple is in the form of namespace:label:kind[:podsAffectedPerc], the empty fields are allowed
+// e.g. frontend:app=web:deployment;api:app=api,tier=backend:deployment:50
+// it returns the error, if the pods affected percentage is not a number between 0 and 100
+func ParseAppTargets(targets string) ([]AppTarget, error) {
+	appTargets := []AppTarget{}
+	for _, tuple := range strings.Split(targets, ";") {
+		if strings.TrimSpace(tuple) == "" {
+			continue
+		}
+		fields := strings.SplitN(tuple, ":", 4)
+		for len(fields) < 4 {
+			fields = append(fields, "")
+		}
+		appTarget := AppTarget{
+			Namespace: strings.TrimSpace(fields[0]),
+			Label:     strings.TrimSpace(fields[1]),
+			Kind:      strings.TrimSpace(fields[2]),
+		}
+		if percentage := strings.TrimSuffix(strings.TrimSpace(fields[3]), "%"); percentage != "" {
+			value, err := strconv.Atoi(percentage)
+			if err != nil || value < 0 || value > 100 {
+				return nil, errors.Errorf("invalid pods affected percentage %q of %v app target, provide a number between 0 and 100", fields[3], strings.TrimSpace(tuple))
+			}
+			appTarget.PodsAffectedPerc = value
+		}
+		appTargets = append(appTargets, appTarget)
+	}
+	return appTargets, nil
 }
 
 //InitialiseChaosVariables initialise all the global variables
-func InitialiseChaosVariables(chaosDetails *ChaosDetails) {
-	appDetails := AppDetails{}
-	appDetails.AnnotationCheck, _ = strconv.ParseBool(Getenv("ANNOTATION_CHECK", "false"))
-	appDetails.AnnotationKey = Getenv("ANNOTATION_KEY", "litmuschaos.io/chaos")
-	appDetails.AnnotationValue = "true"
-	appDetails.Kind = Getenv("APP_KIND", "")
-	appDetails.Label = Getenv("APP_LABEL", "")
-	appDetails.Namespace = Getenv("APP_NAMESPACE", "")
-
-	chaosDetails.ChaosNamespace = Getenv("CHAOS_NAMESPACE", "")
-	chaosDetails.ChaosPodName = Getenv("POD_NAME", "")
-	chaosDetails.Randomness, _ = strconv.ParseBool(Getenv("RANDOMNESS", ""))
-	chaosDetails.ChaosDuration, _ = strconv.Atoi(Getenv("TOTAL_CHAOS_DURATION", ""))
-	chaosDetails.ChaosUID = clientTypes.UID(Getenv("CHAOS_UID", ""))
-	chaosDetails.EngineName = Getenv("CHAOSENGINE", "")
-	chaosDetails.ExperimentName = Getenv("EXPERIMENT_NAME", "")
-	chaosDetails.InstanceID = Getenv("INSTANCE_ID", "")
-	chaosDetails.Timeout, _ = strconv.Atoi(Getenv("STATUS_CHECK_TIMEOUT", "180"))
-	chaosDetails.Delay, _ = strconv.Atoi(Getenv("STATUS_CHECK_DELAY", "2"))
-	chaosDetails.AppDetail = appDetails
-	chaosDetails.DefaultAppHealthCheck, _ = strconv.ParseBool(Getenv("DEFAULT_APP_HEALTH_CHECK", "true"))
-	chaosDetails.JobCleanupPolicy = Getenv("JOB_CLEANUP_POLICY", "retain")
-	chaosDetails.ProbeImagePullPolicy = Getenv("LIB_IMAGE_PULL_POLICY", "Always")
+// the values are loaded from the env and the EXPERIMENT_CONFIG file, it returns all the invalid values together
+func InitialiseChaosVariables(chaosDetails *ChaosDetails) error {
+	sources, err := config.DefaultSources()
+	if err != nil {
+		return err
+	}
+	if err := config.Load(chaosDetails, sources...); err != nil {
+		return err
+	}
+
+	// the application targets are parsed from the raw tuples
+	var targets struct {
+		Value string `env:"APP_TARGETS"`
+	}
+	if err := config.Load(&targets, sources...); err != nil {
+		return err
+	}
+
+	appTargets, err := ParseAppTargets(targets.Value)
+	if err != nil {
+		return err
+	}
+
+	chaosDetails.AppDetail.AnnotationValue = "true"
+	chaosDetails.AppDetail.Targets = appTargets
 	chaosDetails.ParentsResources = []string{}
 	chaosDetails.Targets = []v1alpha1.TargetDetails{}
+	return nil
+}
+
+// ResultName returns the name of the chaosresult of the experiment
+func (chaosDetails ChaosDetails) ResultName() string {
+	name := chaosDetails.ExperimentName
+	if chaosDetails.EngineName != "" {
+		name = chaosDetails.EngineName + "-" + chaosDetails.ExperimentName
+	}
+	if chaosDetails.InstanceID != "" {
+		name = name + "-" + chaosDetails.InstanceID
+	}
+	return name
+}
+
+// MarkInjected notifies the chaos injection through the OnInject hook, if it is set
+func (chaosDetails *ChaosDetails) MarkInjected() {
+	if chaosDetails.OnInject != nil {
+		chaosDetails.OnInject()
+	}
 }
 
 //SetResultAttributes initialise all the chaos result ENV
@@ -137,16 +259,7 @@ func SetResultAttributes(resultDetails *ResultDetails, chaosDetails ChaosDetails
 	resultDetails.Verdict = "Awaited"
 	resultDetails.Phase = "Running"
 	resultDetails.FailStep = "N/A"
-	resultDetails.PassedProbeCount = 0
-	if chaosDetails.EngineName != "" {
-		resultDetails.Name = chaosDetails.EngineName + "-" + chaosDetails.ExperimentName
-	} else {
-		resultDetails.Name = chaosDetails.ExperimentName
-	}
-
-	if chaosDetails.InstanceID != "" {
-		resultDetails.Name = resultDetails.Name + "-" + chaosDetails.InstanceID
-	}
+	resultDetails.Name = chaosDetails.ResultName()
 
 }
 
@@ -0,0 +1,27 @@
+package types
+
+import "testing"
+
+func TestParseAppTargets(t *testing.T) {
+	appTargets, err := ParseAppTargets("frontend:app=web:deployment; api:app=api,tier=backend:deployment:50%;")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(appTargets) != 2 {
+		t.Fatalf("expected 2 app targets, got %v", appTargets)
+	}
+	if appTargets[0].PodsAffectedPerc != 0 || appTargets[1].PodsAffectedPerc != 50 {
+		t.Fatalf("expected 0 and 50 pods affected percentage, got %v and %v", appTargets[0].PodsAffectedPerc, appTargets[1].PodsAffectedPerc)
+	}
+	if appTargets[1].Namespace != "api" || appTargets[1].Label != "app=api,tier=backend" || appTargets[1].Kind != "deployment" {
+		t.Fatalf("unexpected app target: %+v", appTargets[1])
+	}
+}
+
+func TestParseAppTargetsInvalidPercentage(t *testing.T) {
+	for _, targets := range []string{"api:app=api:deployment:half", "api:app=api:deployment:150", "api:app=api:deployment:-5"} {
+		if _, err := ParseAppTargets(targets); err == nil {
+			t.Fatalf("expected an error for %v app targets", targets)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package abort
+
+import (
+	"context"
+)
+
+var ctx, cancel = context.WithCancel(context.Background())
+
+// Context returns the context, which is cancelled once the experiment is aborted
+// it can be used to interrupt the pending waits of the probes and status checks
+func Context() context.Context {
+	return ctx
+}
+
+// Trigger cancels the abort context, it should be called once the abort signal is handled
+func Trigger() {
+	cancel()
+}
+
+// IsAborted checks whether the experiment has been aborted
+func IsAborted() bool {
+	return ctx.Err() != nil
+}
@@ -0,0 +1,102 @@
+package annotation
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// maxOwnerDepth is the maximum number of the owners, walked from the pod to the top-level owner
+const maxOwnerDepth = 10
+
+var (
+	mapperMu sync.Mutex
+	// mapper maps the kinds to the resources, using the cached discovery
+	mapper meta.RESTMapper
+)
+
+// getMapper returns the rest mapper, it is created once and reset on the cache miss
+func getMapper(clients clients.ClientSets) meta.RESTMapper {
+	mapperMu.Lock()
+	defer mapperMu.Unlock()
+	if mapper == nil {
+		mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clients.KubeClient.Discovery()))
+	}
+	return mapper
+}
+
+// resolveKind resolves the appkind into the group version kind and resource
+// the appkind is the singular or plural resource name, optionally qualified with the group like kafkas.kafka.strimzi.io
+func resolveKind(clients clients.ClientSets, appKind string) (schema.GroupVersionKind, schema.GroupVersionResource, error) {
+
+	groupResource := schema.ParseGroupResource(strings.ToLower(appKind))
+	partial := schema.GroupVersionResource{Group: groupResource.Group, Resource: groupResource.Resource}
+
+	restMapper := getMapper(clients)
+	gvr, err := restMapper.ResourceFor(partial)
+	if err != nil {
+		return schema.GroupVersionKind{}, schema.GroupVersionResource{}, errors.Errorf("%v appkind is not supported, err: %v", appKind, err)
+	}
+	gvk, err := restMapper.KindFor(gvr)
+	if err != nil {
+		return schema.GroupVersionKind{}, schema.GroupVersionResource{}, errors.Errorf("%v appkind is not supported, err: %v", appKind, err)
+	}
+	return gvk, gvr, nil
+}
+
+// getTopLevelOwner walks the owner references from the given owners up to the owner of the given kind
+// the intermediate owners (like replicasets, jobs) are fetched with the dynamic client
+func getTopLevelOwner(clients clients.ClientSets, namespace string, owners []v1.OwnerReference, kind schema.GroupKind) (string, error) {
+
+	restMapper := getMapper(clients)
+	for depth := 0; depth < maxOwnerDepth; depth++ {
+		owner := controllerOf(owners)
+		if owner == nil {
+			return "", errors.Errorf("no %v owner found", kind.Kind)
+		}
+
+		ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			return "", err
+		}
+		if owner.Kind == kind.Kind && ownerGV.Group == kind.Group {
+			return owner.Name, nil
+		}
+
+		mapping, err := restMapper.RESTMapping(schema.GroupKind{Group: ownerGV.Group, Kind: owner.Kind}, ownerGV.Version)
+		if err != nil {
+			return "", errors.Errorf("unable to find the resource of %v owner, err: %v", owner.Kind, err)
+		}
+		resource := clients.DynamicClient.Resource(mapping.Resource)
+		ownerNamespace := namespace
+		if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+			ownerNamespace = ""
+		}
+		obj, err := resource.Namespace(ownerNamespace).Get(owner.Name, v1.GetOptions{})
+		if err != nil {
+			return "", errors.Errorf("unable to get the %v %v, err: %v", owner.Kind, owner.Name, err)
+		}
+		owners = obj.GetOwnerReferences()
+	}
+	return "", errors.Errorf("no %v owner found within %v levels of the owner references", kind.Kind, maxOwnerDepth)
+}
+
+// controllerOf returns the controller owner reference, otherwise the first owner reference
+func controllerOf(owners []v1.OwnerReference) *v1.OwnerReference {
+	for index := range owners {
+		if owners[index].Controller != nil && *owners[index].Controller {
+			return &owners[index]
+		}
+	}
+	if len(owners) != 0 {
+		return &owners[0]
+	}
+	return nil
+}
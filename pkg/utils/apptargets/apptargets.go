@@ -0,0 +1,71 @@
+package apptargets
+
+import (
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Resolve derives the namespace, label and kind tuples of the applications
+// the namespace selector is expanded into the tuple per matching namespace, using the app label and kind
+// otherwise the app namespace, label and kind are used as the primary tuple, followed by the additional tuples
+// the primary tuple is skipped, if the app label is not provided along with the additional tuples
+// the empty namespace and kind of the additional tuples are inherited from the app namespace and kind
+func Resolve(clients clients.ClientSets, appDetails types.AppDetails) ([]types.AppTarget, error) {
+
+	targets := []types.AppTarget{}
+	switch {
+	case appDetails.NamespaceSelector != "":
+		namespaces, err := clients.KubeClient.CoreV1().Namespaces().List(v1.ListOptions{LabelSelector: appDetails.NamespaceSelector})
+		if err != nil {
+			return nil, errors.Errorf("unable to list the namespaces with %v selector, err: %v", appDetails.NamespaceSelector, err)
+		}
+		for _, namespace := range namespaces.Items {
+			targets = append(targets, types.AppTarget{Namespace: namespace.Name, Label: appDetails.Label, Kind: appDetails.Kind})
+		}
+	case appDetails.Namespace != "" && (appDetails.Label != "" || len(appDetails.Targets) == 0):
+		targets = append(targets, types.AppTarget{Namespace: appDetails.Namespace, Label: appDetails.Label, Kind: appDetails.Kind})
+	}
+
+	for _, target := range appDetails.Targets {
+		if target.Namespace == "" {
+			target.Namespace = appDetails.Namespace
+		}
+		if target.Kind == "" {
+			target.Kind = appDetails.Kind
+		}
+		if target.Namespace == "" {
+			return nil, errors.Errorf("namespace is not provided for the target with %v label", target.Label)
+		}
+		if !contains(targets, target) {
+			targets = append(targets, target)
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.Errorf("no target application found")
+	}
+	return targets, nil
+}
+
+// WithTarget returns the copy of the chaos details, whose app details are derived from the given target
+func WithTarget(chaosDetails *types.ChaosDetails, target types.AppTarget) *types.ChaosDetails {
+	targetDetails := *chaosDetails
+	targetDetails.AppDetail.Namespace = target.Namespace
+	targetDetails.AppDetail.Label = target.Label
+	targetDetails.AppDetail.Kind = target.Kind
+	targetDetails.AppDetail.Targets = nil
+	targetDetails.AppDetail.NamespaceSelector = ""
+	return &targetDetails
+}
+
+// contains checks whether the target with same namespace, label and kind is present in the targets
+func contains(targets []types.AppTarget, target types.AppTarget) bool {
+	for _, t := range targets {
+		if t.Namespace == target.Namespace && t.Label == target.Label && t.Kind == target.Kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package apptargets
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolve(t *testing.T) {
+	tests := map[string]struct {
+		appDetails types.AppDetails
+		want       []types.AppTarget
+	}{
+		"primary tuple with the additional tuples": {
+			appDetails: types.AppDetails{Namespace: "default", Label: "app=web", Kind: "deployment", Targets: []types.AppTarget{{Namespace: "api", Label: "app=api"}}},
+			want:       []types.AppTarget{{Namespace: "default", Label: "app=web", Kind: "deployment"}, {Namespace: "api", Label: "app=api", Kind: "deployment"}},
+		},
+		"additional tuples without the app label": {
+			appDetails: types.AppDetails{Namespace: "default", Kind: "deployment", Targets: []types.AppTarget{{Label: "app=api"}}},
+			want:       []types.AppTarget{{Namespace: "default", Label: "app=api", Kind: "deployment"}},
+		},
+		"primary tuple without the app label": {
+			appDetails: types.AppDetails{Namespace: "default"},
+			want:       []types.AppTarget{{Namespace: "default"}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			targets, err := Resolve(clients.ClientSets{KubeClient: fake.NewSimpleClientset()}, test.appDetails)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(targets) != len(test.want) {
+				t.Fatalf("expected %v targets, got %v", test.want, targets)
+			}
+			for i := range targets {
+				if targets[i] != test.want[i] {
+					t.Fatalf("expected %v targets, got %v", test.want, targets)
+				}
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/abort"
 	"github.com/pkg/errors"
 	apiv1 "k8s.io/api/core/v1"
 )
@@ -93,6 +94,9 @@ func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultD
 	// generating summary event in chaosresult
 	types.SetResultEventAttributes(eventsDetails, types.AbortVerdict, msg, "Warning", resultDetails)
 	events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosResult")
+
+	// interrupt the pending waits of the probes and status checks
+	abort.Trigger()
 }
 
 //GetIterations derive the iterations value from given parameters
@@ -132,6 +136,15 @@ func (envDetails *ENVDetails) SetEnv(key, value string) *ENVDetails {
 	return envDetails
 }
 
+// SetLogEnv sets the env used by the helper pods to configure the logger
+// it propagates the log format, level and the default log fields of the experiment
+func (envDetails *ENVDetails) SetLogEnv() *ENVDetails {
+	return envDetails.SetEnv("LOG_FORMAT", os.Getenv("LOG_FORMAT")).
+		SetEnv("LOG_LEVEL", os.Getenv("LOG_LEVEL")).
+		SetEnv("RUN_ID", log.GetField(log.RunIDField)).
+		SetEnv("CHAOS_PHASE", log.GetField(log.PhaseField))
+}
+
 // SetEnvFromDownwardAPI sets the downapi env in envDetails struct
 func (envDetails *ENVDetails) SetEnvFromDownwardAPI(apiVersion string, fieldPath string) *ENVDetails {
 	if apiVersion != "" && fieldPath != "" {
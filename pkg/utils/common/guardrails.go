@@ -0,0 +1,270 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	core_v1 "k8s.io/api/core/v1"
+	policy_v1beta1 "k8s.io/api/policy/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TrimmedTargetsAnnotation is the chaosresult annotation, which contains the targets trimmed by the guardrails
+const TrimmedTargetsAnnotation = "litmuschaos.io/trimmed-targets"
+
+// workloadAvailability contains the availability of the workload owning the target pods
+type workloadAvailability struct {
+	owner     string
+	desired   int
+	available int
+	// allowed is the number of the available pods, which can be disrupted
+	allowed int
+	reason  string
+}
+
+// ApplyGuardrails limits the target pods, so that the owner workloads don't go below the MIN_AVAILABLE
+// and the disruptions allowed by the pod disruption budgets, if RESPECT_PDB is enabled
+// the exceeding targets are trimmed and recorded inside the chaosresult, or the chaos is refused based on the BLAST_RADIUS_POLICY
+func ApplyGuardrails(targetPods core_v1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
+
+	guardrails := chaosDetails.Guardrails
+	if !guardrails.IsEnabled() || len(targetPods.Items) == 0 {
+		return targetPods, nil
+	}
+
+	availability := map[string]*workloadAvailability{}
+	finalPods := core_v1.PodList{}
+	trimmed := []types.TrimmedTarget{}
+	for _, pod := range targetPods.Items {
+		key := pod.Namespace + "/" + ownerKey(pod)
+		workload, ok := availability[key]
+		if !ok {
+			var err error
+			if workload, err = getWorkloadAvailability(pod, clients, guardrails); err != nil {
+				return core_v1.PodList{}, err
+			}
+			availability[key] = workload
+		}
+
+		// the pods which are not ready, don't reduce the availability of the workload
+		if !isPodReady(pod) {
+			finalPods.Items = append(finalPods.Items, pod)
+			continue
+		}
+		if workload.allowed > 0 {
+			workload.allowed--
+			finalPods.Items = append(finalPods.Items, pod)
+			continue
+		}
+		trimmed = append(trimmed, types.TrimmedTarget{Pod: pod.Name, Namespace: pod.Namespace, Owner: workload.owner, Reason: workload.reason})
+	}
+
+	if len(trimmed) == 0 {
+		return finalPods, nil
+	}
+	for _, target := range trimmed {
+		log.Warnf("[Guardrails]: %v pod of %v namespace is trimmed, %v", target.Pod, target.Namespace, target.Reason)
+	}
+	recordTrimmedTargets(trimmed, clients, chaosDetails)
+	if strings.ToLower(guardrails.Policy) == "refuse" {
+		return core_v1.PodList{}, errors.Errorf("chaos is refused by the guardrails, %v target pods exceed the blast radius: %v", len(trimmed), trimmed[0].Reason)
+	}
+	if len(finalPods.Items) == 0 {
+		return core_v1.PodList{}, errors.Errorf("no target pod remains after applying the guardrails")
+	}
+	return finalPods, nil
+}
+
+// getWorkloadAvailability derives the availability of the workload owning the given pod
+func getWorkloadAvailability(targetPod core_v1.Pod, clients clients.ClientSets, guardrails types.Guardrails) (*workloadAvailability, error) {
+
+	podList, err := clients.KubeClient.CoreV1().Pods(targetPod.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the pods of %v namespace, err: %v", targetPod.Namespace, err)
+	}
+
+	owner := ownerKey(targetPod)
+	workload := &workloadAvailability{owner: owner}
+	for _, pod := range podList.Items {
+		if ownerKey(pod) != owner || pod.DeletionTimestamp != nil {
+			continue
+		}
+		workload.desired++
+		if isPodReady(pod) {
+			workload.available++
+		}
+	}
+
+	// the desired pods are derived from the controller, the missing pods are not counted in the existing pods
+	desired, found, err := getDesiredReplicas(targetPod, clients)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		workload.desired = desired
+	}
+
+	minAvailable, err := parseMinAvailable(guardrails.MinAvailable, workload.desired)
+	if err != nil {
+		return nil, err
+	}
+	workload.allowed = math.Maximum(0, workload.available-minAvailable)
+	workload.reason = fmt.Sprintf("%v has %v available pods, MIN_AVAILABLE is %v", owner, workload.available, minAvailable)
+
+	if guardrails.RespectPDB {
+		pdbs, err := getMatchingPDBs(targetPod, clients)
+		if err != nil {
+			return nil, err
+		}
+		for _, pdb := range pdbs {
+			if int(pdb.Status.PodDisruptionsAllowed) < workload.allowed {
+				workload.allowed = int(pdb.Status.PodDisruptionsAllowed)
+				workload.reason = fmt.Sprintf("%v pod disruption budget of %v allows %v disruptions", pdb.Name, owner, pdb.Status.PodDisruptionsAllowed)
+			}
+		}
+	}
+	return workload, nil
+}
+
+// getDesiredReplicas returns the desired replicas of the controller of the given pod
+// it returns false, if the pod has no controller or the kind of the controller is not supported
+func getDesiredReplicas(pod core_v1.Pod, clients clients.ClientSets) (int, bool, error) {
+	controller := v1.GetControllerOf(&pod)
+	if controller == nil {
+		return 0, false, nil
+	}
+
+	var replicas *int32
+	switch strings.ToLower(controller.Kind) {
+	case "replicaset":
+		rs, err := clients.KubeClient.AppsV1().ReplicaSets(pod.Namespace).Get(controller.Name, v1.GetOptions{})
+		if err != nil {
+			return 0, false, errors.Errorf("unable to get the %v replicaset, err: %v", controller.Name, err)
+		}
+		replicas = rs.Spec.Replicas
+	case "statefulset":
+		sts, err := clients.KubeClient.AppsV1().StatefulSets(pod.Namespace).Get(controller.Name, v1.GetOptions{})
+		if err != nil {
+			return 0, false, errors.Errorf("unable to get the %v statefulset, err: %v", controller.Name, err)
+		}
+		replicas = sts.Spec.Replicas
+	case "replicationcontroller":
+		rc, err := clients.KubeClient.CoreV1().ReplicationControllers(pod.Namespace).Get(controller.Name, v1.GetOptions{})
+		if err != nil {
+			return 0, false, errors.Errorf("unable to get the %v replicationcontroller, err: %v", controller.Name, err)
+		}
+		replicas = rc.Spec.Replicas
+	case "daemonset":
+		ds, err := clients.KubeClient.AppsV1().DaemonSets(pod.Namespace).Get(controller.Name, v1.GetOptions{})
+		if err != nil {
+			return 0, false, errors.Errorf("unable to get the %v daemonset, err: %v", controller.Name, err)
+		}
+		return int(ds.Status.DesiredNumberScheduled), true, nil
+	default:
+		return 0, false, nil
+	}
+
+	// the replicas are defaulted to 1, if not provided
+	if replicas == nil {
+		return 1, true, nil
+	}
+	return int(*replicas), true, nil
+}
+
+// parseMinAvailable derives the minimum available pods from the number or percentage of the desired pods
+// the percentage is rounded up, so that the availability is never below the given percentage
+func parseMinAvailable(minAvailable string, desired int) (int, error) {
+	minAvailable = strings.TrimSpace(minAvailable)
+	if minAvailable == "" {
+		return 0, nil
+	}
+	if strings.HasSuffix(minAvailable, "%") {
+		percentage, err := strconv.Atoi(strings.TrimSuffix(minAvailable, "%"))
+		if err != nil || percentage < 0 || percentage > 100 {
+			return 0, errors.Errorf("%v is not a valid MIN_AVAILABLE percentage", minAvailable)
+		}
+		return (desired*percentage + 99) / 100, nil
+	}
+	value, err := strconv.Atoi(minAvailable)
+	if err != nil || value < 0 {
+		return 0, errors.Errorf("%v is not a valid MIN_AVAILABLE, provide the number or percentage of the pods", minAvailable)
+	}
+	return value, nil
+}
+
+// getMatchingPDBs returns the pod disruption budgets, which selects the given pod
+func getMatchingPDBs(pod core_v1.Pod, clients clients.ClientSets) ([]policy_v1beta1.PodDisruptionBudget, error) {
+	pdbList, err := clients.KubeClient.PolicyV1beta1().PodDisruptionBudgets(pod.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the pod disruption budgets of %v namespace, err: %v", pod.Namespace, err)
+	}
+	pdbs := []policy_v1beta1.PodDisruptionBudget{}
+	for _, pdb := range pdbList.Items {
+		selector, err := v1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		pdbs = append(pdbs, pdb)
+	}
+	return pdbs, nil
+}
+
+// recordTrimmedTargets adds the trimmed targets to the chaos details and the chaosresult annotation
+func recordTrimmedTargets(trimmed []types.TrimmedTarget, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+
+	for _, target := range trimmed {
+		if !isTrimmed(target, chaosDetails.Guardrails.Trimmed) {
+			chaosDetails.Guardrails.Trimmed = append(chaosDetails.Guardrails.Trimmed, target)
+		}
+	}
+
+	// there is no chaosresult in the standalone mode
+	if chaosDetails.Standalone {
+		return
+	}
+	data, err := json.Marshal(chaosDetails.Guardrails.Trimmed)
+	if err != nil {
+		log.Errorf("Unable to record the trimmed targets, err: %v", err)
+		return
+	}
+	if err := result.SetResultAnnotations(chaosDetails.ResultName(), chaosDetails.ChaosNamespace, map[string]string{TrimmedTargetsAnnotation: string(data)}, clients); err != nil {
+		log.Errorf("Unable to record the trimmed targets inside the chaosresult, err: %v", err)
+	}
+}
+
+// isTrimmed checks whether the target is already recorded as trimmed
+func isTrimmed(target types.TrimmedTarget, trimmed []types.TrimmedTarget) bool {
+	for _, t := range trimmed {
+		if t.Pod == target.Pod && t.Namespace == target.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ownerKey returns the kind and name of the controller of the pod, the pod itself if it has no controller
+func ownerKey(pod core_v1.Pod) string {
+	if controller := v1.GetControllerOf(&pod); controller != nil {
+		return strings.ToLower(controller.Kind) + "/" + controller.Name
+	}
+	return "pod/" + pod.Name
+}
+
+// isPodReady checks whether the pod is ready
+func isPodReady(pod core_v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == core_v1.PodReady {
+			return condition.Status == core_v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// statefulSetPod returns the ready pod of the web statefulset
+func statefulSetPod(name string) core_v1.Pod {
+	controller := true
+	return core_v1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: []v1.OwnerReference{{Kind: "StatefulSet", Name: "web", Controller: &controller}},
+		},
+		Status: core_v1.PodStatus{
+			Conditions: []core_v1.PodCondition{{Type: core_v1.PodReady, Status: core_v1.ConditionTrue}},
+		},
+	}
+}
+
+// degradedStatefulSet returns the clients with the 3 replicas statefulset, which is missing one pod
+func degradedStatefulSet() (clients.ClientSets, core_v1.PodList) {
+	replicas := int32(3)
+	sts := &apps_v1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       apps_v1.StatefulSetSpec{Replicas: &replicas},
+	}
+	pods := core_v1.PodList{Items: []core_v1.Pod{statefulSetPod("web-0"), statefulSetPod("web-1")}}
+	return clients.ClientSets{KubeClient: fake.NewSimpleClientset(sts, &pods.Items[0], &pods.Items[1])}, pods
+}
+
+func TestGetWorkloadAvailabilityUsesDesiredReplicas(t *testing.T) {
+	clients, pods := degradedStatefulSet()
+
+	workload, err := getWorkloadAvailability(pods.Items[0], clients, types.Guardrails{MinAvailable: "50%"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workload.desired != 3 || workload.available != 2 {
+		t.Fatalf("expected 3 desired and 2 available pods, got %v desired and %v available", workload.desired, workload.available)
+	}
+	// the min available is 2 (50% of 3, rounded up), so no pod can be disrupted
+	if workload.allowed != 0 {
+		t.Fatalf("expected no allowed disruption, got %v", workload.allowed)
+	}
+}
+
+func TestApplyGuardrailsRecordsTrimmedTargetsOnRefuse(t *testing.T) {
+	clients, pods := degradedStatefulSet()
+	chaosDetails := &types.ChaosDetails{
+		Standalone: true,
+		Guardrails: types.Guardrails{MinAvailable: "50%", Policy: "refuse"},
+	}
+
+	if _, err := ApplyGuardrails(pods, clients, chaosDetails); err == nil {
+		t.Fatalf("expected the chaos to be refused")
+	}
+	if len(chaosDetails.Guardrails.Trimmed) != 2 {
+		t.Fatalf("expected 2 trimmed targets to be recorded, got %v", chaosDetails.Guardrails.Trimmed)
+	}
+}
+
+func TestApplyGuardrailsTrimsTargets(t *testing.T) {
+	clients, pods := degradedStatefulSet()
+	chaosDetails := &types.ChaosDetails{
+		Standalone: true,
+		Guardrails: types.Guardrails{MinAvailable: "1", Policy: "trim"},
+	}
+
+	finalPods, err := ApplyGuardrails(pods, clients, chaosDetails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(finalPods.Items) != 1 || len(chaosDetails.Guardrails.Trimmed) != 1 {
+		t.Fatalf("expected 1 target and 1 trimmed target, got %v targets and %v trimmed", len(finalPods.Items), len(chaosDetails.Guardrails.Trimmed))
+	}
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/annotation"
+	"github.com/litmuschaos/litmus-go/pkg/utils/apptargets"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	core_v1 "k8s.io/api/core/v1"
@@ -108,7 +111,12 @@ func SetHelperData(chaosDetails *types.ChaosDetails, clients clients.ClientSets)
 }
 
 // GetHelperLabels return the labels of the helper pod
-func GetHelperLabels(labels map[string]string, runID, labelSuffix, experimentName string) map[string]string {
+// the labels are copied, as the labels of the chaos pod are shared by all the helper pods
+func GetHelperLabels(chaosLabels map[string]string, runID, labelSuffix, experimentName string) map[string]string {
+	labels := map[string]string{}
+	for key, value := range chaosLabels {
+		labels[key] = value
+	}
 	labels["name"] = experimentName + "-helper-" + runID
 	labels["app"] = experimentName + "-helper"
 	if labelSuffix != "" {
@@ -156,6 +164,14 @@ func GetPodList(targetPods string, podAffPerc int, clients clients.ClientSets, c
 		}
 		finalPods.Items = append(finalPods.Items, podList.Items...)
 	default:
+		if chaosDetails.AppDetail.IsMultiTarget() {
+			podList, err := getMultiTargetPods(podAffPerc, clients, chaosDetails)
+			if err != nil {
+				return core_v1.PodList{}, err
+			}
+			finalPods.Items = append(finalPods.Items, podList.Items...)
+			break
+		}
 		nonChaosPods, err := FilterNonChaosPods(clients, chaosDetails)
 		if err != nil {
 			return core_v1.PodList{}, err
@@ -166,6 +182,12 @@ func GetPodList(targetPods string, podAffPerc int, clients clients.ClientSets, c
 		}
 		finalPods.Items = append(finalPods.Items, podList.Items...)
 	}
+
+	// limit the blast radius, based on the availability of the owner workloads
+	finalPods, err = ApplyGuardrails(finalPods, clients, chaosDetails)
+	if err != nil {
+		return core_v1.PodList{}, err
+	}
 	log.Infof("[Chaos]:Number of pods targeted: %v", len(finalPods.Items))
 	return finalPods, nil
 }
@@ -238,6 +260,9 @@ func GetTargetPodsWhenTargetPodsENVSet(targetPods string, clients clients.Client
 // SetTargets set the target details in chaosdetails struct
 func SetTargets(target, chaosStatus, kind string, chaosDetails *types.ChaosDetails) {
 
+	defer setLogTargets(chaosStatus, chaosDetails)
+	defer setTargetMetrics(target, chaosStatus, kind, chaosDetails)
+
 	for i := range chaosDetails.Targets {
 		if chaosDetails.Targets[i].Name == target {
 			chaosDetails.Targets[i].ChaosStatus = chaosStatus
@@ -252,6 +277,36 @@ func SetTargets(target, chaosStatus, kind string, chaosDetails *types.ChaosDetai
 	chaosDetails.Targets = append(chaosDetails.Targets, newTarget)
 }
 
+// setTargetMetrics records the chaos status of the target and the chaos injection window inside the metrics
+func setTargetMetrics(target, chaosStatus, kind string, chaosDetails *types.ChaosDetails) {
+	metrics.SetTargetStatus(chaosDetails, target, kind, chaosStatus)
+	switch chaosStatus {
+	case "reverted", "re-attached":
+		metrics.SetInjectionEnd(chaosDetails, true)
+	default:
+		metrics.SetInjectionStart(chaosDetails)
+	}
+}
+
+// setLogTargets update the phase and target pods inside the default log fields
+// the experiment is in chaos injection phase until the targets are reverted
+func setLogTargets(chaosStatus string, chaosDetails *types.ChaosDetails) {
+	switch chaosStatus {
+	case "reverted", "re-attached":
+		log.SetPhase("PostChaos")
+	default:
+		log.SetPhase(types.ChaosInject)
+	}
+
+	var pods []string
+	for _, target := range chaosDetails.Targets {
+		if strings.ToLower(target.Kind) == "pod" {
+			pods = append(pods, target.Name)
+		}
+	}
+	log.SetTargetPod(strings.Join(pods, ","))
+}
+
 // SetParentName set the parent name in chaosdetails struct
 func SetParentName(parentName string, chaosDetails *types.ChaosDetails) {
 	if chaosDetails.ParentsResources == nil {
@@ -268,8 +323,20 @@ func SetParentName(parentName string, chaosDetails *types.ChaosDetails) {
 
 // GetTargetPodsWhenTargetPodsENVNotSet derives the random target pod list, if TARGET_PODS env is not set
 func GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc int, clients clients.ClientSets, nonChaosPods core_v1.PodList, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
+	filteredPods, err := filterAnnotatedPods(clients, nonChaosPods, chaosDetails)
+	if err != nil {
+		return core_v1.PodList{}, err
+	}
+
+	if len(filteredPods.Items) == 0 {
+		return filteredPods, errors.Errorf("No target pod found")
+	}
+	return selectRandomPods(podAffPerc, filteredPods), nil
+}
+
+// filterAnnotatedPods returns the pods, whose parent is annotated if the annotation check is enabled
+func filterAnnotatedPods(clients clients.ClientSets, nonChaosPods core_v1.PodList, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
 	filteredPods := core_v1.PodList{}
-	realPods := core_v1.PodList{}
 	for _, pod := range nonChaosPods.Items {
 		switch chaosDetails.AppDetail.AnnotationCheck {
 		case true:
@@ -288,11 +355,12 @@ func GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc int, clients clients.Client
 			filteredPods.Items = append(filteredPods.Items, pod)
 		}
 	}
+	return filteredPods, nil
+}
 
-	if len(filteredPods.Items) == 0 {
-		return filteredPods, errors.Errorf("No target pod found")
-	}
-
+// selectRandomPods selects the pods affected percentage of the given pods, at least one pod is selected
+func selectRandomPods(podAffPerc int, filteredPods core_v1.PodList) core_v1.PodList {
+	realPods := core_v1.PodList{}
 	newPodListLength := math.Maximum(1, math.Adjustment(math.Minimum(podAffPerc, 100), len(filteredPods.Items)))
 	rand.Seed(time.Now().UnixNano())
 
@@ -303,6 +371,52 @@ func GetTargetPodsWhenTargetPodsENVNotSet(podAffPerc int, clients clients.Client
 		realPods.Items = append(realPods.Items, filteredPods.Items[index])
 		index = (index + 1) % len(filteredPods.Items)
 	}
+	return realPods
+}
+
+// getMultiTargetPods derives the random target pods from all the namespace, label and kind tuples
+// the pods affected percentage of the tuple is applied on its pods, otherwise the PODS_AFFECTED_PERC
+// is applied on the pods of all the tuples together or on each tuple, based on the PODS_AFFECTED_PERC_SCOPE
+func getMultiTargetPods(podAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
+
+	targets, err := apptargets.Resolve(clients, chaosDetails.AppDetail)
+	if err != nil {
+		return core_v1.PodList{}, err
+	}
+
+	perTarget := strings.ToLower(chaosDetails.AppDetail.PodsAffectedPercScope) == "target"
+	realPods, globalPods := core_v1.PodList{}, core_v1.PodList{}
+	for _, target := range targets {
+		targetDetails := apptargets.WithTarget(chaosDetails, target)
+		nonChaosPods, err := FilterNonChaosPods(clients, targetDetails)
+		if err != nil {
+			return core_v1.PodList{}, err
+		}
+		filteredPods, err := filterAnnotatedPods(clients, nonChaosPods, targetDetails)
+		if err != nil {
+			return core_v1.PodList{}, err
+		}
+		if len(filteredPods.Items) == 0 {
+			log.Warnf("[Chaos]: No target pod found in %v namespace with %v label", target.Namespace, target.Label)
+			continue
+		}
+
+		switch {
+		case target.PodsAffectedPerc != 0:
+			realPods.Items = append(realPods.Items, selectRandomPods(target.PodsAffectedPerc, filteredPods).Items...)
+		case perTarget:
+			realPods.Items = append(realPods.Items, selectRandomPods(podAffPerc, filteredPods).Items...)
+		default:
+			globalPods.Items = append(globalPods.Items, filteredPods.Items...)
+		}
+	}
+
+	if len(globalPods.Items) != 0 {
+		realPods.Items = append(realPods.Items, selectRandomPods(podAffPerc, globalPods).Items...)
+	}
+	if len(realPods.Items) == 0 {
+		return realPods, errors.Errorf("No target pod found")
+	}
 	return realPods, nil
 }
 
@@ -370,6 +484,22 @@ func GetContainerID(appNamespace, targetPod, targetContainer string, clients cli
 	return containerID, nil
 }
 
+//GetContainerPID derive the container id and the pid of the application container through the container runtime
+func GetContainerPID(appNamespace, targetPod, targetContainer string, runtime containerRuntime.ContainerRuntime, clients clients.ClientSets) (string, int, error) {
+
+	containerID, err := GetContainerID(appNamespace, targetPod, targetContainer, clients)
+	if err != nil {
+		return "", 0, err
+	}
+	// extract out the pid of the target container
+	pid, err := runtime.PID(containerID)
+	if err != nil {
+		return "", 0, err
+	}
+	log.Infof("[Info]: Container ID=%v has process PID=%v", containerID, pid)
+	return containerID, pid, nil
+}
+
 // CheckContainerStatus checks the status of the application container
 func CheckContainerStatus(appNamespace, appName string, clients clients.ClientSets) error {
 	return retry.
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	containerRuntime "github.com/litmuschaos/litmus-go/pkg/utils/runtime"
+	"github.com/pkg/errors"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// targetPodClients returns the clients with the target pod running the nginx container with the given id
+func targetPodClients(containerID string) clients.ClientSets {
+	pod := &core_v1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "default"},
+		Status: core_v1.PodStatus{
+			ContainerStatuses: []core_v1.ContainerStatus{{Name: "nginx", ContainerID: "containerd://" + containerID}},
+		},
+	}
+	return clients.ClientSets{KubeClient: fake.NewSimpleClientset(pod)}
+}
+
+func TestGetContainerPID(t *testing.T) {
+	runtime := containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: 10, Running: true})
+
+	containerID, pid, err := GetContainerPID("default", "app", "nginx", runtime, targetPodClients("abc"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if containerID != "abc" || pid != 10 {
+		t.Fatalf("expected abc container with 10 pid, got %v container with %v pid", containerID, pid)
+	}
+}
+
+func TestGetContainerPIDRuntimeError(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime func() *containerRuntime.Fake
+	}{
+		{
+			name: "container not running",
+			runtime: func() *containerRuntime.Fake {
+				return containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: 10})
+			},
+		},
+		{
+			name: "container not found",
+			runtime: func() *containerRuntime.Fake {
+				return containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "xyz", PID: 10, Running: true})
+			},
+		},
+		{
+			name: "runtime unavailable",
+			runtime: func() *containerRuntime.Fake {
+				runtime := containerRuntime.NewFake(containerRuntime.ContainerInfo{ID: "abc", PID: 10, Running: true})
+				runtime.Err = errors.Errorf("runtime unavailable")
+				return runtime
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := GetContainerPID("default", "app", "nginx", tt.runtime(), targetPodClients("abc")); err == nil {
+				t.Fatalf("expected the runtime error to be returned")
+			}
+		})
+	}
+}
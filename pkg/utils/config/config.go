@@ -0,0 +1,278 @@
+package config
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Load builds the given struct from the sources, using the struct tags of its fields
+// the value is looked up inside the sources in the given order, the first source containing the key wins
+//
+// the fields are described by the following tags:
+//
+//	env:"KEY"          key of the value inside the sources
+//	default:"value"    value used, if the key is not present inside any source
+//	validate:"rules"   comma separated rules: required, percentage, duration=s|ms, min=N, enum=a|b, interval
+//
+// it validates all the fields and returns all the validation errors together
+func Load(target interface{}, sources ...Source) error {
+
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("target should be a pointer to the struct, found: %T", target)
+	}
+
+	var errs ValidationErrors
+	load(value.Elem(), sources, &errs)
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidationErrors contains all the validation errors of the configuration
+type ValidationErrors []error
+
+// Error returns all the validation errors, separated by semicolon
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for index, err := range errs {
+		messages[index] = err.Error()
+	}
+	return "invalid configuration: " + strings.Join(messages, "; ")
+}
+
+// Merge merges the errors of the different configurations into a single validation error
+// it returns nil, if there is no error
+func Merge(errs ...error) error {
+	var merged ValidationErrors
+	for _, err := range errs {
+		switch err := err.(type) {
+		case nil:
+		case ValidationErrors:
+			merged = append(merged, err...)
+		default:
+			merged = append(merged, err)
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// load sets all the tagged fields of the struct, the nested structs are loaded recursively
+func load(value reflect.Value, sources []Source, errs *ValidationErrors) {
+
+	for index := 0; index < value.NumField(); index++ {
+		field := value.Type().Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		key, ok := field.Tag.Lookup("env")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				load(value.Field(index), sources, errs)
+			}
+			continue
+		}
+
+		raw, found := lookup(key, sources)
+		if !found {
+			raw = field.Tag.Get("default")
+		}
+
+		rules := parseRules(field.Tag.Get("validate"))
+		if err := setField(value.Field(index), raw, rules); err != nil {
+			*errs = append(*errs, errors.Errorf("%v: %v", key, err))
+		}
+	}
+}
+
+// lookup returns the value of the key from the first source containing it
+func lookup(key string, sources []Source) (string, bool) {
+	for _, source := range sources {
+		if value, ok := source.Lookup(key); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// parseRules parse the validate tag into the rules, it maps the rule name to its argument
+func parseRules(tag string) map[string]string {
+	rules := map[string]string{}
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		name, arg := rule, ""
+		if index := strings.Index(rule, "="); index != -1 {
+			name, arg = rule[:index], rule[index+1:]
+		}
+		rules[name] = arg
+	}
+	return rules
+}
+
+// setField parse, validate and set the raw value inside the field
+func setField(field reflect.Value, raw string, rules map[string]string) error {
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		if _, ok := rules["required"]; ok {
+			return errors.Errorf("value is required")
+		}
+		return nil
+	}
+
+	if values, ok := rules["enum"]; ok {
+		value, err := matchEnum(raw, strings.Split(values, "|"))
+		if err != nil {
+			return err
+		}
+		raw = value
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		if _, ok := rules["interval"]; ok {
+			if err := validateInterval(raw); err != nil {
+				return err
+			}
+		}
+		field.SetString(raw)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.Errorf("%q is not a valid boolean", raw)
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		value, err := parseInt(raw, rules)
+		if err != nil {
+			return err
+		}
+		if min, ok := rules["min"]; ok {
+			minValue, err := strconv.ParseInt(min, 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid min rule %q", min)
+			}
+			if value < minValue {
+				return errors.Errorf("%v is less than the minimum value %v", raw, minValue)
+			}
+		}
+		field.SetInt(value)
+	case reflect.Float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.Errorf("%q is not a valid number", raw)
+		}
+		field.SetFloat(value)
+	default:
+		return errors.Errorf("unsupported field type %v", field.Type())
+	}
+	return nil
+}
+
+// parseInt parse the integer value, as a duration or percentage if the respective rule is provided
+func parseInt(raw string, rules map[string]string) (int64, error) {
+
+	if unit, ok := rules["duration"]; ok {
+		return parseDuration(raw, unit)
+	}
+
+	if _, ok := rules["percentage"]; ok {
+		value, err := strconv.ParseInt(strings.TrimSuffix(raw, "%"), 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("%q is not a valid percentage", raw)
+		}
+		if value < 0 || value > 100 {
+			return 0, errors.Errorf("%v is not a valid percentage, it should be between 0 and 100", raw)
+		}
+		return value, nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("%q is not a valid integer", raw)
+	}
+	return value, nil
+}
+
+// parseDuration parse the duration in the given unit (s or ms)
+// the plain numbers are considered in the given unit, otherwise the unit should be provided like 30s, 1m30s
+func parseDuration(raw, unit string) (int64, error) {
+
+	var base time.Duration
+	switch unit {
+	case "", "s":
+		base = time.Second
+	case "ms":
+		base = time.Millisecond
+	default:
+		return 0, errors.Errorf("unsupported duration unit %q", unit)
+	}
+
+	var duration time.Duration
+	if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		duration = time.Duration(value) * base
+	} else {
+		if duration, err = time.ParseDuration(raw); err != nil {
+			return 0, errors.Errorf("%q is not a valid duration, provide the duration like 30s, 5m or plain %v", raw, unitName(unit))
+		}
+	}
+	if duration < 0 {
+		return 0, errors.Errorf("%v is not a valid duration, it should not be negative", raw)
+	}
+	if duration%base != 0 {
+		return 0, errors.Errorf("%v is not a valid duration, it should be in the multiples of %v", raw, base)
+	}
+	return int64(duration / base), nil
+}
+
+// unitName returns the name of the duration unit, used in the error messages
+func unitName(unit string) string {
+	if unit == "ms" {
+		return "milliseconds"
+	}
+	return "seconds"
+}
+
+// matchEnum checks whether the value is one of the allowed values, the comparison is case insensitive
+// it returns the allowed value as declared in the rule, so that the consumers can compare it exactly
+func matchEnum(raw string, values []string) (string, error) {
+	for _, value := range values {
+		if strings.EqualFold(raw, value) {
+			return value, nil
+		}
+	}
+	return "", errors.Errorf("%q is not supported, supported values: %v", raw, strings.Join(values, ", "))
+}
+
+// validateInterval checks whether the value is an interval in seconds or a range of intervals like 5-15
+// the range is used to derive a random interval between its bounds
+func validateInterval(raw string) error {
+	bounds := strings.Split(raw, "-")
+	if len(bounds) > 2 {
+		return errors.Errorf("%q is not a valid interval, provide the seconds like 10 or the range like 5-15", raw)
+	}
+	values := make([]int, len(bounds))
+	for index, bound := range bounds {
+		value, err := strconv.Atoi(strings.TrimSpace(bound))
+		if err != nil || value < 0 {
+			return errors.Errorf("%q is not a valid interval, provide the seconds like 10 or the range like 5-15", raw)
+		}
+		values[index] = value
+	}
+	if len(values) == 2 && values[0] >= values[1] {
+		return errors.Errorf("%v is not a valid interval range, the lower bound should be less than the upper bound", raw)
+	}
+	return nil
+}
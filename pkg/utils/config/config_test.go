@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type testConfig struct {
+	Lib              string `env:"LIB" default:"litmus" validate:"enum=litmus|pumba"`
+	ContainerRuntime string `env:"CONTAINER_RUNTIME" default:"docker" validate:"enum=docker|containerd|crio"`
+	ChaosDuration    int    `env:"TOTAL_CHAOS_DURATION" default:"30" validate:"duration=s,min=1"`
+	PodsAffectedPerc int    `env:"PODS_AFFECTED_PERC" validate:"percentage"`
+}
+
+func TestLoadCanonicalEnum(t *testing.T) {
+	config := testConfig{}
+	if err := Load(&config, Map{"LIB": "Pumba", "CONTAINER_RUNTIME": "CONTAINERD"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Lib != "pumba" || config.ContainerRuntime != "containerd" {
+		t.Fatalf("expected the declared enum values, got %v and %v", config.Lib, config.ContainerRuntime)
+	}
+}
+
+func TestLoadReportsAllErrors(t *testing.T) {
+	config := testConfig{}
+	err := Load(&config, Map{"LIB": "chaosblade", "TOTAL_CHAOS_DURATION": "60x", "PODS_AFFECTED_PERC": "120"})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("expected 3 validation errors, got %v", err)
+	}
+	for _, key := range []string{"LIB", "TOTAL_CHAOS_DURATION", "PODS_AFFECTED_PERC"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Fatalf("expected the %v error, got %v", key, err)
+		}
+	}
+}
+
+func TestLoadDuration(t *testing.T) {
+	config := testConfig{}
+	if err := Load(&config, Map{"TOTAL_CHAOS_DURATION": "1m30s"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.ChaosDuration != 90 {
+		t.Fatalf("expected 90s chaos duration, got %v", config.ChaosDuration)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	if err := Merge(nil, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err := Merge(ValidationErrors{errors.Errorf("LIB: invalid")}, nil, errors.Errorf("APP_TARGETS: invalid"))
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("expected 2 validation errors, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// FileEnv is the env, which contains the path of the configuration file
+const FileEnv = "EXPERIMENT_CONFIG"
+
+// Source provides the raw values of the configuration
+type Source interface {
+	// Lookup returns the value of the key, it returns false if the key is not present
+	Lookup(key string) (string, bool)
+}
+
+// Env is the source, which looks up the values inside the env
+// the empty env values are considered as not present, similar to types.Getenv
+type Env struct{}
+
+// Lookup returns the value of the env
+func (Env) Lookup(key string) (string, bool) {
+	value := os.Getenv(key)
+	return value, value != ""
+}
+
+// Map is the source, which looks up the values inside the map
+type Map map[string]string
+
+// Lookup returns the value of the key inside the map
+func (values Map) Lookup(key string) (string, bool) {
+	value, ok := values[key]
+	return value, ok && value != ""
+}
+
+// File reads the values from the yaml or json file, which contains the env keys and their values
+func File(path string) (Map, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Errorf("unable to read the %v config file, err: %v", path, err)
+	}
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Errorf("unable to parse the %v config file, err: %v", path, err)
+	}
+	values := Map{}
+	for key, value := range raw {
+		switch value.(type) {
+		case string, bool, float64, nil:
+			if value != nil {
+				values[key] = fmt.Sprint(value)
+			}
+		default:
+			return nil, errors.Errorf("%v key of the %v config file should be a string, number or boolean", key, path)
+		}
+	}
+	return values, nil
+}
+
+// DefaultSources returns the env followed by the config file, if its path is provided inside the EXPERIMENT_CONFIG env
+func DefaultSources() ([]Source, error) {
+	sources := []Source{Env{}}
+	if path := os.Getenv(FileEnv); path != "" {
+		file, err := File(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, file)
+	}
+	return sources, nil
+}
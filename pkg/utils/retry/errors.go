@@ -0,0 +1,67 @@
+package retry
+
+import (
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// permanentError wraps the error, which should not be retried
+type permanentError struct {
+	err error
+}
+
+// Error returns the message of the wrapped error
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the wrapped error
+func (e *permanentError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error as permanent, the retry is stopped immediately
+// and the wrapped error is returned to the caller
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent checks whether the error or any error wrapped by it is marked as permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// unwrapPermanent removes the permanent mark from the error
+// the context added by wrapping the permanent error is preserved
+func unwrapPermanent(err error) error {
+	if permanent, ok := err.(*permanentError); ok {
+		return permanent.err
+	}
+	return err
+}
+
+// IsPermanentAPIError checks whether the kubernetes api rejected the request for a reason, which retries can't fix
+// like the forbidden, unauthorized or invalid requests
+// it can be used as the StopIf predicate of the retries, which call the kubernetes api
+func IsPermanentAPIError(err error) bool {
+	var status interface{ Status() metav1.Status }
+	if !errors.As(err, &status) {
+		return false
+	}
+	switch status.Status().Reason {
+	case metav1.StatusReasonForbidden, metav1.StatusReasonUnauthorized, metav1.StatusReasonBadRequest,
+		metav1.StatusReasonInvalid, metav1.StatusReasonMethodNotAllowed:
+		return true
+	}
+	return false
+}
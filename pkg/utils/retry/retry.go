@@ -1,7 +1,9 @@
 package retry
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,11 +12,21 @@ import (
 // Action defines the prototype of action function, function as a value
 type Action func(attempt uint) error
 
+// ContextAction defines the prototype of the context aware action function
+// the context is cancelled, if the retry is aborted or the per-attempt deadline expires
+type ContextAction func(ctx context.Context, attempt uint) error
+
 // Model defines the schema, contains all the attributes need for retry
 type Model struct {
-	retry    uint
-	waitTime time.Duration
-	timeout  int64
+	retry          uint
+	waitTime       time.Duration
+	timeout        time.Duration
+	ctx            context.Context
+	backoff        float64
+	maxWait        time.Duration
+	jitter         float64
+	attemptTimeout time.Duration
+	stopIf         func(error) bool
 }
 
 // Times is used to define the retry count
@@ -45,38 +57,75 @@ func (model *Model) Wait(waitTime time.Duration) *Model {
 	return model
 }
 
-// Timeout is used to define the timeout duration for each iteration of retry
+// Timeout is used to define the timeout duration (in sec) for each iteration of retry
 // it will run if the instance of model is not present before
 func Timeout(timeout int64) *Model {
 	model := Model{}
 	return model.Timeout(timeout)
 }
 
-// Timeout is used to define the timeout duration for each iteration of retry
+// Timeout is used to define the timeout duration (in sec) for each iteration of retry
 // it will run if the instance of model is already present
 func (model *Model) Timeout(timeout int64) *Model {
+	model.timeout = time.Duration(timeout) * time.Second
+	return model
+}
+
+// TimeoutDuration is used to define the timeout duration for each iteration of retry
+// it supports the sub-second timeouts
+func (model *Model) TimeoutDuration(timeout time.Duration) *Model {
 	model.timeout = timeout
 	return model
 }
 
+// Context is used to define the context of the retry
+// the pending waits and attempts are interrupted, once the context is cancelled
+func Context(ctx context.Context) *Model {
+	model := Model{}
+	return model.Context(ctx)
+}
+
+// Context is used to define the context of the retry
+// the pending waits and attempts are interrupted, once the context is cancelled
+func (model *Model) Context(ctx context.Context) *Model {
+	model.ctx = ctx
+	return model
+}
+
+// Backoff is used to define the exponential backoff of the wait duration
+// the wait duration is multiplied by the factor after each iteration, upto the maxWait (if provided)
+func (model *Model) Backoff(factor float64, maxWait time.Duration) *Model {
+	model.backoff = factor
+	model.maxWait = maxWait
+	return model
+}
+
+// Jitter is used to randomize the wait duration by the given fraction
+// the wait duration is picked uniformly from [wait*(1-fraction), wait*(1+fraction)]
+func (model *Model) Jitter(fraction float64) *Model {
+	model.jitter = fraction
+	return model
+}
+
+// AttemptTimeout is used to define the deadline for each attempt of the context aware actions
+func (model *Model) AttemptTimeout(timeout time.Duration) *Model {
+	model.attemptTimeout = timeout
+	return model
+}
+
+// StopIf is used to define the predicate for the permanent errors
+// retry is stopped immediately, if the predicate returns true for the error returned by the action
+func (model *Model) StopIf(predicate func(error) bool) *Model {
+	model.stopIf = predicate
+	return model
+}
+
 // Try is used to run a action with retries and some delay after each iteration
 func (model Model) Try(action Action) error {
 	if action == nil {
 		return fmt.Errorf("no action specified")
 	}
-
-	var err error
-	for attempt := uint(0); (attempt == 0 || err != nil) && attempt <= model.retry; attempt++ {
-		err = action(attempt)
-		if model.waitTime > 0 {
-			time.Sleep(model.waitTime)
-		}
-		if err == errors.Errorf("container is in terminated state") {
-			break
-		}
-	}
-
-	return err
+	return model.run(func(_ context.Context, attempt uint) error { return action(attempt) }, false)
 }
 
 // TryWithTimeout is used to run a action with retries
@@ -85,20 +134,108 @@ func (model Model) TryWithTimeout(action Action) error {
 	if action == nil {
 		return fmt.Errorf("no action specified")
 	}
+	return model.run(func(_ context.Context, attempt uint) error { return action(attempt) }, true)
+}
+
+// TryWithContext is used to run a context aware action with retries
+// it behaves like TryWithTimeout if timeout is provided, otherwise like Try
+func (model Model) TryWithContext(action ContextAction) error {
+	if action == nil {
+		return fmt.Errorf("no action specified")
+	}
+	return model.run(action, model.timeout > 0)
+}
+
+// run executes the action until it succeeds, retries are exhausted, the error is permanent or the context is cancelled
+// if withTimeout is true, each iteration of retry runs the action until the timeout expires
+func (model Model) run(action ContextAction, withTimeout bool) error {
+	ctx := model.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	var err error
-	err = nil
-	for attempt := uint(0); (attempt == 0 || err != nil) && attempt <= model.retry; attempt++ {
-		startTime := time.Now().Unix()
-		currentTime := time.Now().Unix()
-		for trial := uint(0); (trial == 0 || err != nil) && currentTime < startTime+model.timeout; trial++ {
-			err = action(attempt)
-			if model.waitTime > 0 {
-				time.Sleep(model.waitTime)
+	wait := model.waitTime
+	first := true
+	for attempt := uint(0); attempt <= model.retry; attempt++ {
+		startTime := time.Now()
+		for trial := 0; trial == 0 || (withTimeout && time.Since(startTime) < model.timeout); trial++ {
+			// wait before all the trials, except the very first one
+			if !first {
+				if waitErr := sleep(ctx, model.randomize(wait)); waitErr != nil {
+					return aborted(waitErr, err)
+				}
+				wait = model.next(wait)
+			}
+			first = false
+
+			if err = model.attempt(ctx, action, attempt); err == nil {
+				return nil
+			}
+			if IsPermanent(err) {
+				return unwrapPermanent(err)
+			}
+			if model.stopIf != nil && model.stopIf(err) {
+				return err
+			}
+			if ctx.Err() != nil {
+				return aborted(ctx.Err(), err)
 			}
-			currentTime = time.Now().Unix()
 		}
+	}
+	return err
+}
 
+// attempt runs the action once, with the per-attempt deadline (if provided)
+func (model Model) attempt(ctx context.Context, action ContextAction, attempt uint) error {
+	if model.attemptTimeout <= 0 {
+		return action(ctx, attempt)
 	}
+	attemptCtx, cancel := context.WithTimeout(ctx, model.attemptTimeout)
+	defer cancel()
+	return action(attemptCtx, attempt)
+}
 
-	return err
+// next derive the wait duration for the next iteration from the backoff factor
+func (model Model) next(wait time.Duration) time.Duration {
+	if model.backoff <= 1 {
+		return wait
+	}
+	wait = time.Duration(float64(wait) * model.backoff)
+	if model.maxWait > 0 && wait > model.maxWait {
+		wait = model.maxWait
+	}
+	return wait
+}
+
+// randomize applies the jitter to the wait duration
+func (model Model) randomize(wait time.Duration) time.Duration {
+	if model.jitter <= 0 || wait <= 0 {
+		return wait
+	}
+	delta := model.jitter * float64(wait)
+	return time.Duration(float64(wait) - delta + rand.Float64()*2*delta)
+}
+
+// sleep waits for the given duration, it returns early if the context is cancelled
+func sleep(ctx context.Context, wait time.Duration) error {
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// aborted returns the error for the interrupted retries, along with the last error of the action
+func aborted(ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return errors.Errorf("retry aborted, err: %v", ctxErr)
+	}
+	return errors.Errorf("retry aborted, err: %v, last error: %v", ctxErr, lastErr)
 }
@@ -0,0 +1,72 @@
+package retry
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestTryStopsOnWrappedPermanentError(t *testing.T) {
+	tests := map[string]func(error) error{
+		"pkg/errors wrap": func(err error) error { return errors.Wrap(err, "unable to check the pod") },
+		"fmt wrap":        func(err error) error { return fmt.Errorf("unable to check the pod: %w", err) },
+	}
+	for name, wrap := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			err := Times(5).Wait(time.Millisecond).Try(func(attempt uint) error {
+				attempts++
+				return wrap(Permanent(errors.Errorf("container is in terminated state")))
+			})
+			if attempts != 1 {
+				t.Fatalf("expected a single attempt, got %v", attempts)
+			}
+			if !IsPermanent(err) {
+				t.Fatalf("expected the wrapped permanent error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTryStopIf(t *testing.T) {
+	attempts := 0
+	forbidden := k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "app", errors.Errorf("rbac"))
+	err := Times(5).Wait(time.Millisecond).StopIf(IsPermanentAPIError).Try(func(attempt uint) error {
+		attempts++
+		return errors.Wrap(forbidden, "unable to list the pods")
+	})
+	if attempts != 1 || err == nil {
+		t.Fatalf("expected a single failed attempt, got %v attempts with %v error", attempts, err)
+	}
+
+	attempts = 0
+	notFound := k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "app")
+	Times(2).Wait(time.Millisecond).StopIf(IsPermanentAPIError).Try(func(attempt uint) error {
+		attempts++
+		return notFound
+	})
+	if attempts != 3 {
+		t.Fatalf("expected the not found error to be retried, got %v attempts", attempts)
+	}
+}
+
+func TestBackoffAndJitter(t *testing.T) {
+	model := Wait(time.Second).Backoff(2, 5*time.Second).Jitter(0.1)
+
+	wait := time.Second
+	for _, want := range []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		wait = model.next(wait)
+		if wait != want {
+			t.Fatalf("expected %v wait, got %v", want, wait)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if jittered := model.randomize(time.Second); jittered < 900*time.Millisecond || jittered > 1100*time.Millisecond {
+			t.Fatalf("expected the jittered wait within 10%% of a second, got %v", jittered)
+		}
+	}
+}
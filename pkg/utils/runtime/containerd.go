@@ -0,0 +1,150 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"syscall"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// containerdNamespace is the containerd namespace used by kubernetes
+	containerdNamespace = "k8s.io"
+	// containerdNamespaceHeader is the grpc header used to select the containerd namespace
+	containerdNamespaceHeader = "containerd-namespace"
+)
+
+// containerdRuntime talks to containerd through its native grpc api
+type containerdRuntime struct {
+	conn       *grpc.ClientConn
+	tasks      tasksapi.TasksClient
+	containers containersapi.ContainersClient
+}
+
+// newContainerdRuntime returns the containerd runtime for the given socket
+func newContainerdRuntime(socketPath string) (*containerdRuntime, error) {
+	conn, err := dialUnix(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	return &containerdRuntime{
+		conn:       conn,
+		tasks:      tasksapi.NewTasksClient(conn),
+		containers: containersapi.NewContainersClient(conn),
+	}, nil
+}
+
+// Inspect returns the details of the container
+func (c *containerdRuntime) Inspect(containerID string) (ContainerInfo, error) {
+	ctx, cancel := c.context(requestTimeout)
+	defer cancel()
+
+	container, err := c.containers.Get(ctx, &containersapi.GetContainerRequest{ID: containerID})
+	if err != nil {
+		return ContainerInfo{}, errors.Errorf("[containerd]: unable to get %v container, err: %v", containerID, err)
+	}
+	info := ContainerInfo{
+		ID:   container.Container.ID,
+		Name: container.Container.Labels["io.kubernetes.container.name"],
+	}
+
+	resp, err := c.tasks.Get(ctx, &tasksapi.GetRequest{ContainerID: containerID})
+	if err != nil {
+		return ContainerInfo{}, errors.Errorf("[containerd]: unable to get the task of %v container, err: %v", containerID, err)
+	}
+	if resp.Process != nil {
+		info.PID = int(resp.Process.Pid)
+		info.Running = resp.Process.Status == task.StatusRunning
+	}
+
+	// network namespace path is present inside `linux.namespaces` of the oci spec
+	if container.Container.Spec != nil {
+		var spec runtimeDetails
+		if err := json.Unmarshal(container.Container.Spec.Value, &spec); err != nil {
+			return ContainerInfo{}, errors.Errorf("[containerd]: unable to parse the spec of %v container, err: %v", containerID, err)
+		}
+		for _, ns := range spec.Linux.Namespaces {
+			if ns.Type == "network" && ns.Path != "" {
+				info.NetnsPath = ns.Path
+			}
+		}
+	}
+	if info.NetnsPath == "" && info.PID != 0 {
+		info.NetnsPath = procNetnsPath(info.PID)
+	}
+	return info, nil
+}
+
+// PID returns the pid of the main process of the container
+func (c *containerdRuntime) PID(containerID string) (int, error) {
+	info, err := c.Inspect(containerID)
+	if err != nil {
+		return 0, err
+	}
+	return getPIDFromInfo(info)
+}
+
+// Stop stops the container gracefully, it is killed if it doesn't stop within the timeout
+func (c *containerdRuntime) Stop(containerID string, timeout time.Duration) error {
+	if err := c.Kill(containerID, syscall.SIGTERM); err != nil {
+		return err
+	}
+
+	// wait for the task to exit, before killing it
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		info, err := c.Inspect(containerID)
+		if err != nil {
+			return err
+		}
+		if !info.Running {
+			return nil
+		}
+		time.Sleep(time.Second)
+	}
+	return c.Kill(containerID, syscall.SIGKILL)
+}
+
+// Kill sends the signal to the main process of the container
+func (c *containerdRuntime) Kill(containerID string, signal syscall.Signal) error {
+	ctx, cancel := c.context(requestTimeout)
+	defer cancel()
+
+	if _, err := c.tasks.Kill(ctx, &tasksapi.KillRequest{
+		ContainerID: containerID,
+		Signal:      uint32(signal),
+	}); err != nil {
+		return errors.Errorf("[containerd]: unable to send %v signal to %v container, err: %v", signal, containerID, err)
+	}
+	return nil
+}
+
+// NetnsPath returns the path of the network namespace of the container
+func (c *containerdRuntime) NetnsPath(containerID string) (string, error) {
+	info, err := c.Inspect(containerID)
+	if err != nil {
+		return "", err
+	}
+	if info.NetnsPath == "" {
+		return "", errors.Errorf("[containerd]: network namespace of %v container not found", containerID)
+	}
+	return info.NetnsPath, nil
+}
+
+// Close release the grpc connection
+func (c *containerdRuntime) Close() error {
+	return c.conn.Close()
+}
+
+// context returns the request context inside the kubernetes namespace of containerd
+func (c *containerdRuntime) context(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return metadata.AppendToOutgoingContext(ctx, containerdNamespaceHeader, containerdNamespace), cancel
+}
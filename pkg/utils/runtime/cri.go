@@ -0,0 +1,175 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+// gracefulStopTimeout is the timeout used to stop the container on SIGTERM
+// cri doesn't support the arbitrary signals, so SIGTERM is sent through the graceful stop
+const gracefulStopTimeout = 30 * time.Second
+
+// criRuntime talks to the container runtime through the cri grpc api
+type criRuntime struct {
+	conn   *grpc.ClientConn
+	client criapi.RuntimeServiceClient
+}
+
+// criInfo JSON representation of the verbose info of the container status
+// in containerd, pid is present inside `info.pid` and the namespaces inside `info.runtimeSpec.linux.namespaces`
+// in crio, pid is present either inside `pid` or inside `info.pid`
+type criInfo struct {
+	PID         int            `json:"pid"`
+	RuntimeSpec runtimeDetails `json:"runtimeSpec"`
+}
+
+// runtimeDetails contains the oci runtime spec details
+type runtimeDetails struct {
+	Linux linuxAttributes `json:"linux"`
+}
+
+// linuxAttributes contains all the linux attributes
+type linuxAttributes struct {
+	Namespaces []namespace `json:"namespaces"`
+}
+
+// namespace contains linux namespace details
+type namespace struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// newCRIRuntime returns the cri runtime for the given socket
+func newCRIRuntime(socketPath string) (*criRuntime, error) {
+	conn, err := dialUnix(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	return &criRuntime{
+		conn:   conn,
+		client: criapi.NewRuntimeServiceClient(conn),
+	}, nil
+}
+
+// Inspect returns the details of the container
+func (c *criRuntime) Inspect(containerID string) (ContainerInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := c.client.ContainerStatus(ctx, &criapi.ContainerStatusRequest{
+		ContainerId: containerID,
+		Verbose:     true,
+	})
+	if err != nil {
+		return ContainerInfo{}, errors.Errorf("[cri]: unable to get the status of %v container, err: %v", containerID, err)
+	}
+	if resp.Status == nil {
+		return ContainerInfo{}, errors.Errorf("[cri]: status of %v container not found", containerID)
+	}
+
+	info := ContainerInfo{
+		ID:      resp.Status.Id,
+		Running: resp.Status.State == criapi.ContainerState_CONTAINER_RUNNING,
+	}
+	if resp.Status.Metadata != nil {
+		info.Name = resp.Status.Metadata.Name
+	}
+
+	verboseInfo, err := parseCRIInfo(resp.Info)
+	if err != nil {
+		return ContainerInfo{}, err
+	}
+	info.PID = verboseInfo.PID
+	for _, ns := range verboseInfo.RuntimeSpec.Linux.Namespaces {
+		if ns.Type == "network" && ns.Path != "" {
+			info.NetnsPath = ns.Path
+			// network namespace path is in the form of `/proc/<pid>/ns/net`, if it is shared with the other process
+			if info.PID == 0 && strings.HasPrefix(ns.Path, "/proc/") {
+				info.PID, _ = strconv.Atoi(strings.Split(ns.Path, "/")[2])
+			}
+		}
+	}
+	if info.NetnsPath == "" && info.PID != 0 {
+		info.NetnsPath = procNetnsPath(info.PID)
+	}
+	return info, nil
+}
+
+// PID returns the pid of the main process of the container
+func (c *criRuntime) PID(containerID string) (int, error) {
+	info, err := c.Inspect(containerID)
+	if err != nil {
+		return 0, err
+	}
+	return getPIDFromInfo(info)
+}
+
+// Stop stops the container gracefully, it is killed if it doesn't stop within the timeout
+func (c *criRuntime) Stop(containerID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout+timeout)
+	defer cancel()
+
+	if _, err := c.client.StopContainer(ctx, &criapi.StopContainerRequest{
+		ContainerId: containerID,
+		Timeout:     int64(timeout.Seconds()),
+	}); err != nil {
+		return errors.Errorf("[cri]: unable to stop %v container, err: %v", containerID, err)
+	}
+	return nil
+}
+
+// Kill sends the signal to the main process of the container
+// it supports SIGKILL and SIGTERM signals only
+func (c *criRuntime) Kill(containerID string, signal syscall.Signal) error {
+	switch signal {
+	case syscall.SIGKILL:
+		return c.Stop(containerID, 0)
+	case syscall.SIGTERM:
+		return c.Stop(containerID, gracefulStopTimeout)
+	default:
+		return errors.Errorf("[cri]: %v signal not supported, use either SIGTERM or SIGKILL", signal)
+	}
+}
+
+// NetnsPath returns the path of the network namespace of the container
+func (c *criRuntime) NetnsPath(containerID string) (string, error) {
+	info, err := c.Inspect(containerID)
+	if err != nil {
+		return "", err
+	}
+	if info.NetnsPath == "" {
+		return "", errors.Errorf("[cri]: network namespace of %v container not found", containerID)
+	}
+	return info.NetnsPath, nil
+}
+
+// Close release the grpc connection
+func (c *criRuntime) Close() error {
+	return c.conn.Close()
+}
+
+// parseCRIInfo parse the verbose info of the container status
+func parseCRIInfo(info map[string]string) (criInfo, error) {
+	var result criInfo
+	if raw, ok := info["info"]; ok {
+		if err := json.Unmarshal([]byte(raw), &result); err != nil {
+			return result, errors.Errorf("[cri]: unable to parse the container info, err: %v", err)
+		}
+	}
+	if raw, ok := info["pid"]; ok && result.PID == 0 {
+		pid, err := strconv.Atoi(raw)
+		if err != nil {
+			return result, errors.Errorf("[cri]: unable to parse the container pid, err: %v", err)
+		}
+		result.PID = pid
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// dockerRuntime talks to the docker engine api through the docker socket
+type dockerRuntime struct {
+	client *http.Client
+}
+
+// dockerInspectResponse JSON representation of the container inspect api response
+type dockerInspectResponse struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	State struct {
+		Running bool `json:"Running"`
+		Pid     int  `json:"Pid"`
+	} `json:"State"`
+}
+
+// newDockerRuntime returns the docker runtime for the given socket
+func newDockerRuntime(socketPath string) *dockerRuntime {
+	return &dockerRuntime{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+				},
+			},
+			Timeout: requestTimeout,
+		},
+	}
+}
+
+// Inspect returns the details of the container
+func (d *dockerRuntime) Inspect(containerID string) (ContainerInfo, error) {
+	body, err := d.do(http.MethodGet, "/containers/"+containerID+"/json", nil)
+	if err != nil {
+		return ContainerInfo{}, err
+	}
+	var resp dockerInspectResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return ContainerInfo{}, errors.Errorf("[docker]: unable to parse the inspect response, err: %v", err)
+	}
+
+	info := ContainerInfo{
+		ID:      resp.ID,
+		Name:    strings.TrimPrefix(resp.Name, "/"),
+		PID:     resp.State.Pid,
+		Running: resp.State.Running,
+	}
+	if info.PID != 0 {
+		info.NetnsPath = procNetnsPath(info.PID)
+	}
+	return info, nil
+}
+
+// PID returns the pid of the main process of the container
+func (d *dockerRuntime) PID(containerID string) (int, error) {
+	info, err := d.Inspect(containerID)
+	if err != nil {
+		return 0, err
+	}
+	return getPIDFromInfo(info)
+}
+
+// Stop stops the container gracefully, it is killed if it doesn't stop within the timeout
+func (d *dockerRuntime) Stop(containerID string, timeout time.Duration) error {
+	query := url.Values{}
+	query.Set("t", strconv.Itoa(int(timeout.Seconds())))
+	_, err := d.do(http.MethodPost, "/containers/"+containerID+"/stop", query)
+	return err
+}
+
+// Kill sends the signal to the main process of the container
+func (d *dockerRuntime) Kill(containerID string, signal syscall.Signal) error {
+	query := url.Values{}
+	query.Set("signal", unix.SignalName(signal))
+	_, err := d.do(http.MethodPost, "/containers/"+containerID+"/kill", query)
+	return err
+}
+
+// NetnsPath returns the path of the network namespace of the container
+func (d *dockerRuntime) NetnsPath(containerID string) (string, error) {
+	pid, err := d.PID(containerID)
+	if err != nil {
+		return "", err
+	}
+	return procNetnsPath(pid), nil
+}
+
+// Close release the idle connections with the docker socket
+func (d *dockerRuntime) Close() error {
+	d.client.CloseIdleConnections()
+	return nil
+}
+
+// do send the request to the docker engine api and returns the response body
+func (d *dockerRuntime) do(method, path string, query url.Values) ([]byte, error) {
+	// host is ignored, as the connection is always made with the docker socket
+	endpoint := "http://docker" + path
+	if len(query) != 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, errors.Errorf("[docker]: unable to send %v request to %v, err: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var dockerErr struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(body, &dockerErr)
+		return nil, errors.Errorf("[docker]: %v request to %v failed, status code: %v, err: %v", method, path, resp.StatusCode, dockerErr.Message)
+	}
+	return body, nil
+}
@@ -0,0 +1,105 @@
+package runtime
+
+import (
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Fake is the in-memory container runtime, it can be used to exercise the helpers without a node
+type Fake struct {
+	mu sync.Mutex
+	// Containers contains the details of the containers, keyed by the container id
+	Containers map[string]ContainerInfo
+	// Signals contains the signals received by each container
+	Signals map[string][]syscall.Signal
+	// Err is returned by all the operations, if provided
+	Err error
+}
+
+// NewFake returns the fake runtime with the given containers
+func NewFake(containers ...ContainerInfo) *Fake {
+	fake := &Fake{
+		Containers: map[string]ContainerInfo{},
+		Signals:    map[string][]syscall.Signal{},
+	}
+	for _, container := range containers {
+		fake.Containers[container.ID] = container
+	}
+	return fake
+}
+
+// Inspect returns the details of the container
+func (f *Fake) Inspect(containerID string) (ContainerInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.get(containerID)
+}
+
+// PID returns the pid of the main process of the container
+func (f *Fake) PID(containerID string) (int, error) {
+	info, err := f.Inspect(containerID)
+	if err != nil {
+		return 0, err
+	}
+	return getPIDFromInfo(info)
+}
+
+// Stop marks the container as stopped
+func (f *Fake) Stop(containerID string, timeout time.Duration) error {
+	return f.Kill(containerID, syscall.SIGTERM)
+}
+
+// Kill records the signal and marks the container as stopped for SIGKILL and SIGTERM
+func (f *Fake) Kill(containerID string, signal syscall.Signal) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := f.get(containerID)
+	if err != nil {
+		return err
+	}
+	f.Signals[containerID] = append(f.Signals[containerID], signal)
+	if signal == syscall.SIGKILL || signal == syscall.SIGTERM {
+		info.Running = false
+		f.Containers[containerID] = info
+	}
+	return nil
+}
+
+// NetnsPath returns the path of the network namespace of the container
+func (f *Fake) NetnsPath(containerID string) (string, error) {
+	info, err := f.Inspect(containerID)
+	if err != nil {
+		return "", err
+	}
+	if info.NetnsPath != "" {
+		return info.NetnsPath, nil
+	}
+	pid, err := getPIDFromInfo(info)
+	if err != nil {
+		return "", err
+	}
+	return procNetnsPath(pid), nil
+}
+
+// Close is a no-op for the fake runtime
+func (f *Fake) Close() error {
+	return nil
+}
+
+// get returns the container details, caller should hold the lock
+func (f *Fake) get(containerID string) (ContainerInfo, error) {
+	if f.Err != nil {
+		return ContainerInfo{}, f.Err
+	}
+	info, ok := f.Containers[containerID]
+	if !ok {
+		return ContainerInfo{}, errors.Errorf("[fake]: %v container not found", containerID)
+	}
+	return info, nil
+}
+
+var _ ContainerRuntime = (*Fake)(nil)
@@ -0,0 +1,97 @@
+package runtime
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// requestTimeout is the timeout of each request sent to the container runtime
+const requestTimeout = 30 * time.Second
+
+// ContainerRuntime contains the operations performed on the containers through the container runtime of the node
+type ContainerRuntime interface {
+	// Inspect returns the details of the container
+	Inspect(containerID string) (ContainerInfo, error)
+	// PID returns the pid of the main process of the container
+	PID(containerID string) (int, error)
+	// Stop stops the container gracefully, it is killed if it doesn't stop within the timeout
+	Stop(containerID string, timeout time.Duration) error
+	// Kill sends the signal to the main process of the container
+	Kill(containerID string, signal syscall.Signal) error
+	// NetnsPath returns the path of the network namespace of the container
+	NetnsPath(containerID string) (string, error)
+	// Close release the connection with the container runtime
+	Close() error
+}
+
+// ContainerInfo contains the details of the container
+type ContainerInfo struct {
+	ID        string
+	Name      string
+	PID       int
+	Running   bool
+	NetnsPath string
+}
+
+// New returns the container runtime client for the given runtime
+// it supports docker, containerd and crio runtimes
+func New(runtime, socketPath string) (ContainerRuntime, error) {
+	switch runtime {
+	case "docker":
+		return newDockerRuntime(socketPath), nil
+	case "containerd":
+		return newContainerdRuntime(socketPath)
+	case "crio":
+		return newCRIRuntime(socketPath)
+	default:
+		return nil, errors.Errorf("%v container runtime not suported", runtime)
+	}
+}
+
+// GetPID returns the pid of the target container
+func GetPID(runtime, containerID, socketPath string) (int, error) {
+	containerRuntime, err := New(runtime, socketPath)
+	if err != nil {
+		return 0, err
+	}
+	defer containerRuntime.Close()
+	return containerRuntime.PID(containerID)
+}
+
+// getPIDFromInfo returns the pid from the container details
+// it returns error, if the container is not running
+func getPIDFromInfo(info ContainerInfo) (int, error) {
+	if !info.Running || info.PID == 0 {
+		return 0, errors.Errorf("no running target container found, containerID: %v", info.ID)
+	}
+	return info.PID, nil
+}
+
+// procNetnsPath returns the path of the network namespace of the given pid
+func procNetnsPath(pid int) string {
+	return "/proc/" + strconv.Itoa(pid) + "/ns/net"
+}
+
+// dialUnix creates the grpc connection with the given unix socket
+func dialUnix(socketPath string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, socketPath,
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", addr)
+		}),
+	)
+	if err != nil {
+		return nil, errors.Errorf("unable to connect with %v socket, err: %v", socketPath, err)
+	}
+	return conn, nil
+}